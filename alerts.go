@@ -0,0 +1,103 @@
+package boc
+
+import "math"
+
+// Condition is a predicate over an observation and the observation of the
+// previous date with data, which is nil for the first date
+type Condition func(obs, previous *Observations) bool
+
+// AlertEvent is passed to an alert handler when a refresh satisfies its condition
+type AlertEvent struct {
+	// Name is the name the alert was registered with
+	Name string
+	// Date is the date of the observation that satisfied the condition
+	Date        string
+	Observation *Observations
+	// Previous is the observation of the previous date with data, nil if none
+	Previous *Observations
+}
+
+type alert struct {
+	name      string
+	condition Condition
+	handler   func(AlertEvent)
+}
+
+// Above is satisfied when a series is above threshold, in percent
+func Above(seriesKey string, threshold float64) Condition {
+	return func(obs, _ *Observations) bool {
+		v, ok := obs.Value(seriesKey)
+		return ok && v > threshold
+	}
+}
+
+// Below is satisfied when a series is below threshold, in percent
+func Below(seriesKey string, threshold float64) Condition {
+	return func(obs, _ *Observations) bool {
+		v, ok := obs.Value(seriesKey)
+		return ok && v < threshold
+	}
+}
+
+// MovedBy is satisfied when a series moved by at least bps basis points, up
+// or down, since the previous date with data
+func MovedBy(seriesKey string, bps float64) Condition {
+	return func(obs, previous *Observations) bool {
+		if previous == nil {
+			return false
+		}
+		v, ok := obs.Value(seriesKey)
+		prev, okPrev := previous.Value(seriesKey)
+		return ok && okPrev && math.Abs(float64(ToBps(v-prev))) >= bps
+	}
+}
+
+// SpreadAbove is satisfied when seriesA minus seriesB is above bps basis points
+func SpreadAbove(seriesA, seriesB string, bps float64) Condition {
+	return func(obs, _ *Observations) bool {
+		spread, ok := spreadBps(obs, seriesA, seriesB)
+		return ok && spread > bps
+	}
+}
+
+// SpreadBelow is satisfied when seriesA minus seriesB is below bps basis
+// points, e.g. SpreadBelow(SeriesYield10Year, SeriesYield2Year, 0) for an inverted curve
+func SpreadBelow(seriesA, seriesB string, bps float64) Condition {
+	return func(obs, _ *Observations) bool {
+		spread, ok := spreadBps(obs, seriesA, seriesB)
+		return ok && spread < bps
+	}
+}
+
+func spreadBps(obs *Observations, seriesA, seriesB string) (float64, bool) {
+	a, ok := obs.Value(seriesA)
+	if !ok {
+		return 0, false
+	}
+	b, ok := obs.Value(seriesB)
+	if !ok {
+		return 0, false
+	}
+	return float64(ToBps(a - b)), true
+}
+
+// checkAlerts evaluates the alerts on the dates added or revised by a refresh, in chronological order
+func (b *bocInterests) checkAlerts(ds *dataset, diff Diff) {
+	if len(b.alerts) == 0 {
+		return
+	}
+	for _, date := range diff.changedDates() {
+		i, _ := ds.index(date)
+		obs := ds.observation(i)
+		var previous *Observations
+		if i > 0 {
+			previous = ds.observation(i - 1)
+		}
+		for _, a := range b.alerts {
+			if a.condition(obs, previous) {
+				b.logger.Info("alert triggered", "alert", a.name, "date", date)
+				a.handler(AlertEvent{Name: a.name, Date: date, Observation: obs, Previous: previous})
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package boc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditions(t *testing.T) {
+	previous := &Observations{D: "2022-06-01", Yield2Year: Val{V: "2.73"}, Yield10Year: Val{V: "2.97"}}
+	obs := &Observations{D: "2022-06-02", Yield2Year: Val{V: "2.90"}, Yield10Year: Val{V: "2.85"}}
+	tests := []struct {
+		name      string
+		condition Condition
+		previous  *Observations
+		want      bool
+	}{
+		{name: "above", condition: Above(SeriesYield2Year, 2.8), want: true},
+		{name: "not above", condition: Above(SeriesYield2Year, 2.9)},
+		{name: "below", condition: Below(SeriesYield10Year, 2.9), want: true},
+		{name: "not below missing value", condition: Below(SeriesYieldRRB, 2.9)},
+		{name: "moved by", condition: MovedBy(SeriesYield2Year, 15), previous: previous, want: true},
+		{name: "moved down by", condition: MovedBy(SeriesYield10Year, 12), previous: previous, want: true},
+		{name: "not moved by", condition: MovedBy(SeriesYield10Year, 13), previous: previous},
+		{name: "moved by without previous", condition: MovedBy(SeriesYield2Year, 1)},
+		{name: "spread below", condition: SpreadBelow(SeriesYield10Year, SeriesYield2Year, 0), want: true},
+		{name: "spread above", condition: SpreadAbove(SeriesYield2Year, SeriesYield10Year, 4), want: true},
+		{name: "spread not above", condition: SpreadAbove(SeriesYield2Year, SeriesYield10Year, 5)},
+		{name: "spread missing value", condition: SpreadAbove(SeriesYield2Year, SeriesYieldRRB, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.condition(obs, tt.previous))
+		})
+	}
+}
+
+func TestRefreshAlerts(t *testing.T) {
+	a := assert.New(t)
+	data := readFixture(t)
+	srv := newDataServer(t, func() *BOCData { return data })
+
+	events := make([]AlertEvent, 0)
+	handler := func(e AlertEvent) { events = append(events, e) }
+	b := newBOCInterests(
+		WithBaseURL(srv.URL),
+		WithFullRefresh(),
+		WithAlert("inverted", SpreadBelow(SeriesYield10Year, SeriesYield2Year, 0), handler),
+		WithAlert("2y jump", MovedBy(SeriesYield2Year, 10), handler),
+	)
+	a.NoError(b.load(context.Background()))
+	a.NoError(b.Refresh(context.Background()))
+	a.Empty(events, "no alert without change")
+
+	updated := readFixture(t)
+	updated.Observations[2].Yield10Year.V = "2.50"
+	updated.Observations = append(updated.Observations, Observations{D: "2022-06-02", Yield2Year: Val{V: "2.90"}, Yield10Year: Val{V: "2.85"}})
+	data = updated
+
+	a.NoError(b.Refresh(context.Background()))
+	a.Len(events, 3)
+	a.Equal("inverted", events[0].Name)
+	a.Equal("2022-05-25", events[0].Date)
+	a.Equal("2022-05-24", events[0].Previous.D)
+	a.Equal("inverted", events[1].Name)
+	a.Equal("2022-06-02", events[1].Date)
+	a.Equal("2y jump", events[2].Name)
+	a.Equal("2022-06-02", events[2].Date)
+	a.Equal("2.90", events[2].Observation.Yield2Year.V)
+}
@@ -0,0 +1,118 @@
+package boc
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// announcementDates are the scheduled dates of the policy interest rate
+// announcements published by the Bank of Canada. The Bank publishes the
+// schedule of the next year in the fall, without a machine readable source:
+// use NewCalendar to add dates ahead of a release of this package.
+var announcementDates = []string{
+	"2020-01-22", "2020-03-04", "2020-04-15", "2020-06-03", "2020-07-15", "2020-09-09", "2020-10-28", "2020-12-09",
+	"2021-01-20", "2021-03-10", "2021-04-21", "2021-06-09", "2021-07-14", "2021-09-08", "2021-10-27", "2021-12-08",
+	"2022-01-26", "2022-03-02", "2022-04-13", "2022-06-01", "2022-07-13", "2022-09-07", "2022-10-26", "2022-12-07",
+	"2023-01-25", "2023-03-08", "2023-04-12", "2023-06-07", "2023-07-12", "2023-09-06", "2023-10-25", "2023-12-06",
+	"2024-01-24", "2024-03-06", "2024-04-10", "2024-06-05", "2024-07-24", "2024-09-04", "2024-10-23", "2024-12-11",
+	"2025-01-29", "2025-03-12", "2025-04-16", "2025-06-04", "2025-07-30", "2025-09-17", "2025-10-29", "2025-12-10",
+	"2026-01-28", "2026-03-18", "2026-04-29", "2026-06-10", "2026-07-15", "2026-09-02", "2026-10-28", "2026-12-09",
+}
+
+// defaultCalendar holds the embedded announcement dates
+var defaultCalendar = NewCalendar()
+
+// Calendar is a schedule of policy interest rate announcements
+type Calendar struct {
+	dates []time.Time
+	now   func() time.Time
+}
+
+// NewCalendar returns the calendar of the embedded announcement dates and of
+// extra, e.g. the dates of a schedule published after this release
+func NewCalendar(extra ...time.Time) *Calendar {
+	c := &Calendar{now: time.Now}
+	for _, d := range announcementDates {
+		t, _ := time.Parse("2006-01-02", d)
+		c.dates = append(c.dates, t)
+	}
+	for _, t := range extra {
+		c.dates = append(c.dates, truncateDay(t))
+	}
+	sort.Slice(c.dates, func(i, j int) bool { return c.dates[i].Before(c.dates[j]) })
+	dates := c.dates[:0]
+	for i, t := range c.dates {
+		if i == 0 || !t.Equal(c.dates[i-1]) {
+			dates = append(dates, t)
+		}
+	}
+	c.dates = dates
+	return c
+}
+
+// Announcements returns the announcement dates of a year, at midnight UTC
+func (c *Calendar) Announcements(year int) []time.Time {
+	var dates []time.Time
+	for _, t := range c.dates {
+		if t.Year() == year {
+			dates = append(dates, t)
+		}
+	}
+	return dates
+}
+
+// NextAnnouncement returns the first announcement date strictly after the day
+// of after, and false if it is beyond the known schedule
+func (c *Calendar) NextAnnouncement(after time.Time) (time.Time, bool) {
+	day := truncateDay(after)
+	i := sort.Search(len(c.dates), func(i int) bool { return c.dates[i].After(day) })
+	if i == len(c.dates) {
+		return time.Time{}, false
+	}
+	return c.dates[i], true
+}
+
+// WriteICS writes the announcements from the day of from onward as an
+// iCalendar document of all-day events
+func (c *Calendar) WriteICS(w io.Writer, from time.Time) error {
+	day := truncateDay(from)
+	stamp := c.now().UTC().Format("20060102T150405Z")
+	var sb strings.Builder
+	line := func(format string, args ...any) {
+		fmt.Fprintf(&sb, format+"\r\n", args...)
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//bank-of-canada-interests-rates//announcements//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:Bank of Canada rate announcements")
+	for _, t := range c.dates {
+		if t.Before(day) {
+			continue
+		}
+		line("BEGIN:VEVENT")
+		line("UID:%s@bank-of-canada-interests-rates", t.Format("20060102"))
+		line("DTSTAMP:%s", stamp)
+		line("DTSTART;VALUE=DATE:%s", t.Format("20060102"))
+		line("DTEND;VALUE=DATE:%s", t.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:Bank of Canada interest rate announcement")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Announcements returns the embedded announcement dates of a year
+func Announcements(year int) []time.Time {
+	return defaultCalendar.Announcements(year)
+}
+
+// NextAnnouncement returns the first embedded announcement date strictly after
+// the day of after, and false if it is beyond the embedded schedule
+func NextAnnouncement(after time.Time) (time.Time, bool) {
+	return defaultCalendar.NextAnnouncement(after)
+}
@@ -0,0 +1,77 @@
+package boc
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnouncements(t *testing.T) {
+	dates := Announcements(2022)
+	require.Len(t, dates, 8)
+	assert.Equal(t, date(2022, time.January, 26), dates[0])
+	assert.Equal(t, date(2022, time.December, 7), dates[7])
+	for _, year := range []int{2020, 2021, 2023, 2024, 2025, 2026} {
+		for _, d := range Announcements(year) {
+			assert.Equal(t, time.Wednesday, d.Weekday(), d)
+		}
+	}
+	assert.Empty(t, Announcements(2010))
+}
+
+func TestNextAnnouncement(t *testing.T) {
+	tests := []struct {
+		after  time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		{after: date(2022, time.May, 24), want: date(2022, time.June, 1), wantOK: true},
+		{after: time.Date(2022, time.June, 1, 9, 0, 0, 0, time.UTC), want: date(2022, time.July, 13), wantOK: true},
+		{after: date(2022, time.December, 31), want: date(2023, time.January, 25), wantOK: true},
+		{after: date(2026, time.December, 9)},
+	}
+	for _, tt := range tests {
+		got, ok := NextAnnouncement(tt.after)
+		assert.Equal(t, tt.wantOK, ok, tt.after)
+		assert.Equal(t, tt.want, got, tt.after)
+	}
+
+	c := NewCalendar(time.Date(2027, time.January, 27, 10, 0, 0, 0, time.UTC), date(2026, time.December, 9))
+	got, ok := c.NextAnnouncement(date(2026, time.December, 9))
+	assert.True(t, ok)
+	assert.Equal(t, date(2027, time.January, 27), got)
+	assert.Len(t, c.Announcements(2026), 8, "duplicates are ignored")
+}
+
+func TestWriteICS(t *testing.T) {
+	c := NewCalendar()
+	c.now = func() time.Time { return time.Date(2026, time.October, 14, 12, 0, 0, 0, time.UTC) }
+	var sb strings.Builder
+	require.NoError(t, c.WriteICS(&sb, date(2026, time.October, 28)))
+	assert.Equal(t, strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//bank-of-canada-interests-rates//announcements//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:Bank of Canada rate announcements",
+		"BEGIN:VEVENT",
+		"UID:20261028@bank-of-canada-interests-rates",
+		"DTSTAMP:20261014T120000Z",
+		"DTSTART;VALUE=DATE:20261028",
+		"DTEND;VALUE=DATE:20261029",
+		"SUMMARY:Bank of Canada interest rate announcement",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:20261209@bank-of-canada-interests-rates",
+		"DTSTAMP:20261014T120000Z",
+		"DTSTART;VALUE=DATE:20261209",
+		"DTEND;VALUE=DATE:20261210",
+		"SUMMARY:Bank of Canada interest rate announcement",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n"), sb.String())
+}
@@ -0,0 +1,31 @@
+package boc
+
+// defaultTermsURL is the terms of use of the Bank of Canada, used when the
+// response did not include them
+const defaultTermsURL = "https://www.bankofcanada.ca/terms/"
+
+// Attribution is the citation required by the terms of use of the Bank of
+// Canada when its data is republished
+type Attribution struct {
+	// Text is the source citation, in the language of the client
+	Text string
+	// TermsURL is the URL of the terms of use, as published in Terms
+	TermsURL string
+}
+
+// String returns the citation followed by the terms of use
+func (a Attribution) String() string {
+	return a.Text + " " + a.TermsURL
+}
+
+// Attribution implements BOCInterests
+func (b *bocInterests) Attribution() Attribution {
+	a := Attribution{Text: "Source: Bank of Canada.", TermsURL: b.Terms().URL}
+	if b.language == French {
+		a.Text = "Source : Banque du Canada."
+	}
+	if a.TermsURL == "" {
+		a.TermsURL = defaultTermsURL
+	}
+	return a
+}
@@ -0,0 +1,26 @@
+package boc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttribution(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	a.Equal(Attribution{Text: "Source : Banque du Canada.", TermsURL: "https://www.bankofcanada.ca/terms/"}, b.Attribution())
+
+	srv := newTestServer(t, http.StatusOK)
+	b = newBOCInterests(WithLanguage(English), WithBaseURL(srv.URL))
+	require.NoError(t, b.load(context.Background()))
+	a.Equal("Source: Bank of Canada. https://www.bankofcanada.ca/terms/", b.Attribution().String())
+
+	data := readFixture(t)
+	data.Terms.URL = ""
+	b.ds.Store(b.newDataset(context.Background(), data))
+	a.Equal(defaultTermsURL, b.Attribution().TermsURL)
+}
@@ -0,0 +1,63 @@
+package boc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BatchError is returned by GetObservationsForDates when some of the dates
+// could not be looked up. errors.Is and errors.As match the error of any date.
+type BatchError struct {
+	// Errors are the errors by date, as given by the caller
+	Errors map[string]error
+}
+
+// Error implements error
+func (e *BatchError) Error() string {
+	dates := make([]string, 0, len(e.Errors))
+	for date := range e.Errors {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	msgs := make([]string, 0, len(dates))
+	for _, date := range dates {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", date, e.Errors[date]))
+	}
+	return fmt.Sprintf("%d of the dates failed: %s", len(dates), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the dates
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// GetObservationsForDates implements BOCInterests
+func (b *bocInterests) GetObservationsForDates(dates []string, opts ...QueryOption) (map[string]*Observations, error) {
+	q := newQuery(opts)
+	ds, err := b.fresh(q.context())
+	if err != nil {
+		return nil, err
+	}
+	observations := make(map[string]*Observations, len(dates))
+	var errs map[string]error
+	for _, date := range dates {
+		obs, err := b.observationFor(ds, date, q)
+		if err != nil {
+			if errs == nil {
+				errs = make(map[string]error)
+			}
+			errs[date] = err
+			continue
+		}
+		observations[date] = obs
+	}
+	if errs != nil {
+		return observations, &BatchError{Errors: errs}
+	}
+	return observations, nil
+}
@@ -0,0 +1,39 @@
+package boc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetObservationsForDates(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	observations, err := b.GetObservationsForDates([]string{"2022-05-24", "27-05-2022"})
+	require.NoError(t, err)
+	a.Len(observations, 2)
+	a.Equal("2.57", observations["2022-05-24"].Yield2Year.V)
+	a.Equal("2022-05-27", observations["27-05-2022"].D)
+
+	observations, err = b.GetObservationsForDates([]string{"2022-05-24", "2022-05-23", "foo"})
+	a.Len(observations, 1)
+	var batchErr *BatchError
+	require.True(t, errors.As(err, &batchErr))
+	a.Len(batchErr.Errors, 2)
+	a.ErrorIs(batchErr.Errors["2022-05-23"], ErrNoData)
+	a.ErrorIs(batchErr.Errors["foo"], ErrInvalidDate)
+	a.ErrorIs(err, ErrNoData)
+	a.ErrorIs(err, ErrInvalidDate)
+	a.Contains(err.Error(), "2 of the dates failed: 2022-05-23: no data for this date")
+
+	var dataErr *DataError
+	require.True(t, errors.As(batchErr.Errors["2022-05-23"], &dataErr))
+	a.Equal("2022-05-24", dataErr.NearestDate)
+
+	observations, err = b.GetObservationsForDates([]string{"2022-05-23"}, ForwardFill())
+	require.NoError(t, err)
+	a.Equal("2.59", observations["2022-05-23"].Yield2Year.V)
+}
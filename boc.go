@@ -1,80 +1,357 @@
 package boc
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
+	"log/slog"
+	"maps"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/clauderoy790/bank-of-canada-interests-rates/cache"
 )
 
-const bocDataLink = "https://www.banqueducanada.ca/valet/observations/group/bond_yields_all/json"
+// ObservationReader reads the observations of the data in use
+type ObservationReader interface {
+	GetObservationForDate(date string, opts ...QueryOption) (*Observations, error)
+	// Today returns the observation of the current date in Toronto, whatever the
+	// time zone of the machine, see TorontoDay
+	Today(opts ...QueryOption) (*Observations, error)
+	// Yesterday returns the observation of the day before the current date in Toronto
+	Yesterday(opts ...QueryOption) (*Observations, error)
+	// GetObservationsForDates looks up several dates at once, returning the
+	// observations by date as given. The dates that failed are reported in a
+	// *BatchError, the others are still returned.
+	GetObservationsForDates(dates []string, opts ...QueryOption) (map[string]*Observations, error)
+	// GetObservationsForPeriod returns the observations of a year like "2023" or
+	// of a month like "2023-05", sorted by ascending date
+	GetObservationsForPeriod(period string) ([]Observations, error)
+	// All returns the observations in chronological order
+	All() iter.Seq2[string, *Observations]
+	// Between returns the observations from start to end inclusively, in chronological order.
+	// An empty start or end leaves that side of the range open.
+	Between(start, end string, opts ...QueryOption) (iter.Seq2[string, *Observations], error)
+	// Observations returns a copy of all the observations sorted by ascending date
+	Observations() []Observations
+	// Records returns all the observations as records sorted by ascending date,
+	// with the series Observations has no field for
+	Records() []Record
+	// RecordFor returns the observation of a formatted date, e.g. "2022-06-01",
+	// as a Record, and false if the date has no data
+	RecordFor(date string) (Record, bool)
+	// SeriesValue returns the value of a series for a date
+	SeriesValue(date, seriesKey string, opts ...QueryOption) (float64, error)
+	// PreviousValue returns the last published value of a series strictly before a date,
+	// skipping the weekends, holidays and missing values
+	PreviousValue(seriesKey, date string) (Point, error)
+	// TimeSeries returns the dates and the values of a series from start to end,
+	// skipping the dates without value, e.g. for gonum/stat
+	TimeSeries(seriesKey, start, end string, opts ...QueryOption) ([]time.Time, []float64, error)
+	// Query starts building a selection of series over a range of dates
+	Query() *Query
+}
 
-type BOCInterests interface {
-	GetObservationForDate(date string) (*Observations, error)
+// MetadataReader reads the metadata of the group and of its series
+type MetadataReader interface {
 	GroupDetail() GroupDetail
 	Terms() Terms
 	SeriesDetail() SeriesDetail
+	// SeriesInfo returns the details of a series by key
+	SeriesInfo(key string) (Detail, error)
+	// SeriesDetails returns the details of all the series, by key
+	SeriesDetails() map[string]Detail
+	Language() Language
+	// Attribution returns the source citation required to republish the data
+	Attribution() Attribution
+	// Staleness reports how fresh the data in use is, see WithStaleIfError
+	Staleness() Staleness
+	// LastUpdated returns when the Bank of Canada last updated the data in use,
+	// from the Last-Modified or Date header of the response, zero if unknown
+	LastUpdated() time.Time
+	// LatestDate returns the date of the latest observation, empty if there is none
+	LatestDate() string
+	// Stats reports the size of the data in use and counts the fetches and the cache hits
+	Stats() Stats
+	// Metadata describes the Bank of Canada as a RateSource
+	Metadata() SourceMetadata
 }
 
+// Refresher updates the data in use
+type Refresher interface {
+	// Refresh fetches the observations since the latest known date and merges them
+	// into the data in use, or fetches all the data again with WithFullRefresh
+	Refresh(ctx context.Context) error
+	// Ping requests the metadata of the group, a small response, to check that
+	// the Valet API is reachable, e.g. for a readiness probe. It is not retried.
+	Ping(ctx context.Context) error
+}
+
+// Reader reads the observations and the metadata of the data in use, e.g. for Summarize
+type Reader interface {
+	ObservationReader
+	MetadataReader
+}
+
+// BOCInterests is the client of the bond yields. Depend on ObservationReader,
+// MetadataReader or Refresher when only a part of it is needed. The analytics
+// are functions of an ObservationReader, e.g. YieldCurve or Volatility.
+type BOCInterests interface {
+	ObservationReader
+	MetadataReader
+	Refresher
+	// ImportCSV merges the observations of a CSV, e.g. written by ResultSet.WriteCSV,
+	// into the data in use and the storage, and returns the number of rows read.
+	// The first column holds the dates, read according to layout, e.g. "DD/MM/YYYY",
+	// or as the other dates given to the client if layout is empty; the header
+	// names the series of the other columns. For the dates already known, only
+	// the missing values are filled. An import waits for a Refresh in progress.
+	ImportCSV(r io.Reader, layout string) (int, error)
+	// Save writes a binary snapshot of the data, to be loaded with LoadSnapshot
+	Save(w io.Writer) error
+	// Fetch fetches observations with the ordering and limits of the Valet API,
+	// e.g. the most recent first, without changing the data in use. It also
+	// returns the payload as fetched, which RawJSON does not return.
+	Fetch(ctx context.Context, opts ...FetchOption) ([]Observations, []byte, error)
+	// RawJSON returns a copy of the last payload of the data in use fetched from
+	// the Valet API or read from the cache, nil if none, to read the fields the
+	// types do not model. The observations of the incremental refreshes and of
+	// the chunks of WithChunkedFetch are merged into it.
+	RawJSON() []byte
+	// RawMap returns the last payload, see RawJSON, decoded as a generic map
+	RawMap() (map[string]any, error)
+	// LongRun fetches the long-run monthly series of a benchmark series, see
+	// LegacySeries, and returns it stitched with the daily data into one
+	// chronological series: the monthly values, dated the first of the month,
+	// until the first month of the daily data, then the daily values
+	LongRun(ctx context.Context, seriesKey string) ([]Point, error)
+}
+
+var _ BOCInterests = (*bocInterests)(nil)
+
 type bocInterests struct {
-	data         *BOCData
-	observations map[string]*Observations
-	url          string
+	ds           atomic.Pointer[dataset]
+	baseURL      string
+	group        string
+	language     Language
+	logger       *slog.Logger
+	tracer       trace.Tracer
+	hooks        Hooks
+	httpClient   *http.Client
+	timeout      time.Duration
+	hedgeDelay   time.Duration
+	breaker      *breaker
+	staleIfError bool
+	maxStaleness time.Duration
+	staleErrors  bool
+	staleMu      sync.Mutex
+	// updateMu serializes the updates of the data in use, by Refresh and ImportCSV
+	updateMu sync.Mutex
+	// staleAttempt and staleErr are the time and the error of the last refresh
+	// of stale data by fresh, guarded by staleMu
+	staleAttempt    time.Time
+	staleErr        error
+	refreshErr      atomic.Pointer[error]
+	raw             atomic.Pointer[[]byte]
+	counters        counters
+	unknown         []func(date, seriesKey string, value Val)
+	progress        func(Progress)
+	driftHandlers   []func(SchemaDrift)
+	retries         int
+	backoff         time.Duration
+	retryAfterLimit time.Duration
+	diffHandlers    []func(Diff)
+	alerts          []alert
+	sinks           []Sink
+	cache           cache.Store
+	cacheTTL        time.Duration
+	storage         Storage
+	fullRefresh     bool
+	chunkStart      int
+	chunkWorkers    int
+	groupWorkers    int
+	dateLayout      string
+	strictDates     bool
+	// now returns the current time, time.Now unless replaced in tests
+	now     func() time.Time
+	series  []string
+	legacy  map[string]string
+	headers http.Header
 }
 
 // NewBOCInterests provides an interface to get the interests data from Bank of Canada
-func NewBOCInterests() (BOCInterests, error) {
-	boc := new(bocInterests)
-	boc.url = bocDataLink
-	if err := boc.fetchData(); err != nil {
+func NewBOCInterests(opts ...Option) (BOCInterests, error) {
+	return NewBOCInterestsWithContext(context.Background(), opts...)
+}
+
+// NewBOCInterestsWithContext is like NewBOCInterests but fetches the data with the given context
+func NewBOCInterestsWithContext(ctx context.Context, opts ...Option) (BOCInterests, error) {
+	boc := newBOCInterests(opts...)
+	for _, key := range boc.series {
+		if _, ok := seriesColumns[key]; !ok {
+			return nil, &DataError{Series: key, Err: ErrUnknownSeries}
+		}
+	}
+	if boc.storage != nil {
+		ok, err := boc.loadStored(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error reading storage: %w", err)
+		}
+		if ok {
+			return boc, nil
+		}
+	}
+	if err := boc.load(ctx); err != nil {
 		return nil, fmt.Errorf("error fetching data: %w", err)
 	}
-	boc.setObservationsMap()
 	return boc, nil
 }
 
+func newBOCInterests(opts ...Option) *bocInterests {
+	boc := new(bocInterests)
+	boc.language = French
+	boc.baseURL = French.baseURL()
+	boc.group = bondYieldsGroup
+	boc.logger = discardLogger()
+	boc.tracer = noopTracer()
+	boc.httpClient = http.DefaultClient
+	boc.timeout = defaultTimeout
+	boc.now = time.Now
+	for _, opt := range opts {
+		opt(boc)
+	}
+	return boc
+}
+
+// current returns the dataset in use
+func (b *bocInterests) current() *dataset {
+	return b.ds.Load()
+}
+
+// load fetches the data and makes it the dataset in use
+func (b *bocInterests) load(ctx context.Context) error {
+	data, err := b.fetchAll(ctx)
+	if err != nil {
+		return err
+	}
+	data.FetchedAt = b.now()
+	ds := b.newDataset(ctx, data)
+	b.ds.Store(ds)
+	b.store(ctx, data)
+	return nil
+}
+
 // GroupDetail implements BOCInterests
 func (b *bocInterests) GroupDetail() GroupDetail {
-	return b.data.GroupDetail
+	return b.current().meta.GroupDetail
 }
 
 // Terms implements BOCInterests
 func (b *bocInterests) Terms() Terms {
-	return b.data.Terms
+	return b.current().meta.Terms
 }
 
 // SeriesDetail implements BOCInterests
 func (b *bocInterests) SeriesDetail() SeriesDetail {
-	return b.data.SeriesDetail
+	return b.current().meta.SeriesDetail
 }
 
-func (b *bocInterests) setObservationsMap() {
-	m := make(map[string]*Observations)
-	for _, obs := range b.data.Observations {
-		obs := obs
-		m[obs.D] = &obs
+// SeriesInfo implements BOCInterests
+func (b *bocInterests) SeriesInfo(key string) (Detail, error) {
+	detail, ok := b.current().details[key]
+	if !ok {
+		return Detail{}, &DataError{Series: key, Err: ErrUnknownSeries}
 	}
-	b.observations = m
+	return detail, nil
+}
+
+// SeriesDetails implements BOCInterests
+func (b *bocInterests) SeriesDetails() map[string]Detail {
+	return maps.Clone(b.current().details)
+}
+
+// newDataset stores data in columns indexed by date
+func (b *bocInterests) newDataset(ctx context.Context, data *BOCData) *dataset {
+	_, span := b.tracer.Start(ctx, "boc.index")
+	defer span.End()
+	ds, duplicates := buildDataset(data)
+	for _, date := range duplicates {
+		b.logger.Warn("duplicate observation date, keeping the last one", "date", date)
+	}
+	span.SetAttributes(attribute.Int("boc.observations", len(ds.dates)))
+	return ds
 }
 
 // GetObservationForDate implements BOCInterests
-func (b *bocInterests) GetObservationForDate(date string) (*Observations, error) {
-	date, err := FormatDate(date)
+func (b *bocInterests) GetObservationForDate(date string, opts ...QueryOption) (*Observations, error) {
+	q := newQuery(opts)
+	ds, err := b.fresh(q.context())
+	if err != nil {
+		return nil, err
+	}
+	return b.observationFor(ds, date, q)
+}
 
+// observationFor returns the observation of date in ds
+func (b *bocInterests) observationFor(ds *dataset, date string, q *query) (*Observations, error) {
+	formatted, err := b.formatDate(date)
 	if err != nil {
-		return nil, fmt.Errorf("invalid date format: %s", date)
+		return nil, err
+	}
+	return observationAt(ds, formatted, q)
+}
+
+// observationAt returns the observation of a formatted date in ds
+func observationAt(ds *dataset, formatted string, q *query) (*Observations, error) {
+	if q.interpolate {
+		if obs := ds.interpolatedObservation(formatted); obs != nil {
+			return obs, nil
+		}
+	} else if q.forwardFill {
+		if obs := ds.filledObservation(formatted); obs != nil {
+			return obs, nil
+		}
+	}
+	i, ok := ds.index(formatted)
+	if !ok {
+		return nil, &DataError{Date: formatted, NearestDate: ds.nearestDate(formatted), Err: noDataError(formatted)}
 	}
-	if b.observations[date] == nil {
-		return nil, fmt.Errorf("no data for this date: %s", date)
+	return ds.observation(i), nil
+}
+
+// Observations implements BOCInterests
+func (b *bocInterests) Observations() []Observations {
+	ds := b.current()
+	observations := make([]Observations, 0, len(ds.dates))
+	for i := range ds.dates {
+		observations = append(observations, *ds.observation(i))
 	}
-	return b.observations[date], nil
+	return observations
 }
 
-// FormatDate formats a date string according to what is expected for boc's data
+// FormatDate formats a date string according to what is expected for boc's data.
+// It accepts three numeric parts separated by "-", "/" or "\\" in any order,
+// YYYYMMDD, dates with a month name like "Jan 2, 2024" or "2 January 2024",
+// and timestamps like "2024-01-02T15:04:05Z" whose time is ignored.
 func FormatDate(date string) (string, error) {
+	return formatDate(date, false)
+}
+
+func formatDate(date string, strict bool) (string, error) {
 	date = strings.TrimSpace(date)
+	if formatted, ok := formatSpecialDate(date); ok {
+		return formatted, nil
+	}
 	separator := ""
 	if strings.Contains(date, "-") {
 		separator = "-"
@@ -134,6 +411,9 @@ func FormatDate(date string) (string, error) {
 		month = digits[0]
 		day = digits[1]
 	} else if yearInd == 2 {
+		if strict && digits[0] != digits[1] {
+			return "", fmt.Errorf("%w: %s", ErrAmbiguousDate, date)
+		}
 		if digits[0] > digits[1] {
 			month = digits[1]
 			day = digits[0]
@@ -146,42 +426,153 @@ func FormatDate(date string) (string, error) {
 	if year == 0 || month == 0 || day == 0 {
 		return "", fmt.Errorf("invalid format: %s", date)
 	}
-	if month > 12 || month < 0 {
-		return "", fmt.Errorf("invalid month: %d", month)
-	}
+	return validDate(year, month, day)
+}
 
-	if day > 31 || day < 0 {
-		return "", fmt.Errorf("invalid day: %d", day)
+// fetchData fetches url and keeps its payload for RawJSON
+func (b *bocInterests) fetchData(ctx context.Context, url string) (*BOCData, error) {
+	data, raw, err := b.fetchPayload(ctx, url)
+	if err != nil {
+		return nil, err
 	}
-	return fmt.Sprintf("%04d-%02d-%02d", year, month, day), nil
+	b.raw.Store(&raw)
+	return data, nil
 }
 
-func (b *bocInterests) fetchData() error {
-	resp, err := http.Get(bocDataLink)
-	if err != nil {
-		return fmt.Errorf("error fetching data: %w", err)
+// fetchPayload fetches url and returns its data and its payload
+func (b *bocInterests) fetchPayload(ctx context.Context, url string) (*BOCData, []byte, error) {
+	var data *BOCData
+	var payload []byte
+	err := b.fetch(ctx, url, func(ctx context.Context, raw []byte, header http.Header) (int, error) {
+		d, err := b.decode(ctx, url, raw)
+		if err != nil {
+			return 0, err
+		}
+		d.LastModified = lastModified(header)
+		b.logParseWarnings(d)
+		b.checkSchema(d)
+		data, payload = d, raw
+		return len(d.Observations), nil
+	})
+	return data, payload, err
+}
+
+// fetch downloads url, or reads it from the cache, and decodes it with decode,
+// which returns the number of observations decoded. The header of the response
+// is nil when it was served from the cache.
+func (b *bocInterests) fetch(ctx context.Context, url string, decode func(context.Context, []byte, http.Header) (int, error)) (err error) {
+	ctx, span := b.tracer.Start(ctx, "boc.fetch", trace.WithAttributes(attribute.String("http.url", url)))
+	defer func() { endSpan(span, err) }()
+	defer func() { b.counters.record(err) }()
+
+	if raw, ok := b.cachedData(ctx, url); ok {
+		n, err := decode(ctx, raw, nil)
+		if err == nil {
+			b.logger.Info("fetch served from cache", "url", url, "observations", n)
+			span.SetAttributes(attribute.Bool("boc.cache_hit", true))
+			return nil
+		}
+		b.logger.Warn("cached data is invalid", "url", url, "error", err)
 	}
-	respData, err := io.ReadAll(resp.Body)
-	defer resp.Body.Close()
+
+	if err := b.breaker.allow(); err != nil {
+		b.logger.Warn("fetch skipped", "url", url, "error", err)
+		return &DataError{Err: err}
+	}
+	defer func() {
+		if ctx.Err() != nil {
+			// cancelled by the caller, it says nothing about the Valet API
+			b.breaker.release()
+			return
+		}
+		b.breaker.record(err == nil)
+	}()
+
+	start := time.Now()
+	b.logger.Info("fetch start", "url", url)
+	resp, respData, err := b.download(ctx, url)
+	if resp == nil {
+		b.logger.Error("fetch failed", "url", url, "error", err)
+		return &DataError{Err: fmt.Errorf("error fetching data: %w", err)}
+	}
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode), attribute.Int("http.response_size", len(respData)))
 	if err != nil {
-		return fmt.Errorf("error reading body data")
+		return &DataError{StatusCode: resp.StatusCode, Err: err}
 	}
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("invalid Response code: %v\n\nResp data: %v", resp.StatusCode, string(respData))
+		err := statusError(resp, respData)
+		b.logger.Error("fetch failed", "url", url, "status", resp.StatusCode, "retry_after", err.RetryAfter)
+		return err
 	}
-	jsonData := new(BOCData)
-	if err = json.Unmarshal(respData, jsonData); err != nil {
-		return fmt.Errorf("failed to parse json data")
+	n, err := decode(ctx, respData, resp.Header)
+	if err != nil {
+		b.logger.Error("failed to parse json data", "url", url, "error", err)
+		return &DataError{StatusCode: resp.StatusCode, Snippet: snippet(respData), Err: fmt.Errorf("failed to parse json data: %w", err)}
 	}
-	b.data = jsonData
+	b.logger.Info("fetch finished", "url", url, "status", resp.StatusCode, "bytes", len(respData),
+		"observations", n, "duration", time.Since(start))
+	b.cacheData(ctx, url, respData)
 	return nil
 }
 
+func (b *bocInterests) decode(ctx context.Context, url string, respData []byte) (data *BOCData, err error) {
+	_, span := b.tracer.Start(ctx, "boc.decode")
+	defer func() { endSpan(span, err) }()
+
+	if data, err = decodeData(ctx, bytes.NewReader(respData), b.unknownSeriesFunc(), b.decodeProgress(url, len(respData))); err != nil {
+		return nil, err
+	}
+	if b.progress != nil {
+		size := int64(len(respData))
+		b.progress(Progress{URL: url, BytesRead: size, TotalBytes: size, Observations: len(data.Observations), Done: true})
+	}
+	span.SetAttributes(attribute.Int("boc.observations", len(data.Observations)))
+	return data, nil
+}
+
+// unknownSeriesFunc returns the function calling the handlers of WithUnknownSeries, nil if there is none
+func (b *bocInterests) unknownSeriesFunc() unknownFunc {
+	if len(b.unknown) == 0 {
+		return nil
+	}
+	return func(date, key string, raw json.RawMessage) {
+		var v Val
+		if err := json.Unmarshal(raw, &v); err != nil {
+			b.logger.Warn("unknown series has an invalid value", "date", date, "series", key, "error", err)
+			return
+		}
+		for _, handler := range b.unknown {
+			handler(date, key, v)
+		}
+	}
+}
+
+// logParseWarnings logs observations that were decoded but look suspicious
+func (b *bocInterests) logParseWarnings(data *BOCData) {
+	if len(data.Observations) == 0 {
+		b.logger.Warn("no observations in response")
+	}
+	for _, obs := range data.Observations {
+		if _, err := time.Parse("2006-01-02", obs.D); err != nil {
+			b.logger.Warn("observation has an invalid date", "date", obs.D)
+		}
+	}
+}
+
 type BOCData struct {
-	GroupDetail  GroupDetail    `json:"groupDetail"`
-	Terms        Terms          `json:"terms"`
-	SeriesDetail SeriesDetail   `json:"seriesDetail"`
-	Observations []Observations `json:"observations"`
+	GroupDetail  GroupDetail  `json:"groupDetail"`
+	Terms        Terms        `json:"terms"`
+	SeriesDetail SeriesDetail `json:"seriesDetail"`
+	// SeriesDetails indexes the details of every series of the response by key,
+	// including the series without a field in SeriesDetail
+	SeriesDetails map[string]Detail `json:"-"`
+	Observations  []Observations    `json:"observations"`
+	// FetchedAt is when the data was fetched from the Valet API, zero if unknown
+	FetchedAt time.Time `json:"-"`
+	// LastModified is when the Bank of Canada last updated the data, from the
+	// Last-Modified header of the response or else its Date, zero if unknown,
+	// e.g. when the payload was read from the cache
+	LastModified time.Time `json:"-"`
 }
 
 type Observations struct {
@@ -197,6 +588,9 @@ type Observations struct {
 	AverageOver10Year Val    `json:"CDN.AVG.OVER.10.AVG,omitempty"`
 	Yield5Year        Val    `json:"BD.CDN.5YR.DQ.YLD,omitempty"`
 	YieldLong         Val    `json:"BD.CDN.LONG.DQ.YLD,omitempty"`
+	// Extra are the values of the series that have no field, by series key,
+	// e.g. a series added to the group by the Bank of Canada. See Record.
+	Extra map[string]Val `json:"-"`
 }
 type SeriesDetail struct {
 	Average1To3Year   Detail `json:"CDN.AVG.1YTO3Y.AVG"`
@@ -220,8 +614,13 @@ type Detail struct {
 	Label       string    `json:"label"`
 	Description string    `json:"description"`
 	Dimension   Dimension `json:"dimension"`
+	// Unit is the unit of the values, see SeriesUnit. The Valet API does not
+	// publish it in the group responses, it is set for the known series.
+	Unit string `json:"unit,omitempty"`
 }
 
+// Dimension is the dimension the observations of a series are indexed by,
+// the date ("d") for all the series of the Valet API
 type Dimension struct {
 	Key  string `json:"key"`
 	Name string `json:"name"`
@@ -232,61 +631,3 @@ type Terms struct {
 type Val struct {
 	V string `json:"v"`
 }
-
-func hasSameData(bocAll, boc bocInterests) error {
-	mAll := make(map[string]*Observations)
-	for _, obs := range bocAll.observations {
-		mAll[obs.D] = obs
-	}
-	m := make(map[string]*Observations)
-	for _, obs := range boc.observations {
-		m[obs.D] = obs
-	}
-
-	if len(mAll) != len(m) {
-		return fmt.Errorf("data doesn't have the same count: %v vs %v", len(mAll), len(m))
-	}
-
-	for date, obsAll := range mAll {
-		if err := isSameObs(obsAll, m[date]); err != nil {
-			return fmt.Errorf("data not the same for date: %v \n %v \n vs \n %v\n, error: %w", date, obsAll, m[date], err)
-		}
-	}
-
-	return nil
-}
-
-func isSameObs(obsAll, obs *Observations) error {
-	valsAll := []Val{
-		obsAll.Average1To3Year,
-		obsAll.Average3To5Year,
-		obsAll.Average5To10Year,
-		obsAll.AverageOver10Year,
-		obsAll.Yield2Year,
-		obsAll.Yield3Year,
-		obsAll.Yield5Year,
-		obsAll.Yield7Year,
-		obsAll.Yield10Year,
-		obsAll.YieldLong,
-		obsAll.YieldRRB,
-	}
-	vals := []Val{
-		obs.Average1To3Year,
-		obs.Average3To5Year,
-		obs.Average5To10Year,
-		obs.AverageOver10Year,
-		obs.Yield2Year,
-		obs.Yield3Year,
-		obs.Yield5Year,
-		obs.Yield7Year,
-		obs.Yield10Year,
-		obs.YieldLong,
-		obs.YieldRRB,
-	}
-	for i, v := range vals {
-		if v.V != "" && v.V != valsAll[i].V {
-			return fmt.Errorf("vals are not the same, all: %v vs %v", valsAll[i].V, v.V)
-		}
-	}
-	return nil
-}
@@ -1,11 +1,63 @@
 package boc
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// newTestServer serves the testdata fixture, or the given status code if not 200
+func newTestServer(t *testing.T, status int) *httptest.Server {
+	t.Helper()
+	data, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write(data)
+			return
+		}
+		w.Write([]byte("service unavailable"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newTestBOC returns a client loaded from the testdata fixture
+func newTestBOC(t *testing.T) *bocInterests {
+	t.Helper()
+	srv := newTestServer(t, http.StatusOK)
+	b := newBOCInterests(WithBaseURL(srv.URL))
+	require.NoError(t, b.load(context.Background()))
+	return b
+}
+
+// readFixture decodes the testdata fixture
+func readFixture(t *testing.T) *BOCData {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	data := new(BOCData)
+	require.NoError(t, json.Unmarshal(raw, data))
+	return data
+}
+
+// newDataServer serves whatever payload returns when it is called
+func newDataServer(t *testing.T, payload func() *BOCData) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(payload())
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
 type testData struct {
 	date    string
 	year2   string
@@ -62,6 +114,26 @@ func TestSuccess(t *testing.T) {
 
 }
 
+func TestObservations(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	data := b.current().bocData()
+	data.Observations[0], data.Observations[3] = data.Observations[3], data.Observations[0]
+	b.ds.Store(b.newDataset(context.Background(), data))
+
+	observations := b.Observations()
+	a.Len(observations, 8)
+	for i := 1; i < len(observations); i++ {
+		a.Less(observations[i-1].D, observations[i].D)
+	}
+	a.Equal("2.57", observations[1].Yield2Year.V)
+
+	observations[1].Yield2Year.V = "changed"
+	obs, err := b.GetObservationForDate("2022-05-24")
+	a.NoError(err)
+	a.Equal("2.57", obs.Yield2Year.V)
+}
+
 func TestFormatDate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -109,6 +181,76 @@ func TestFormatDate(t *testing.T) {
 			date: "1990/05/01",
 			want: "1990-05-01",
 		},
+		{
+			name: "success",
+			date: "20240102",
+			want: "2024-01-02",
+		},
+		{
+			name: "success",
+			date: "Jan 2, 2024",
+			want: "2024-01-02",
+		},
+		{
+			name: "success",
+			date: "2 January 2024",
+			want: "2024-01-02",
+		},
+		{
+			name: "success",
+			date: "september 30,  2022",
+			want: "2022-09-30",
+		},
+		{
+			name: "success",
+			date: "2024-01-02T15:04:05Z",
+			want: "2024-01-02",
+		},
+		{
+			name: "success",
+			date: "2024-01-02T23:30:00-05:00",
+			want: "2024-01-02",
+		},
+		{
+			name: "success",
+			date: "2024-01-02 15:04",
+			want: "2024-01-02",
+		},
+		{
+			name:    "error",
+			date:    "20241302",
+			wantErr: true,
+		},
+		{
+			name:    "error",
+			date:    "Foo 2, 2024",
+			wantErr: true,
+		},
+		{
+			name:    "error",
+			date:    "2024-01-02T25:00:00",
+			wantErr: true,
+		},
+		{
+			name: "success",
+			date: "29-02-2024",
+			want: "2024-02-29",
+		},
+		{
+			name:    "error",
+			date:    "2023-02-30",
+			wantErr: true,
+		},
+		{
+			name:    "error",
+			date:    "29/02/2023",
+			wantErr: true,
+		},
+		{
+			name:    "error",
+			date:    "2023-04-31",
+			wantErr: true,
+		},
 		{
 			name:    "error",
 			date:    "19906-05-01",
@@ -0,0 +1,159 @@
+package boc
+
+import (
+	"errors"
+	"math"
+)
+
+// Price returns the price per 100 of face value of a bond paying frequency
+// coupons a year, with yield and couponRate in percent. When yearsToMaturity
+// is not a whole number of coupon periods the price includes the accrued interest.
+func Price(yield, couponRate, yearsToMaturity float64, frequency int) float64 {
+	price := 0.0
+	forEachCashflow(yield, couponRate, yearsToMaturity, frequency, func(periods, pv float64) {
+		price += pv
+	})
+	return price
+}
+
+// ModifiedDuration returns the percentage change of the price of a bond for a
+// change of 1 percentage point of its yield, with the same arguments as Price
+func ModifiedDuration(yield, couponRate, yearsToMaturity float64, frequency int) float64 {
+	f := float64(frequency)
+	price, weighted := 0.0, 0.0
+	forEachCashflow(yield, couponRate, yearsToMaturity, frequency, func(periods, pv float64) {
+		price += pv
+		weighted += periods * pv
+	})
+	macaulay := weighted / price / f
+	return macaulay / (1 + yield/100/f)
+}
+
+// Convexity returns the convexity of a bond in years squared, with the same arguments as Price
+func Convexity(yield, couponRate, yearsToMaturity float64, frequency int) float64 {
+	f := float64(frequency)
+	price, weighted := 0.0, 0.0
+	forEachCashflow(yield, couponRate, yearsToMaturity, frequency, func(periods, pv float64) {
+		price += pv
+		weighted += periods * (periods + 1) * pv
+	})
+	return weighted / (price * f * f * math.Pow(1+yield/100/f, 2))
+}
+
+// DV01 returns the change of the price per 100 of face value of a bond for a
+// one basis point change of its yield, with the same arguments as Price
+func DV01(yield, couponRate, yearsToMaturity float64, frequency int) float64 {
+	return ModifiedDuration(yield, couponRate, yearsToMaturity, frequency) * Price(yield, couponRate, yearsToMaturity, frequency) / 10000
+}
+
+// Quoting precision of Government of Canada bonds: prices per 100 of face value
+// and yields in percent are rounded to three decimals
+const (
+	priceDecimals = 3
+	yieldDecimals = 3
+)
+
+// Newton solver settings of YieldFromPrice
+const (
+	yieldTolerance = 1e-10
+	maxIterations  = 100
+)
+
+// AccruedInterest returns the interest per 100 of face value accrued since the
+// last coupon of a semi-annual bond, with couponRate in percent, assuming regular
+// coupon periods counted back from the maturity
+func AccruedInterest(couponRate, yearsToMaturity float64) float64 {
+	periods := yearsToMaturity * 2
+	toNext := periods - math.Floor(periods)
+	if toNext == 0 {
+		return 0
+	}
+	return couponRate / 2 * (1 - toNext)
+}
+
+// CleanPrice returns the clean price per 100 of face value of a semi-annual
+// Government of Canada bond, with yield and couponRate in percent, rounded to
+// three decimals as quoted in Canada
+func CleanPrice(yield, couponRate, yearsToMaturity float64) float64 {
+	return round(Price(yield, couponRate, yearsToMaturity, 2)-AccruedInterest(couponRate, yearsToMaturity), priceDecimals)
+}
+
+// YieldFromPrice returns the yield in percent, rounded to three decimals, of a
+// semi-annual Government of Canada bond quoted at a clean price per 100 of face
+// value, solved with Newton's method
+func YieldFromPrice(cleanPrice, couponRate, yearsToMaturity float64) (float64, error) {
+	if cleanPrice <= 0 || yearsToMaturity <= 0 {
+		return 0, errors.New("the price and the maturity of a bond must be positive")
+	}
+	dirty := cleanPrice + AccruedInterest(couponRate, yearsToMaturity)
+	y := couponRate
+	for range maxIterations {
+		price := Price(y, couponRate, yearsToMaturity, 2)
+		// the derivative of the price for a change of 1 percentage point of the yield
+		slope := -ModifiedDuration(y, couponRate, yearsToMaturity, 2) * price / 100
+		step := (price - dirty) / slope
+		y -= step
+		if y <= -200 {
+			// the discount factor 1+y/2 must stay positive
+			y = -199.999
+		}
+		if math.Abs(step) < yieldTolerance {
+			return round(y, yieldDecimals), nil
+		}
+	}
+	return 0, errors.New("failed to solve the yield of the bond")
+}
+
+func round(v float64, decimals int) float64 {
+	p := math.Pow10(decimals)
+	return math.Round(v*p) / p
+}
+
+// forEachCashflow calls fn with the number of coupon periods until each cash
+// flow of a bond and its present value per 100 of face value
+func forEachCashflow(yield, couponRate, yearsToMaturity float64, frequency int, fn func(periods, pv float64)) {
+	f := float64(frequency)
+	y := yield / 100 / f
+	coupon := couponRate / f
+	periods := yearsToMaturity * f
+
+	fn(periods, 100/math.Pow(1+y, periods))
+	for t := periods; t > 0; t-- {
+		fn(t, coupon/math.Pow(1+y, t))
+	}
+}
+
+// Risk holds the price and risk measures of a bond
+type Risk struct {
+	Yield            float64
+	Price            float64
+	ModifiedDuration float64
+	Convexity        float64
+	DV01             float64
+}
+
+// PriceBenchmark prices a semi-annual Government of Canada bond using the curve of client for a date
+func PriceBenchmark(client ObservationReader, date string, couponRate, yearsToMaturity float64) (float64, error) {
+	c, err := YieldCurve(client, date)
+	if err != nil {
+		return 0, err
+	}
+	return Price(c.Yield(yearsToMaturity), couponRate, yearsToMaturity, 2), nil
+}
+
+// BenchmarkRisk returns the price, duration, convexity and DV01 of a semi-annual
+// Government of Canada bond using the curve of client for a date
+func BenchmarkRisk(client ObservationReader, date string, couponRate, yearsToMaturity float64) (Risk, error) {
+	c, err := YieldCurve(client, date)
+	if err != nil {
+		return Risk{}, err
+	}
+	y := c.Yield(yearsToMaturity)
+	return Risk{
+		Yield:            y,
+		Price:            Price(y, couponRate, yearsToMaturity, 2),
+		ModifiedDuration: ModifiedDuration(y, couponRate, yearsToMaturity, 2),
+		Convexity:        Convexity(y, couponRate, yearsToMaturity, 2),
+		DV01:             DV01(y, couponRate, yearsToMaturity, 2),
+	}, nil
+}
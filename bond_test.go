@@ -0,0 +1,129 @@
+package boc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrice(t *testing.T) {
+	tests := []struct {
+		name      string
+		yield     float64
+		coupon    float64
+		years     float64
+		frequency int
+		want      float64
+	}{
+		{name: "par", yield: 3, coupon: 3, years: 10, frequency: 2, want: 100},
+		{name: "premium", yield: 2, coupon: 3, years: 5, frequency: 2, want: 104.7357},
+		{name: "discount", yield: 4, coupon: 3, years: 5, frequency: 2, want: 95.5087},
+		{name: "zero coupon", yield: 3, coupon: 0, years: 2, frequency: 1, want: 94.2596},
+		{name: "annual", yield: 5, coupon: 5, years: 3, frequency: 1, want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, Price(tt.yield, tt.coupon, tt.years, tt.frequency), 1e-4)
+		})
+	}
+}
+
+func TestCleanPriceYield(t *testing.T) {
+	tests := []struct {
+		name   string
+		yield  float64
+		coupon float64
+		years  float64
+		price  float64
+	}{
+		{name: "par", yield: 2, coupon: 2, years: 10, price: 100},
+		{name: "discount", yield: 4, coupon: 3, years: 5, price: 95.509},
+		{name: "premium", yield: 2.73, coupon: 3.5, years: 2, price: 101.489},
+		{name: "between coupons", yield: 2.97, coupon: 2.5, years: 9.75, price: 96.044},
+		{name: "zero coupon", yield: 3, coupon: 0, years: 1, price: 97.066},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			price := CleanPrice(tt.yield, tt.coupon, tt.years)
+			a.Equal(tt.price, price)
+			y, err := YieldFromPrice(price, tt.coupon, tt.years)
+			require.NoError(t, err)
+			a.Equal(tt.yield, y)
+		})
+	}
+
+	assert.Equal(t, 0.625, AccruedInterest(2.5, 9.75), "half of a coupon of 1.25")
+	assert.Equal(t, 0.0, AccruedInterest(2.5, 10))
+	_, err := YieldFromPrice(0, 2, 10)
+	assert.Error(t, err)
+	_, err = YieldFromPrice(100, 2, 0)
+	assert.Error(t, err)
+}
+
+func TestPriceBenchmark(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	price, err := PriceBenchmark(b, "2022-05-24", 2.64, 5)
+	a.NoError(err)
+	a.InDelta(100, price, 1e-9)
+
+	price, err = PriceBenchmark(b, "2022-05-24", 2.5, 4)
+	a.NoError(err)
+	a.InDelta(Price(2.61, 2.5, 4, 2), price, 1e-9)
+
+	_, err = PriceBenchmark(b, "2022-05-23", 2.5, 4)
+	a.ErrorIs(err, ErrNoData)
+}
+
+func TestDurationConvexity(t *testing.T) {
+	tests := []struct {
+		name      string
+		yield     float64
+		coupon    float64
+		years     float64
+		frequency int
+	}{
+		{name: "par", yield: 3, coupon: 3, years: 10, frequency: 2},
+		{name: "premium", yield: 2, coupon: 3, years: 5, frequency: 2},
+		{name: "zero coupon", yield: 4, coupon: 0, years: 7, frequency: 2},
+		{name: "annual", yield: 5, coupon: 5, years: 3, frequency: 1},
+	}
+	const h = 0.01
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			price := Price(tt.yield, tt.coupon, tt.years, tt.frequency)
+			up := Price(tt.yield+h, tt.coupon, tt.years, tt.frequency)
+			down := Price(tt.yield-h, tt.coupon, tt.years, tt.frequency)
+
+			// finite differences with h in percent, so 100*h in decimal
+			duration := (down - up) / (2 * price * h / 100)
+			convexity := (up + down - 2*price) / (price * h * h / 10000)
+			a.InDelta(duration, ModifiedDuration(tt.yield, tt.coupon, tt.years, tt.frequency), 1e-4)
+			a.InDelta(convexity, Convexity(tt.yield, tt.coupon, tt.years, tt.frequency), 1e-2)
+			a.InDelta((down-up)/2/(100*h), DV01(tt.yield, tt.coupon, tt.years, tt.frequency), 1e-6)
+		})
+	}
+
+	// zero coupon: duration is the maturity discounted by one period
+	assert.InDelta(t, 7/1.02, ModifiedDuration(4, 0, 7, 2), 1e-9)
+}
+
+func TestBenchmarkRisk(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	risk, err := BenchmarkRisk(b, "2022-05-24", 2.78, 10)
+	a.NoError(err)
+	a.Equal(2.78, risk.Yield)
+	a.InDelta(100, risk.Price, 1e-9)
+	a.InDelta(ModifiedDuration(2.78, 2.78, 10, 2), risk.ModifiedDuration, 1e-12)
+	a.InDelta(Convexity(2.78, 2.78, 10, 2), risk.Convexity, 1e-12)
+	a.InDelta(risk.ModifiedDuration/100, risk.DV01, 1e-12)
+
+	_, err = BenchmarkRisk(b, "2022-05-23", 2.78, 10)
+	a.ErrorIs(err, ErrNoData)
+}
@@ -0,0 +1,58 @@
+package boc
+
+import (
+	"fmt"
+	"math"
+)
+
+// bpsPrecision is the precision, in basis points, to which conversions are rounded
+// to drop the floating point noise of subtracting two published values, e.g. 2.97 - 2.90
+const bpsPrecision = 1e-6
+
+// Bps is an amount in basis points, a hundredth of a percentage point
+type Bps float64
+
+// ToBps converts a rate or a difference of rates in percent to basis points
+func ToBps(percent float64) Bps {
+	return Bps(math.Round(percent*100/bpsPrecision) * bpsPrecision)
+}
+
+// FromBps converts basis points to percent
+func FromBps(bps Bps) float64 {
+	return float64(bps) / 100
+}
+
+// DeltaBps returns the change from one published value to another in basis
+// points, and false if either has no value
+func DeltaBps(from, to Val) (Bps, bool) {
+	a, ok := from.Float()
+	if !ok {
+		return 0, false
+	}
+	b, ok := to.Float()
+	if !ok {
+		return 0, false
+	}
+	return ToBps(b - a), true
+}
+
+// BpsPoint is an amount in basis points at a date, e.g. a change or a volatility
+type BpsPoint struct {
+	Date  string
+	Value Bps
+}
+
+// Percent returns b in percent
+func (b Bps) Percent() float64 {
+	return FromBps(b)
+}
+
+// Round returns b rounded to a number of decimals
+func (b Bps) Round(decimals int) Bps {
+	return Bps(round(float64(b), decimals))
+}
+
+// String implements fmt.Stringer, e.g. "7 bps"
+func (b Bps) String() string {
+	return fmt.Sprintf("%g bps", float64(b))
+}
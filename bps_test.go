@@ -0,0 +1,43 @@
+package boc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBps(t *testing.T) {
+	a := assert.New(t)
+	a.Equal(Bps(10), ToBps(0.3-0.2), "no floating point noise")
+	a.Equal(Bps(-12.5), ToBps(-0.125))
+	a.Equal(0.25, FromBps(25))
+	a.Equal(0.25, Bps(25).Percent())
+	a.Equal(Bps(1.23), Bps(1.2345).Round(2))
+	a.Equal("7 bps", Bps(7).String())
+
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want Bps
+		ok   bool
+	}{
+		{name: "rise", from: "2.90", to: "2.97", want: 7, ok: true},
+		{name: "fall", from: "0.72", to: "0.67", want: -5, ok: true},
+		{name: "missing from", from: "", to: "2.97"},
+		{name: "missing to", from: "2.90", to: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DeltaBps(Val{V: tt.from}, Val{V: tt.to})
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMovedByRounding(t *testing.T) {
+	moved := MovedBy(SeriesYield10Year, 10)
+	assert.True(t, moved(&Observations{Yield10Year: Val{V: "0.30"}}, &Observations{Yield10Year: Val{V: "0.20"}}),
+		"a change of exactly the threshold is not lost to floating point noise")
+}
@@ -0,0 +1,69 @@
+package boc
+
+import (
+	"sync"
+	"time"
+)
+
+// breaker is a circuit breaker around the Valet API. It opens after threshold
+// consecutive failures, rejecting the fetches for cooldown, then lets one
+// trial fetch through: a success closes it, a failure opens it again.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow returns ErrCircuitOpen if a fetch must not be attempted
+func (cb *breaker) allow() error {
+	if cb == nil {
+		return nil
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.failures < cb.threshold {
+		return nil
+	}
+	if cb.trial || cb.now().Sub(cb.openedAt) < cb.cooldown {
+		return ErrCircuitOpen
+	}
+	cb.trial = true
+	return nil
+}
+
+// record reports the outcome of an allowed fetch
+func (cb *breaker) record(ok bool) {
+	if cb == nil {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.trial = false
+	if ok {
+		cb.failures = 0
+		return
+	}
+	cb.failures++
+	if cb.failures >= cb.threshold {
+		cb.openedAt = cb.now()
+	}
+}
+
+// release ends an allowed fetch without an outcome
+func (cb *breaker) release() {
+	if cb == nil {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.trial = false
+}
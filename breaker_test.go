@@ -0,0 +1,58 @@
+package boc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	a := assert.New(t)
+	now := time.Date(2022, 5, 24, 12, 0, 0, 0, time.UTC)
+	cb := newBreaker(2, time.Minute)
+	cb.now = func() time.Time { return now }
+
+	a.NoError(cb.allow())
+	cb.record(false)
+	a.NoError(cb.allow(), "closed below the threshold")
+	cb.record(false)
+	a.ErrorIs(cb.allow(), ErrCircuitOpen)
+
+	now = now.Add(time.Minute)
+	a.NoError(cb.allow(), "one trial after the cooldown")
+	a.ErrorIs(cb.allow(), ErrCircuitOpen, "only one trial at a time")
+	cb.record(false)
+	a.ErrorIs(cb.allow(), ErrCircuitOpen, "a failed trial opens again")
+
+	now = now.Add(time.Minute)
+	a.NoError(cb.allow())
+	cb.release()
+	a.NoError(cb.allow(), "a released trial can be tried again")
+	cb.record(true)
+	a.NoError(cb.allow())
+	a.NoError(cb.allow())
+
+	var disabled *breaker
+	a.NoError(disabled.allow())
+	disabled.record(false)
+	disabled.release()
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	a := assert.New(t)
+	srv, calls := newFlakyServer(t, 3)
+	b := newBOCInterests(WithBaseURL(srv.URL), WithCircuitBreaker(2, time.Hour))
+
+	a.ErrorIs(b.load(context.Background()), ErrBadStatus)
+	a.ErrorIs(b.load(context.Background()), ErrBadStatus)
+	a.ErrorIs(b.load(context.Background()), ErrCircuitOpen)
+	a.Equal(int32(2), calls.Load(), "no request while open")
+
+	b.breaker.openedAt = b.breaker.openedAt.Add(-time.Hour)
+	a.ErrorIs(b.load(context.Background()), ErrBadStatus)
+	b.breaker.openedAt = b.breaker.openedAt.Add(-time.Hour)
+	a.NoError(b.load(context.Background()))
+	a.Equal(int32(4), calls.Load())
+}
@@ -0,0 +1,44 @@
+package boc
+
+// SeriesBreakevenInflation is a derived series: the long-term benchmark yield
+// minus the real return bond yield. It can be used wherever a series key is
+// accepted, but it is not part of AllSeries since the Valet API does not publish it.
+const SeriesBreakevenInflation = "DERIVED.BREAKEVEN.LONG"
+
+// breakeven returns the breakeven inflation of an observation, rounded to the
+// decimals of the yields so that it reads like a published value
+func (o *Observations) breakeven() (float64, bool) {
+	nominal, ok := o.Value(SeriesYieldLong)
+	if !ok {
+		return 0, false
+	}
+	real, ok := o.Value(SeriesYieldRRB)
+	if !ok {
+		return 0, false
+	}
+	return round(nominal-real, max(decimals(o.YieldLong.V), decimals(o.YieldRRB.V))), true
+}
+
+// Breakeven returns the long-term breakeven inflation of client from start to
+// end, see SeriesBreakevenInflation
+func Breakeven(client ObservationReader, start, end string, opts ...QueryOption) ([]Point, error) {
+	return seriesBetween(client, SeriesBreakevenInflation, start, end, opts...)
+}
+
+// seriesBetween returns the values of a series from start to end, skipping the dates without value
+func seriesBetween(client ObservationReader, seriesKey, start, end string, opts ...QueryOption) ([]Point, error) {
+	if err := checkSeries(seriesKey); err != nil {
+		return nil, err
+	}
+	seq, err := client.Between(start, end, opts...)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]Point, 0)
+	for date, obs := range seq {
+		if v, ok := obs.Value(seriesKey); ok {
+			points = append(points, Point{Date: date, Value: v})
+		}
+	}
+	return points, nil
+}
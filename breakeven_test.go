@@ -0,0 +1,39 @@
+package boc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreakeven(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	points, err := Breakeven(b, "2022-05-26", "2022-05-30")
+	a.NoError(err)
+	a.Len(points, 2)
+	a.Equal("2022-05-26", points[0].Date)
+	a.Equal(2.27, points[0].Value)
+	a.Equal("2022-05-30", points[1].Date)
+	a.Equal(2.29, points[1].Value)
+
+	points, err = Breakeven(b, "2022-05-26", "2022-05-30", ForwardFill())
+	a.NoError(err)
+	a.Len(points, 5)
+	a.Equal(2.3, points[1].Value)
+
+	v, err := b.SeriesValue("2022-06-01", SeriesBreakevenInflation)
+	a.NoError(err)
+	a.Equal(2.31, v, "without float noise")
+	_, err = b.SeriesValue("2022-05-27", SeriesBreakevenInflation)
+	a.ErrorIs(err, ErrNoValue)
+
+	obs := Observations{YieldLong: Val{V: "3.14"}, YieldRRB: Val{V: "1.5"}}
+	v, ok := obs.Value(SeriesBreakevenInflation)
+	a.True(ok)
+	a.Equal(1.64, v)
+
+	_, err = Breakeven(b, "abc", "")
+	a.ErrorIs(err, ErrInvalidDate)
+}
@@ -0,0 +1,53 @@
+package boc
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// bypassCacheKey marks the context of the fetches that must not read the cache
+type bypassCacheKey struct{}
+
+// bypassCache returns a context whose fetches download the data and cache it
+// without reading the cache, so that new and revised observations are seen
+func bypassCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+// cachedData returns the payload cached for url. Cache failures are logged and
+// treated as a miss so that the data is downloaded.
+func (b *bocInterests) cachedData(ctx context.Context, url string) ([]byte, bool) {
+	if b.cache == nil || ctx.Value(bypassCacheKey{}) != nil {
+		return nil, false
+	}
+	ctx, span := b.tracer.Start(ctx, "boc.cache.get", trace.WithAttributes(attribute.String("boc.cache_key", url)))
+	raw, ok, err := b.cache.Get(ctx, url)
+	span.SetAttributes(attribute.Bool("boc.cache_hit", ok && err == nil))
+	endSpan(span, err)
+	if err != nil {
+		b.logger.Warn("cache read failed", "url", url, "error", err)
+		b.counters.cacheMisses.Add(1)
+		return nil, false
+	}
+	if ok {
+		b.counters.cacheHits.Add(1)
+	} else {
+		b.counters.cacheMisses.Add(1)
+	}
+	return raw, ok
+}
+
+// cacheData caches the raw payload downloaded from url
+func (b *bocInterests) cacheData(ctx context.Context, url string, raw []byte) {
+	if b.cache == nil {
+		return
+	}
+	ctx, span := b.tracer.Start(ctx, "boc.cache.set", trace.WithAttributes(attribute.String("boc.cache_key", url)))
+	err := b.cache.Set(ctx, url, raw, b.cacheTTL)
+	endSpan(span, err)
+	if err != nil {
+		b.logger.Warn("cache write failed", "url", url, "error", err)
+	}
+}
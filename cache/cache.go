@@ -0,0 +1,61 @@
+// Package cache defines where a client keeps the Valet payload it downloaded, so
+// that several clients can share one cached copy instead of each fetching it.
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store caches raw payloads by key
+type Store interface {
+	// Get returns the value cached under key, and false if there is none or it expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set caches value under key for ttl, forever if ttl is 0
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Memory is a Store keeping the values in memory, local to the process
+type Memory struct {
+	mu      sync.Mutex
+	entries map[string]entry
+	now     func() time.Time
+}
+
+type entry struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemory returns an empty in-memory Store
+func NewMemory() *Memory {
+	return &Memory{entries: make(map[string]entry), now: time.Now}
+}
+
+// Get implements Store
+func (m *Memory) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !e.expires.IsZero() && !m.now().Before(e.expires) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+	return e.value, true, nil
+}
+
+// Set implements Store
+func (m *Memory) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := entry{value: value}
+	if ttl > 0 {
+		e.expires = m.now().Add(ttl)
+	}
+	m.entries[key] = e
+	return nil
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemory(t *testing.T) {
+	a := assert.New(t)
+	ctx := context.Background()
+	now := time.Date(2022, 5, 24, 12, 0, 0, 0, time.UTC)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+
+	_, ok, err := m.Get(ctx, "key")
+	a.NoError(err)
+	a.False(ok)
+
+	a.NoError(m.Set(ctx, "key", []byte("value"), time.Minute))
+	a.NoError(m.Set(ctx, "forever", []byte("kept"), 0))
+	v, ok, err := m.Get(ctx, "key")
+	a.NoError(err)
+	a.True(ok)
+	a.Equal([]byte("value"), v)
+
+	now = now.Add(time.Minute)
+	_, ok, err = m.Get(ctx, "key")
+	a.NoError(err)
+	a.False(ok, "expired")
+
+	v, ok, err = m.Get(ctx, "forever")
+	a.NoError(err)
+	a.True(ok)
+	a.Equal([]byte("kept"), v)
+}
@@ -0,0 +1,71 @@
+// Package rediscache implements a cache.Store on top of Redis, so that the
+// replicas of a service share one cached copy of the Valet payload.
+package rediscache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/clauderoy790/bank-of-canada-interests-rates/cache"
+)
+
+// defaultPrefix is prepended to the keys unless WithPrefix is used
+const defaultPrefix = "boc:"
+
+// Client is the subset of the go-redis clients used by Store. It is satisfied
+// by *redis.Client, *redis.ClusterClient and redis.UniversalClient.
+type Client interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+}
+
+// Store is a cache.Store keeping the values in Redis
+type Store struct {
+	client Client
+	prefix string
+}
+
+var _ cache.Store = (*Store)(nil)
+
+// Option configures a Store
+type Option func(*Store)
+
+// WithPrefix sets the prefix of the Redis keys, "boc:" by default
+func WithPrefix(prefix string) Option {
+	return func(s *Store) {
+		s.prefix = prefix
+	}
+}
+
+// New returns a Store using client
+func New(client Client, opts ...Option) *Store {
+	s := &Store{client: client, prefix: defaultPrefix}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Get implements cache.Store
+func (s *Store) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading %s from redis: %w", key, err)
+	}
+	return value, true, nil
+}
+
+// Set implements cache.Store
+func (s *Store) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := s.client.Set(ctx, s.prefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("error writing %s to redis: %w", key, err)
+	}
+	return nil
+}
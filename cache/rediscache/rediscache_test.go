@@ -0,0 +1,67 @@
+package rediscache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClient struct {
+	values map[string][]byte
+	ttls   map[string]time.Duration
+	err    error
+}
+
+func (c *fakeClient) Get(ctx context.Context, key string) *redis.StringCmd {
+	if c.err != nil {
+		return redis.NewStringResult("", c.err)
+	}
+	v, ok := c.values[key]
+	if !ok {
+		return redis.NewStringResult("", redis.Nil)
+	}
+	return redis.NewStringResult(string(v), nil)
+}
+
+func (c *fakeClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	if c.err != nil {
+		return redis.NewStatusResult("", c.err)
+	}
+	c.values[key] = value.([]byte)
+	c.ttls[key] = expiration
+	return redis.NewStatusResult("OK", nil)
+}
+
+func TestStore(t *testing.T) {
+	a := assert.New(t)
+	ctx := context.Background()
+	client := &fakeClient{values: make(map[string][]byte), ttls: make(map[string]time.Duration)}
+	s := New(client)
+
+	_, ok, err := s.Get(ctx, "url")
+	a.NoError(err)
+	a.False(ok)
+
+	a.NoError(s.Set(ctx, "url", []byte("payload"), time.Hour))
+	a.Equal([]byte("payload"), client.values["boc:url"])
+	a.Equal(time.Hour, client.ttls["boc:url"])
+
+	v, ok, err := s.Get(ctx, "url")
+	a.NoError(err)
+	a.True(ok)
+	a.Equal([]byte("payload"), v)
+
+	s = New(client, WithPrefix("app:"))
+	_, ok, err = s.Get(ctx, "url")
+	a.NoError(err)
+	a.False(ok)
+
+	client.err = errors.New("connection refused")
+	_, _, err = s.Get(ctx, "url")
+	a.ErrorIs(err, client.err)
+	a.ErrorIs(s.Set(ctx, "url", nil, 0), client.err)
+}
@@ -0,0 +1,90 @@
+package boc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clauderoy790/bank-of-canada-interests-rates/cache"
+)
+
+type failingStore struct{}
+
+func (failingStore) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, errors.New("unavailable")
+}
+
+func (failingStore) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("unavailable")
+}
+
+func TestCache(t *testing.T) {
+	a := assert.New(t)
+	srv, calls := newFlakyServer(t, 0)
+	store := cache.NewMemory()
+
+	b := newBOCInterests(WithBaseURL(srv.URL), WithCache(store, time.Hour))
+	a.NoError(b.load(context.Background()))
+	a.Equal(int32(1), calls.Load())
+
+	// another client sharing the store does not download the data
+	other := newBOCInterests(WithBaseURL(srv.URL), WithCache(store, time.Hour))
+	a.NoError(other.load(context.Background()))
+	a.Equal(int32(1), calls.Load())
+	obs, err := other.GetObservationForDate("2022-05-24")
+	a.NoError(err)
+	a.Equal("2.57", obs.Yield2Year.V)
+
+	// invalid cached data is downloaded again
+	a.NoError(store.Set(context.Background(), b.dataURL(), []byte("{"), 0))
+	a.NoError(newBOCInterests(WithBaseURL(srv.URL), WithCache(store, time.Hour)).load(context.Background()))
+	a.Equal(int32(2), calls.Load())
+}
+
+func TestCacheRefresh(t *testing.T) {
+	for _, full := range []bool{false, true} {
+		t.Run(fmt.Sprintf("full %v", full), func(t *testing.T) {
+			a := assert.New(t)
+			data := readFixture(t)
+			srv := newDataServer(t, func() *BOCData { return data })
+			store := cache.NewMemory()
+			opts := []Option{WithBaseURL(srv.URL), WithCache(store, 0)}
+			if full {
+				opts = append(opts, WithFullRefresh())
+			}
+			b := newBOCInterests(opts...)
+			require.NoError(t, b.load(context.Background()))
+
+			next := *data
+			next.Observations = append(slices.Clone(data.Observations), Observations{D: "2022-06-02", Yield2Year: Val{V: "2.70"}})
+			data = &next
+			require.NoError(t, b.Refresh(context.Background()))
+			_, err := b.GetObservationForDate("2022-06-02")
+			a.NoError(err, "the refresh does not read the cache")
+
+			if full {
+				raw, ok, err := store.Get(context.Background(), b.dataURL())
+				require.NoError(t, err)
+				require.True(t, ok)
+				a.Contains(string(raw), "2022-06-02", "the fresh payload is cached")
+			}
+		})
+	}
+}
+
+func TestCacheFailure(t *testing.T) {
+	a := assert.New(t)
+	srv := newTestServer(t, http.StatusOK)
+	b := newBOCInterests(WithBaseURL(srv.URL), WithCache(failingStore{}, 0))
+
+	a.NoError(b.load(context.Background()), "cache failures do not fail the fetch")
+	_, err := b.GetObservationForDate("2022-05-24")
+	a.NoError(err)
+}
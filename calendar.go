@@ -0,0 +1,146 @@
+package boc
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Holiday is a day on which Canadian banks and the bond market are closed
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// Holidays returns the federal and bank holidays observed in Canada for a year,
+// sorted by date. A holiday falling on a weekend is observed on the next business day.
+func Holidays(year int) []Holiday {
+	easter := easterSunday(year)
+	holidays := []Holiday{
+		{Date: weekendToMonday(date(year, time.January, 1)), Name: "New Year's Day"},
+		{Date: easter.AddDate(0, 0, -2), Name: "Good Friday"},
+		{Date: victoriaDay(year), Name: "Victoria Day"},
+		{Date: weekendToMonday(date(year, time.July, 1)), Name: "Canada Day"},
+		{Date: nthWeekday(year, time.August, time.Monday, 1), Name: "Civic Holiday"},
+		{Date: nthWeekday(year, time.September, time.Monday, 1), Name: "Labour Day"},
+		{Date: nthWeekday(year, time.October, time.Monday, 2), Name: "Thanksgiving"},
+		{Date: weekendToMonday(date(year, time.November, 11)), Name: "Remembrance Day"},
+	}
+	if year >= 2021 {
+		holidays = append(holidays, Holiday{Date: weekendToMonday(date(year, time.September, 30)), Name: "National Day for Truth and Reconciliation"})
+	}
+
+	christmas := weekendToMonday(date(year, time.December, 25))
+	boxingDay := weekendToMonday(date(year, time.December, 26))
+	if !boxingDay.After(christmas) {
+		boxingDay = christmas.AddDate(0, 0, 1)
+	}
+	holidays = append(holidays,
+		Holiday{Date: christmas, Name: "Christmas Day"},
+		Holiday{Date: boxingDay, Name: "Boxing Day"},
+	)
+
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+// HolidayName returns the name of the holiday observed on t, and false if t is not a holiday
+func HolidayName(t time.Time) (string, bool) {
+	day := truncateDay(t)
+	for _, h := range Holidays(day.Year()) {
+		if h.Date.Equal(day) {
+			return h.Name, true
+		}
+	}
+	return "", false
+}
+
+// IsBusinessDay reports whether t is neither a weekend nor a holiday
+func IsBusinessDay(t time.Time) bool {
+	if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	_, holiday := HolidayName(t)
+	return !holiday
+}
+
+// NextBusinessDay returns the first business day strictly after t
+func NextBusinessDay(t time.Time) time.Time {
+	day := truncateDay(t).AddDate(0, 0, 1)
+	for !IsBusinessDay(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+// PreviousBusinessDay returns the last business day strictly before t
+func PreviousBusinessDay(t time.Time) time.Time {
+	day := truncateDay(t).AddDate(0, 0, -1)
+	for !IsBusinessDay(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// noDataError explains why there is no data for a formatted date when it is not a business day
+func noDataError(formatted string) error {
+	t, err := time.Parse("2006-01-02", formatted)
+	if err != nil {
+		return ErrNoData
+	}
+	if name, ok := HolidayName(t); ok {
+		return fmt.Errorf("%w: holiday (%s)", ErrNoData, name)
+	}
+	if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return fmt.Errorf("%w: weekend", ErrNoData)
+	}
+	return ErrNoData
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// truncateDay returns the calendar day of t at midnight UTC
+func truncateDay(t time.Time) time.Time {
+	return date(t.Year(), t.Month(), t.Day())
+}
+
+func weekendToMonday(t time.Time) time.Time {
+	switch t.Weekday() {
+	case time.Saturday:
+		return t.AddDate(0, 0, 2)
+	case time.Sunday:
+		return t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+// nthWeekday returns the nth weekday of a month, e.g. the first Monday of August
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	first := date(year, month, 1)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+7*(n-1))
+}
+
+// victoriaDay returns the last Monday before May 25
+func victoriaDay(year int) time.Time {
+	t := date(year, time.May, 24)
+	return t.AddDate(0, 0, -((int(t.Weekday()) - int(time.Monday) + 7) % 7))
+}
+
+// easterSunday uses the anonymous Gregorian algorithm
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
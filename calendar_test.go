@@ -0,0 +1,75 @@
+package boc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHolidays(t *testing.T) {
+	tests := []struct {
+		year int
+		want []string
+	}{
+		{
+			year: 2022,
+			want: []string{
+				"2022-01-03", "2022-04-15", "2022-05-23", "2022-07-01", "2022-08-01", "2022-09-05",
+				"2022-09-30", "2022-10-10", "2022-11-11", "2022-12-26", "2022-12-27",
+			},
+		},
+		{
+			year: 2023,
+			want: []string{
+				"2023-01-02", "2023-04-07", "2023-05-22", "2023-07-03", "2023-08-07", "2023-09-04",
+				"2023-10-02", "2023-10-09", "2023-11-13", "2023-12-25", "2023-12-26",
+			},
+		},
+		{
+			year: 2020,
+			want: []string{
+				"2020-01-01", "2020-04-10", "2020-05-18", "2020-07-01", "2020-08-03", "2020-09-07",
+				"2020-10-12", "2020-11-11", "2020-12-25", "2020-12-28",
+			},
+		},
+	}
+	for _, tt := range tests {
+		got := make([]string, 0)
+		for _, h := range Holidays(tt.year) {
+			got = append(got, h.Date.Format("2006-01-02"))
+		}
+		assert.Equal(t, tt.want, got, tt.year)
+	}
+}
+
+func TestBusinessDays(t *testing.T) {
+	a := assert.New(t)
+	day := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		a.NoError(err)
+		return d
+	}
+
+	a.True(IsBusinessDay(day("2022-05-24")))
+	a.False(IsBusinessDay(day("2022-05-23")))
+	a.False(IsBusinessDay(day("2022-05-21")))
+
+	name, ok := HolidayName(day("2022-05-23"))
+	a.True(ok)
+	a.Equal("Victoria Day", name)
+	_, ok = HolidayName(day("2022-05-24"))
+	a.False(ok)
+
+	a.Equal(day("2022-05-24"), NextBusinessDay(day("2022-05-20")))
+	a.Equal(day("2022-05-20"), PreviousBusinessDay(day("2022-05-24")))
+	a.Equal(day("2022-12-28"), NextBusinessDay(day("2022-12-23")))
+	a.Equal(day("2022-12-23"), PreviousBusinessDay(time.Date(2022, 12, 28, 15, 0, 0, 0, time.UTC)))
+}
+
+func TestNoDataOnHoliday(t *testing.T) {
+	b := newTestBOC(t)
+	_, err := b.GetObservationForDate("2022-05-23")
+	assert.ErrorIs(t, err, ErrNoData)
+	assert.ErrorContains(t, err, "Victoria Day")
+}
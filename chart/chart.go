@@ -0,0 +1,107 @@
+// Package chart exports the series of a client as Chart.js and Plotly data,
+// to be marshaled to JSON and plotted as is by web dashboards.
+package chart
+
+import (
+	"fmt"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// ChartJS is the data of a Chart.js line chart
+type ChartJS struct {
+	// Labels are the dates of the x axis
+	Labels   []string         `json:"labels"`
+	Datasets []ChartJSDataset `json:"datasets"`
+}
+
+// ChartJSDataset is a series of a Chart.js chart
+type ChartJSDataset struct {
+	Label string `json:"label"`
+	// Data has one value per label, nil when the series has no value for the date
+	Data     []*float64 `json:"data"`
+	SpanGaps bool       `json:"spanGaps"`
+}
+
+// PlotlyTrace is a series of a Plotly figure
+type PlotlyTrace struct {
+	Type string    `json:"type"`
+	Mode string    `json:"mode"`
+	Name string    `json:"name"`
+	X    []string  `json:"x"`
+	Y    []float64 `json:"y"`
+}
+
+// NewChartJS returns the Chart.js data of the series from start to end, one
+// dataset per series in the order of seriesKeys, with a label per date.
+func NewChartJS(client boc.ObservationReader, seriesKeys []string, start, end string, opts ...boc.QueryOption) (*ChartJS, error) {
+	labels, err := seriesLabels(seriesKeys)
+	if err != nil {
+		return nil, err
+	}
+	seq, err := client.Between(start, end, opts...)
+	if err != nil {
+		return nil, err
+	}
+	chart := &ChartJS{Labels: make([]string, 0), Datasets: make([]ChartJSDataset, len(seriesKeys))}
+	for i, label := range labels {
+		chart.Datasets[i] = ChartJSDataset{Label: label, Data: make([]*float64, 0), SpanGaps: true}
+	}
+	for date, obs := range seq {
+		chart.Labels = append(chart.Labels, date)
+		for i, key := range seriesKeys {
+			chart.Datasets[i].Data = append(chart.Datasets[i].Data, obs.ValuePtr(key))
+		}
+	}
+	if len(chart.Labels) == 0 {
+		return nil, &boc.DataError{Date: start, Err: boc.ErrNoData}
+	}
+	return chart, nil
+}
+
+// NewPlotly returns the Plotly line traces of the series from start to end, in
+// the order of seriesKeys, skipping the dates without value.
+func NewPlotly(client boc.ObservationReader, seriesKeys []string, start, end string, opts ...boc.QueryOption) ([]PlotlyTrace, error) {
+	labels, err := seriesLabels(seriesKeys)
+	if err != nil {
+		return nil, err
+	}
+	seq, err := client.Between(start, end, opts...)
+	if err != nil {
+		return nil, err
+	}
+	traces := make([]PlotlyTrace, len(seriesKeys))
+	for i, label := range labels {
+		traces[i] = PlotlyTrace{Type: "scatter", Mode: "lines", Name: label, X: make([]string, 0), Y: make([]float64, 0)}
+	}
+	found := false
+	for date, obs := range seq {
+		found = true
+		for i, key := range seriesKeys {
+			if v, ok := obs.Value(key); ok {
+				traces[i].X = append(traces[i].X, date)
+				traces[i].Y = append(traces[i].Y, v)
+			}
+		}
+	}
+	if !found {
+		return nil, &boc.DataError{Date: start, Err: boc.ErrNoData}
+	}
+	return traces, nil
+}
+
+// seriesLabels returns the labels of the series, checking that they are known
+func seriesLabels(seriesKeys []string) ([]string, error) {
+	if len(seriesKeys) == 0 {
+		return nil, fmt.Errorf("no series selected")
+	}
+	labels := make([]string, len(seriesKeys))
+	for i, key := range seriesKeys {
+		label, ok := boc.SeriesLabel(key)
+		if !ok {
+			return nil, &boc.DataError{Series: key, Err: boc.ErrUnknownSeries}
+		}
+		labels[i] = label
+	}
+	return labels, nil
+}
@@ -0,0 +1,53 @@
+package chart
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+	"github.com/clauderoy790/bank-of-canada-interests-rates/internal/testutil"
+)
+
+func TestChartJS(t *testing.T) {
+	a := assert.New(t)
+	client := testutil.NewClient(t)
+
+	chart, err := NewChartJS(client, []string{boc.SeriesYield2Year, boc.SeriesYieldRRB}, "2022-05-26", "2022-05-30")
+	require.NoError(t, err)
+	raw, err := json.Marshal(chart)
+	require.NoError(t, err)
+	a.JSONEq(`{
+		"labels": ["2022-05-26", "2022-05-27", "2022-05-30"],
+		"datasets": [
+			{"label": "2 year benchmark yield", "data": [2.55, 2.61, 2.65], "spanGaps": true},
+			{"label": "Real return bond yield", "data": [0.57, null, 0.63], "spanGaps": true}
+		]
+	}`, string(raw))
+
+	_, err = NewChartJS(client, nil, "", "")
+	a.Error(err)
+	_, err = NewChartJS(client, []string{"foo"}, "", "")
+	a.ErrorIs(err, boc.ErrUnknownSeries)
+	_, err = NewChartJS(client, []string{boc.SeriesYield2Year}, "2023-01-01", "")
+	a.ErrorIs(err, boc.ErrNoData)
+}
+
+func TestPlotly(t *testing.T) {
+	a := assert.New(t)
+	client := testutil.NewClient(t)
+
+	traces, err := NewPlotly(client, []string{boc.SeriesYieldRRB}, "2022-05-26", "2022-05-30")
+	require.NoError(t, err)
+	raw, err := json.Marshal(traces)
+	require.NoError(t, err)
+	a.JSONEq(`[{"type": "scatter", "mode": "lines", "name": "Real return bond yield",
+		"x": ["2022-05-26", "2022-05-30"], "y": [0.57, 0.63]}]`, string(raw))
+
+	_, err = NewPlotly(client, []string{"foo"}, "", "")
+	a.ErrorIs(err, boc.ErrUnknownSeries)
+	_, err = NewPlotly(client, []string{boc.SeriesYield2Year}, "2023-01-01", "")
+	a.ErrorIs(err, boc.ErrNoData)
+}
@@ -0,0 +1,72 @@
+package boc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// fetchAll downloads the full history, in yearly chunks with WithChunkedFetch
+func (b *bocInterests) fetchAll(ctx context.Context) (*BOCData, error) {
+	if b.chunkStart == 0 {
+		return b.fetchData(ctx, b.dataURL())
+	}
+	return b.fetchChunked(ctx, b.chunkStart, b.now().Year())
+}
+
+// fetchChunked downloads the observations of each year from first to last
+// concurrently and merges them, keeping the merged payload for RawJSON. The
+// first failure cancels the other requests.
+func (b *bocInterests) fetchChunked(ctx context.Context, first, last int) (*BOCData, error) {
+	if last < first {
+		return nil, &DataError{Err: fmt.Errorf("chunked fetch starts in %d, after the current year %d", first, last)}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := make([]*BOCData, last-first+1)
+	payloads := make([][]byte, len(chunks))
+	var once sync.Once
+	var firstErr error
+	sem := make(chan struct{}, b.chunkWorkers)
+	var wg sync.WaitGroup
+	for i := range chunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			year := first + i
+			query := url.Values{
+				"start_date": {fmt.Sprintf("%d-01-01", year)},
+				"end_date":   {fmt.Sprintf("%d-12-31", year)},
+			}
+			chunk, payload, err := b.fetchPayload(ctx, b.dataURL()+"?"+query.Encode())
+			if err != nil {
+				// the first failure is the cause, the next ones are the cancelled requests
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			chunks[i], payloads[i] = chunk, payload
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	data, raw := chunks[0], payloads[0]
+	for i, chunk := range chunks[1:] {
+		data = mergeData(data, chunk)
+		var err error
+		if raw, err = mergeRaw(raw, payloads[i+1]); err != nil {
+			return nil, err
+		}
+	}
+	b.raw.Store(&raw)
+	return data, nil
+}
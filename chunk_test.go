@@ -0,0 +1,83 @@
+package boc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRangeServer serves the fixture observations between the start_date and end_date
+// query parameters, failing the requests starting on failDate
+func newRangeServer(t *testing.T, failDate string) (*httptest.Server, func() []string) {
+	t.Helper()
+	fixture := readFixture(t)
+	var mu sync.Mutex
+	starts := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, end := r.URL.Query().Get("start_date"), r.URL.Query().Get("end_date")
+		mu.Lock()
+		starts = append(starts, start)
+		mu.Unlock()
+		if start == failDate {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		data := *fixture
+		data.Observations = nil
+		for _, obs := range fixture.Observations {
+			if obs.D >= start && (end == "" || obs.D <= end) {
+				data.Observations = append(data.Observations, obs)
+			}
+		}
+		json.NewEncoder(w).Encode(&data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), starts...)
+	}
+}
+
+func TestChunkedFetch(t *testing.T) {
+	a := assert.New(t)
+	srv, starts := newRangeServer(t, "")
+	b := newBOCInterests(WithBaseURL(srv.URL), WithChunkedFetch(2020, 2))
+	b.now = func() time.Time { return time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC) }
+	require.NoError(t, b.load(context.Background()))
+
+	a.ElementsMatch([]string{"2020-01-01", "2021-01-01", "2022-01-01", "2023-01-01"}, starts())
+	a.Equal(readFixture(t).Observations, b.Observations())
+	a.Equal(readFixture(t).SeriesDetail, b.SeriesDetail())
+
+	m, err := b.RawMap()
+	require.NoError(t, err, "the payloads of the chunks are merged")
+	a.Len(m["observations"], len(readFixture(t).Observations))
+	a.NotNil(m["terms"])
+}
+
+func TestChunkedFetchFutureStart(t *testing.T) {
+	a := assert.New(t)
+	srv, starts := newRangeServer(t, "")
+	for _, year := range []int{2024, 2030} {
+		b := newBOCInterests(WithBaseURL(srv.URL), WithChunkedFetch(year, 2))
+		b.now = func() time.Time { return time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC) }
+		var dataErr *DataError
+		a.ErrorAs(b.load(context.Background()), &dataErr, year)
+	}
+	a.Empty(starts())
+}
+
+func TestChunkedFetchError(t *testing.T) {
+	a := assert.New(t)
+	srv, _ := newRangeServer(t, "2021-01-01")
+	b := newBOCInterests(WithBaseURL(srv.URL), WithChunkedFetch(2020, 4))
+	a.ErrorIs(b.load(context.Background()), ErrBadStatus)
+}
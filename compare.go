@@ -0,0 +1,87 @@
+package boc
+
+import (
+	"fmt"
+	"math"
+)
+
+// parallelThreshold is the largest change of the curve slope, in basis points,
+// still classified as a parallel shift
+const parallelThreshold Bps = 5
+
+// CurveShift classifies how a curve moved between two dates
+type CurveShift int
+
+const (
+	// ShiftParallel is a move of every tenor by about the same amount
+	ShiftParallel CurveShift = iota
+	// ShiftSteepening is a widening of the spread between the long and the short end
+	ShiftSteepening
+	// ShiftFlattening is a narrowing of the spread between the long and the short end
+	ShiftFlattening
+)
+
+// String implements fmt.Stringer
+func (s CurveShift) String() string {
+	switch s {
+	case ShiftParallel:
+		return "parallel"
+	case ShiftSteepening:
+		return "steepening"
+	case ShiftFlattening:
+		return "flattening"
+	}
+	return fmt.Sprintf("CurveShift(%d)", int(s))
+}
+
+// CurveComparison holds the changes of the benchmark curve between two dates
+type CurveComparison struct {
+	From string
+	To   string
+	// Tenors are the maturities in years quoted on both dates, in ascending order
+	Tenors []float64
+	// Changes are the yield changes of each tenor
+	Changes []Bps
+	// Shift classifies the move from the changes of the shortest and the longest tenor
+	Shift CurveShift
+}
+
+// compareCurves compares two curves, skipping the tenors missing from either
+func compareCurves(from, to *Curve) *CurveComparison {
+	cmp := &CurveComparison{From: from.Date, To: to.Date}
+	j := 0
+	for i, years := range from.Tenors {
+		for j < len(to.Tenors) && to.Tenors[j] < years {
+			j++
+		}
+		if j < len(to.Tenors) && to.Tenors[j] == years {
+			cmp.Tenors = append(cmp.Tenors, years)
+			cmp.Changes = append(cmp.Changes, ToBps(to.Yields[j]-from.Yields[i]))
+		}
+	}
+	if n := len(cmp.Changes); n > 1 {
+		slope := cmp.Changes[n-1] - cmp.Changes[0]
+		switch {
+		case math.Abs(float64(slope)) <= float64(parallelThreshold):
+			cmp.Shift = ShiftParallel
+		case slope > 0:
+			cmp.Shift = ShiftSteepening
+		default:
+			cmp.Shift = ShiftFlattening
+		}
+	}
+	return cmp
+}
+
+// CompareCurves returns the changes of the benchmark curve of client from dateA to dateB
+func CompareCurves(client ObservationReader, dateA, dateB string) (*CurveComparison, error) {
+	from, err := YieldCurve(client, dateA)
+	if err != nil {
+		return nil, err
+	}
+	to, err := YieldCurve(client, dateB)
+	if err != nil {
+		return nil, err
+	}
+	return compareCurves(from, to), nil
+}
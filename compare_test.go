@@ -0,0 +1,74 @@
+package boc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareCurves(t *testing.T) {
+	base := &Curve{Date: "a", Tenors: []float64{2, 5, 10}, Yields: []float64{2, 2.5, 3}}
+	tests := []struct {
+		name    string
+		to      *Curve
+		tenors  []float64
+		changes []Bps
+		shift   CurveShift
+	}{
+		{
+			name:    "parallel",
+			to:      &Curve{Date: "b", Tenors: []float64{2, 5, 10}, Yields: []float64{2.1, 2.6, 3.12}},
+			tenors:  []float64{2, 5, 10},
+			changes: []Bps{10, 10, 12},
+			shift:   ShiftParallel,
+		},
+		{
+			name:    "steepening",
+			to:      &Curve{Date: "b", Tenors: []float64{2, 5, 10}, Yields: []float64{1.9, 2.5, 3.1}},
+			tenors:  []float64{2, 5, 10},
+			changes: []Bps{-10, 0, 10},
+			shift:   ShiftSteepening,
+		},
+		{
+			name:    "flattening",
+			to:      &Curve{Date: "b", Tenors: []float64{2, 10}, Yields: []float64{2.2, 3}},
+			tenors:  []float64{2, 10},
+			changes: []Bps{20, 0},
+			shift:   ShiftFlattening,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			cmp := compareCurves(base, tt.to)
+			a.Equal("a", cmp.From)
+			a.Equal("b", cmp.To)
+			a.Equal(tt.tenors, cmp.Tenors)
+			a.Equal(tt.changes, cmp.Changes)
+			a.Equal(tt.shift, cmp.Shift)
+		})
+	}
+}
+
+func TestCurveShiftString(t *testing.T) {
+	a := assert.New(t)
+	a.Equal("parallel", ShiftParallel.String())
+	a.Equal("steepening", ShiftSteepening.String())
+	a.Equal("flattening", ShiftFlattening.String())
+	a.Equal("CurveShift(9)", CurveShift(9).String())
+}
+
+func TestCompareCurvesClient(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	cmp, err := CompareCurves(b, "2022-05-24", "2022-05-25")
+	a.NoError(err)
+	a.Equal("2022-05-24", cmp.From)
+	a.Equal("2022-05-25", cmp.To)
+	a.Equal([]float64{2, 3, 5, 7, 10, 30}, cmp.Tenors)
+	a.Equal(Bps(-4), cmp.Changes[0])
+
+	_, err = CompareCurves(b, "2022-05-23", "2022-05-25")
+	a.ErrorIs(err, ErrNoData)
+}
@@ -0,0 +1,55 @@
+package boc
+
+import (
+	"errors"
+	"math"
+)
+
+// Correlation returns the correlation of the daily changes of two series of client from start to end
+func Correlation(client ObservationReader, seriesA, seriesB, start, end string) (float64, error) {
+	for _, key := range []string{seriesA, seriesB} {
+		if err := checkSeries(key); err != nil {
+			return 0, err
+		}
+	}
+	seq, err := client.Between(start, end)
+	if err != nil {
+		return 0, err
+	}
+	var pointsA, pointsB []Point
+	for date, obs := range seq {
+		va, okA := obs.Value(seriesA)
+		vb, okB := obs.Value(seriesB)
+		if okA && okB {
+			pointsA = append(pointsA, Point{Date: date, Value: va})
+			pointsB = append(pointsB, Point{Date: date, Value: vb})
+		}
+	}
+	return correlation(changes(pointsA), changes(pointsB))
+}
+
+// correlation returns the Pearson correlation of two aligned sets of points
+func correlation(a, b []BpsPoint) (float64, error) {
+	n := len(a)
+	if n < 2 {
+		return 0, errors.New("at least two common changes are needed to compute a correlation")
+	}
+	meanA, meanB := 0.0, 0.0
+	for i := range a {
+		meanA += float64(a[i].Value)
+		meanB += float64(b[i].Value)
+	}
+	meanA /= float64(n)
+	meanB /= float64(n)
+	var cov, varA, varB float64
+	for i := range a {
+		da, db := float64(a[i].Value)-meanA, float64(b[i].Value)-meanB
+		cov += da * db
+		varA += da * da
+		varB += db * db
+	}
+	if varA == 0 || varB == 0 {
+		return 0, errors.New("correlation is undefined for a series without changes")
+	}
+	return cov / math.Sqrt(varA*varB), nil
+}
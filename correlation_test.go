@@ -0,0 +1,46 @@
+package boc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelation(t *testing.T) {
+	a := assert.New(t)
+	x := []BpsPoint{{"d1", 1}, {"d2", 2}, {"d3", 3}}
+
+	c, err := correlation(x, []BpsPoint{{"d1", 2}, {"d2", 4}, {"d3", 6}})
+	a.NoError(err)
+	a.InDelta(1, c, 1e-9)
+
+	c, err = correlation(x, []BpsPoint{{"d1", 3}, {"d2", 2}, {"d3", 1}})
+	a.NoError(err)
+	a.InDelta(-1, c, 1e-9)
+
+	_, err = correlation(x, []BpsPoint{{"d1", 1}, {"d2", 1}, {"d3", 1}})
+	a.Error(err)
+	_, err = correlation(x[:1], x[:1])
+	a.Error(err)
+}
+
+func TestCorrelationClient(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	c, err := Correlation(b, SeriesYield2Year, SeriesYield2Year, "", "")
+	a.NoError(err)
+	a.InDelta(1, c, 1e-9)
+
+	c, err = Correlation(b, SeriesYield2Year, SeriesYield10Year, "2022-05-20", "2022-06-01")
+	a.NoError(err)
+	a.GreaterOrEqual(c, -1.0)
+	a.LessOrEqual(c, 1.0)
+
+	_, err = Correlation(b, SeriesYield2Year, "foo", "", "")
+	a.ErrorIs(err, ErrUnknownSeries)
+	_, err = Correlation(b, SeriesYield2Year, SeriesYield10Year, "2022-05-24", "2022-05-24")
+	a.Error(err)
+	_, err = Correlation(b, SeriesYield2Year, SeriesYield10Year, "foo", "")
+	a.ErrorIs(err, ErrInvalidDate)
+}
@@ -0,0 +1,136 @@
+package boc
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ImportCSV implements BOCInterests
+func (b *bocInterests) ImportCSV(r io.Reader, layout string) (int, error) {
+	imported, err := b.readCSV(r, layout)
+	if err != nil {
+		return 0, err
+	}
+	// the data in use is merged and replaced as a whole, not concurrently with Refresh
+	b.updateMu.Lock()
+	defer b.updateMu.Unlock()
+	data := b.current().bocData()
+	known := make(map[string]int, len(data.Observations))
+	for i, obs := range data.Observations {
+		known[obs.D] = i
+	}
+	for _, obs := range imported {
+		i, ok := known[obs.D]
+		if !ok {
+			known[obs.D] = len(data.Observations)
+			data.Observations = append(data.Observations, obs)
+			continue
+		}
+		// the values of the data in use are kept, only the missing ones are filled
+		existing := &data.Observations[i]
+		for key, v := range obs.Record().Values {
+			if !existing.Has(key) {
+				existing.set(key, v)
+			}
+		}
+	}
+	sort.SliceStable(data.Observations, func(i, j int) bool {
+		return data.Observations[i].D < data.Observations[j].D
+	})
+
+	ctx := context.Background()
+	b.ds.Store(b.newDataset(ctx, data))
+	b.store(ctx, data)
+	return len(imported), nil
+}
+
+// readCSV reads observations from a CSV with a date column then one column per
+// series, named by key or by label as written by ResultSet.WriteCSV. The dates are read according to
+// layout, see FormatDateLayout, or as the other dates given to the client if
+// layout is empty. Empty values are missing, the derived series are skipped
+// since they are computed, and the lines starting with "#" are comments.
+func (b *bocInterests) readCSV(r io.Reader, layout string) ([]Observations, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV header: %w", err)
+	}
+	if len(header) == 0 || !strings.EqualFold(strings.TrimSpace(header[0]), "date") {
+		return nil, fmt.Errorf("CSV first column must be date, got %q", header[0])
+	}
+	var observations []Observations
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return observations, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading CSV: %w", err)
+		}
+		var date string
+		if layout != "" {
+			date, err = FormatDateLayout(row[0], layout)
+			if err != nil {
+				err = &DataError{Date: row[0], Err: fmt.Errorf("%w: %w", ErrInvalidDate, err)}
+			}
+		} else {
+			date, err = b.formatDate(row[0])
+		}
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("CSV line %d: %w", line, err)
+		}
+		obs := Observations{D: date}
+		for i, field := range row[1:] {
+			key := columnSeries(header[i+1])
+			if v := (Val{V: strings.TrimSpace(field)}); v.Valid() && key != SeriesBreakevenInflation {
+				obs.set(key, v)
+			}
+		}
+		observations = append(observations, obs)
+	}
+}
+
+// seriesByLabel maps the labels of SeriesLabel to their series key
+var seriesByLabel = func() map[string]string {
+	m := make(map[string]string, len(seriesLabels))
+	for key, label := range seriesLabels {
+		m[label] = key
+	}
+	return m
+}()
+
+// columnSeries returns the series key of a CSV column named by key, or by
+// label with or without its unit, see ResultSet.ColumnLabel
+func columnSeries(column string) string {
+	column = strings.TrimSpace(column)
+	label := column
+	if i := strings.LastIndex(column, " ("); i > 0 && strings.HasSuffix(column, ")") {
+		label = column[:i]
+	}
+	if key, ok := seriesByLabel[label]; ok {
+		return key
+	}
+	return column
+}
+
+// set sets the value of a series, in Extra if Observations has no field for it
+func (o *Observations) set(key string, v Val) {
+	if f := o.field(key); f != nil {
+		*f = v
+		return
+	}
+	if o.Extra == nil {
+		o.Extra = make(map[string]Val)
+	}
+	o.Extra[key] = v
+}
@@ -0,0 +1,92 @@
+package boc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCSV(t *testing.T) {
+	a := assert.New(t)
+	storage := NewMemoryStorage()
+	b := newTestBOC(t)
+	b.storage = storage
+
+	n, err := b.ImportCSV(strings.NewReader(`date,BD.CDN.2YR.DQ.YLD,BD.CDN.RRB.DQ.YLD,NEW.SERIES,DERIVED.BREAKEVEN.LONG
+19/05/2022,2.60,0.62,1.5,2.00
+27/05/2022,9.99,0.59,,
+`), "DD/MM/YYYY")
+	require.NoError(t, err)
+	a.Equal(2, n)
+
+	obs, err := b.GetObservationForDate("2022-05-19")
+	require.NoError(t, err)
+	a.Equal("2.60", obs.Yield2Year.V)
+	a.Equal(Val{V: "1.5"}, obs.Extra["NEW.SERIES"])
+	a.False(obs.Yield10Year.Valid())
+	v, _ := obs.Value(SeriesBreakevenInflation)
+	a.NotEqual(2.0, v, "derived series are computed")
+
+	obs, err = b.GetObservationForDate("2022-05-27")
+	require.NoError(t, err)
+	a.Equal("2.61", obs.Yield2Year.V, "fetched values are kept")
+	a.Equal("0.59", obs.YieldRRB.V, "missing values are filled")
+
+	stored, err := storage.Observation(context.Background(), "2022-05-19")
+	require.NoError(t, err)
+	a.Equal("2.60", stored.Yield2Year.V)
+	a.Equal("2022-05-19", b.current().dates[0])
+}
+
+func TestImportCSVErrors(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	_, err := b.ImportCSV(strings.NewReader("day,BD.CDN.2YR.DQ.YLD\n"), "")
+	a.ErrorContains(err, "CSV first column must be date")
+	_, err = b.ImportCSV(strings.NewReader("date,BD.CDN.2YR.DQ.YLD\n2022-05-19,2.60\nfoo,2.61\n"), "")
+	a.ErrorIs(err, ErrInvalidDate)
+	a.ErrorContains(err, "CSV line 3")
+	_, err = b.ImportCSV(strings.NewReader("date,BD.CDN.2YR.DQ.YLD\n2022-05-19\n"), "")
+	a.ErrorContains(err, "error reading CSV")
+	_, err = b.GetObservationForDate("2022-05-19")
+	a.ErrorIs(err, ErrNoData, "nothing is imported on error")
+
+	n, err := b.ImportCSV(strings.NewReader(""), "")
+	a.NoError(err)
+	a.Zero(n)
+}
+
+func TestColumnSeries(t *testing.T) {
+	a := assert.New(t)
+	a.Equal(SeriesYield2Year, columnSeries(SeriesYield2Year))
+	a.Equal(SeriesYield2Year, columnSeries(" 2 year benchmark yield (% per annum) "))
+	a.Equal(SeriesYieldRRB, columnSeries("Real return bond yield"))
+	a.Equal(SeriesBreakevenInflation, columnSeries("Long-term breakeven inflation (% per annum)"))
+	a.Equal("BD.CDN.1YR.DQ.YLD", columnSeries("BD.CDN.1YR.DQ.YLD"), "unknown keys are kept")
+	a.Equal("Other (x)", columnSeries("Other (x)"))
+}
+
+func TestImportCSVDuringRefresh(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	imported := make(chan error, 1)
+	b.baseURL = newDataServer(t, func() *BOCData {
+		// the import runs while the refresh is fetching the data it replaces
+		go func() {
+			_, err := b.ImportCSV(strings.NewReader("date,BD.CDN.2YR.DQ.YLD\n2022-05-19,2.60\n"), "")
+			imported <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+		return readFixture(t)
+	}).URL
+
+	require.NoError(t, b.Refresh(context.Background()))
+	require.NoError(t, <-imported)
+	obs, err := b.GetObservationForDate("2022-05-19")
+	require.NoError(t, err, "the import is not overwritten by the refresh")
+	a.Equal("2.60", obs.Yield2Year.V)
+}
@@ -0,0 +1,87 @@
+package boc
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LongTermYears is the maturity, in years, used for the long-term benchmark bond
+const LongTermYears = 30
+
+// benchmarkTenors are the maturities in years of the benchmark yield series, in ascending order
+var benchmarkTenors = []struct {
+	series string
+	years  float64
+}{
+	{SeriesYield2Year, 2},
+	{SeriesYield3Year, 3},
+	{SeriesYield5Year, 5},
+	{SeriesYield7Year, 7},
+	{SeriesYield10Year, 10},
+	{SeriesYieldLong, LongTermYears},
+}
+
+// SeriesTenor returns the maturity in years of a benchmark yield series, and
+// false if the series is not a benchmark yield
+func SeriesTenor(key string) (float64, bool) {
+	for _, t := range benchmarkTenors {
+		if t.series == key {
+			return t.years, true
+		}
+	}
+	return 0, false
+}
+
+// Curve is the Government of Canada benchmark yield curve of a date
+type Curve struct {
+	Date string
+	// Tenors are the maturities in years, in ascending order
+	Tenors []float64
+	// Yields are the yields in percent of each tenor
+	Yields []float64
+}
+
+// Yield returns the yield in percent for a maturity in years, linearly
+// interpolated between tenors and flat beyond the first and last ones
+func (c *Curve) Yield(years float64) float64 {
+	n := len(c.Tenors)
+	if n == 0 {
+		return 0
+	}
+	if years <= c.Tenors[0] {
+		return c.Yields[0]
+	}
+	if years >= c.Tenors[n-1] {
+		return c.Yields[n-1]
+	}
+	i := sort.SearchFloat64s(c.Tenors, years)
+	if c.Tenors[i] == years {
+		return c.Yields[i]
+	}
+	w := (years - c.Tenors[i-1]) / (c.Tenors[i] - c.Tenors[i-1])
+	return c.Yields[i-1] + w*(c.Yields[i]-c.Yields[i-1])
+}
+
+// YieldCurve returns the benchmark yield curve of client for a date
+func YieldCurve(client ObservationReader, date string, opts ...QueryOption) (*Curve, error) {
+	obs, err := client.GetObservationForDate(date, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return curveOf(obs)
+}
+
+// curveOf builds the curve of an observation, skipping the tenors without value
+func curveOf(obs *Observations) (*Curve, error) {
+	c := &Curve{Date: obs.D}
+	for _, t := range benchmarkTenors {
+		if v, ok := obs.Value(t.series); ok {
+			c.Tenors = append(c.Tenors, t.years)
+			c.Yields = append(c.Yields, v)
+		}
+	}
+	if len(c.Tenors) == 0 {
+		return nil, &DataError{Date: obs.D, Err: fmt.Errorf("%w: no benchmark yield", ErrNoValue)}
+	}
+	return c, nil
+}
@@ -0,0 +1,41 @@
+package boc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurve(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	c, err := YieldCurve(b, "2022-05-24")
+	a.NoError(err)
+	a.Equal("2022-05-24", c.Date)
+	a.Equal([]float64{2, 3, 5, 7, 10, 30}, c.Tenors)
+	a.Equal([]float64{2.57, 2.58, 2.64, 2.71, 2.78, 2.85}, c.Yields)
+
+	a.Equal(2.57, c.Yield(1))
+	a.Equal(2.64, c.Yield(5))
+	a.InDelta(2.61, c.Yield(4), 1e-9)
+	a.InDelta(2.815, c.Yield(20), 1e-9)
+	a.Equal(2.85, c.Yield(40))
+
+	_, err = YieldCurve(b, "2022-05-23")
+	a.ErrorIs(err, ErrNoData)
+	c, err = YieldCurve(b, "2022-05-23", ForwardFill())
+	a.NoError(err)
+	a.Equal(2.59, c.Yield(2))
+
+	_, err = curveOf(&Observations{D: "2022-05-24", YieldRRB: Val{V: "0.58"}})
+	a.ErrorIs(err, ErrNoValue)
+}
+
+func TestSeriesTenor(t *testing.T) {
+	years, ok := SeriesTenor(SeriesYield7Year)
+	assert.True(t, ok)
+	assert.Equal(t, 7.0, years)
+	_, ok = SeriesTenor(SeriesYieldRRB)
+	assert.False(t, ok)
+}
@@ -0,0 +1,192 @@
+package boc
+
+import (
+	"maps"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dataset holds the fetched data in columns indexed by date. It is never
+// modified once built: a refresh replaces it as a whole, so readers can keep
+// using the dataset they started with.
+type dataset struct {
+	// meta is the fetched data without its observations
+	meta BOCData
+	// dates are the dates with data, in ascending order
+	dates []string
+	// columns hold the values of each series of allSeries by date index, NaN when missing
+	columns [][]float64
+	// decimals are the number of decimals each value is published with, by
+	// column then date index, so that format returns the value as published
+	decimals [][]int8
+	// extra are the values of the series without a field by date index, see
+	// Observations.Extra, nil if no observation has any
+	extra []map[string]Val
+	// details are the series details by key, see BOCData.SeriesDetails
+	details map[string]Detail
+}
+
+// seriesColumns maps the series keys to their index in allSeries and dataset.columns
+var seriesColumns = func() map[string]int {
+	m := make(map[string]int, len(allSeries))
+	for i, key := range allSeries {
+		m[key] = i
+	}
+	return m
+}()
+
+// buildDataset stores the observations in columns, keeping the last one of a
+// duplicated date. It returns the duplicated dates.
+func buildDataset(data *BOCData) (*dataset, []string) {
+	observations := make([]*Observations, 0, len(data.Observations))
+	for i := range data.Observations {
+		observations = append(observations, &data.Observations[i])
+	}
+	sort.SliceStable(observations, func(i, j int) bool { return observations[i].D < observations[j].D })
+
+	var duplicates []string
+	d := &dataset{meta: *data, columns: make([][]float64, len(allSeries)), decimals: make([][]int8, len(allSeries))}
+	d.meta.Observations = nil
+	d.details = make(map[string]Detail, len(allSeries))
+	for _, key := range allSeries {
+		if detail := data.SeriesDetail.field(key); detail.Label != "" {
+			d.details[key] = *detail
+		}
+	}
+	maps.Copy(d.details, data.SeriesDetails)
+	for key, detail := range d.details {
+		if unit, ok := SeriesUnit(key); ok && detail.Unit == "" {
+			detail.Unit = unit
+			d.details[key] = detail
+		}
+	}
+	d.dates = make([]string, 0, len(observations))
+	for c := range d.columns {
+		d.columns[c] = make([]float64, 0, len(observations))
+		d.decimals[c] = make([]int8, 0, len(observations))
+	}
+	for _, obs := range observations {
+		last := len(d.dates) - 1
+		if last >= 0 && d.dates[last] == obs.D {
+			duplicates = append(duplicates, obs.D)
+			for c := range d.columns {
+				d.columns[c] = d.columns[c][:last]
+				d.decimals[c] = d.decimals[c][:last]
+			}
+			d.dates = d.dates[:last]
+			if d.extra != nil {
+				d.extra = d.extra[:last]
+			}
+		}
+		if len(obs.Extra) > 0 && d.extra == nil {
+			d.extra = make([]map[string]Val, len(d.dates), cap(d.dates))
+		}
+		if d.extra != nil {
+			d.extra = append(d.extra, maps.Clone(obs.Extra))
+		}
+		d.dates = append(d.dates, obs.D)
+		for c, key := range allSeries {
+			v, _ := obs.val(key)
+			d.columns[c] = append(d.columns[c], parseValue(v.V))
+			d.decimals[c] = append(d.decimals[c], int8(min(decimals(v.V), math.MaxInt8)))
+		}
+	}
+	return d, duplicates
+}
+
+// parseValue returns the value of a string, and NaN if it is empty or not a number
+func parseValue(s string) float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return f
+}
+
+// decimals returns the number of decimals of a value as published
+func decimals(s string) int {
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// index returns the index of a formatted date, and false if the date has no data
+func (d *dataset) index(date string) (int, bool) {
+	i := sort.SearchStrings(d.dates, date)
+	return i, i < len(d.dates) && d.dates[i] == date
+}
+
+// format returns the value of column c at date index i as published, and "" if it is missing
+func (d *dataset) format(c, i int) string {
+	v := d.columns[c][i]
+	if math.IsNaN(v) {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', int(d.decimals[c][i]), 64)
+}
+
+// precision returns the largest number of decimals of the values of column c
+// from date index from to to, and def if none is published
+func (d *dataset) precision(c, from, to, def int) int {
+	p := -1
+	for i := from; i < to; i++ {
+		if !math.IsNaN(d.columns[c][i]) {
+			p = max(p, int(d.decimals[c][i]))
+		}
+	}
+	if p < 0 {
+		return def
+	}
+	return p
+}
+
+// observation returns a new Observations with the values of date index i
+func (d *dataset) observation(i int) *Observations {
+	obs := &Observations{D: d.dates[i]}
+	for c, key := range allSeries {
+		obs.field(key).V = d.format(c, i)
+	}
+	if d.extra != nil && len(d.extra[i]) > 0 {
+		obs.Extra = maps.Clone(d.extra[i])
+	}
+	return obs
+}
+
+// bocData returns the data of the dataset with its observations in chronological order
+func (d *dataset) bocData() *BOCData {
+	data := d.meta
+	data.Observations = make([]Observations, 0, len(d.dates))
+	for i := range d.dates {
+		data.Observations = append(data.Observations, *d.observation(i))
+	}
+	return &data
+}
+
+// nearestDate returns the date with data closest to date, preferring the earlier one on ties
+func (d *dataset) nearestDate(date string) string {
+	if len(d.dates) == 0 {
+		return ""
+	}
+	i := sort.SearchStrings(d.dates, date)
+	if i == 0 {
+		return d.dates[0]
+	}
+	if i == len(d.dates) {
+		return d.dates[i-1]
+	}
+	before, after := d.dates[i-1], d.dates[i]
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return before
+	}
+	tBefore, _ := time.Parse("2006-01-02", before)
+	tAfter, _ := time.Parse("2006-01-02", after)
+	if tAfter.Sub(t) < t.Sub(tBefore) {
+		return after
+	}
+	return before
+}
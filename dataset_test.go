@@ -0,0 +1,69 @@
+package boc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildDataset(t *testing.T) {
+	a := assert.New(t)
+	data := &BOCData{
+		GroupDetail: GroupDetail{Label: "group"},
+		Observations: []Observations{
+			{D: "2022-05-25", Yield2Year: Val{V: "2.53"}, YieldRRB: Val{V: "0.5"}},
+			{D: "2022-05-24", Yield2Year: Val{V: "2.57"}, YieldRRB: Val{V: "foo"}},
+			{D: "2022-05-25", Yield2Year: Val{V: "2.54"}, YieldRRB: Val{V: "0.6"}},
+		},
+	}
+	ds, duplicates := buildDataset(data)
+	a.Equal([]string{"2022-05-25"}, duplicates)
+	a.Equal([]string{"2022-05-24", "2022-05-25"}, ds.dates)
+	a.Equal("group", ds.meta.GroupDetail.Label)
+	a.Nil(ds.meta.Observations)
+
+	c := seriesColumns[SeriesYield2Year]
+	a.Equal([]float64{2.57, 2.54}, ds.columns[c])
+	a.Equal([]int8{2, 2}, ds.decimals[c])
+	rrb := seriesColumns[SeriesYieldRRB]
+	a.True(math.IsNaN(ds.columns[rrb][0]), "invalid values are missing")
+	a.True(math.IsNaN(ds.columns[seriesColumns[SeriesYield10Year]][1]))
+
+	i, ok := ds.index("2022-05-25")
+	a.True(ok)
+	a.Equal(1, i)
+	_, ok = ds.index("2022-05-23")
+	a.False(ok)
+
+	obs := ds.observation(1)
+	a.Equal("2022-05-25", obs.D)
+	a.Equal("2.54", obs.Yield2Year.V)
+	a.Equal("0.6", obs.YieldRRB.V)
+	a.Equal("", obs.Yield10Year.V)
+	a.Equal("", ds.observation(0).YieldRRB.V)
+}
+
+func TestDatasetDecimals(t *testing.T) {
+	a := assert.New(t)
+	ds, _ := buildDataset(&BOCData{Observations: []Observations{
+		{D: "2022-05-24", Yield2Year: Val{V: "2.6"}},
+		{D: "2022-05-25", Yield2Year: Val{V: "2.535"}},
+		{D: "2022-05-26", Yield2Year: Val{V: "2.60"}},
+	}})
+	c := seriesColumns[SeriesYield2Year]
+	a.Equal("2.6", ds.format(c, 0), "each value keeps its own decimals")
+	a.Equal("2.535", ds.format(c, 1))
+	a.Equal("2.60", ds.format(c, 2))
+	a.Equal(3, ds.precision(c, 0, 3, 2))
+	a.Equal(2, ds.precision(c, 2, 3, 4))
+	a.Equal(4, ds.precision(seriesColumns[SeriesYield10Year], 0, 3, 4), "no value")
+}
+
+func TestDatasetRoundTrip(t *testing.T) {
+	a := assert.New(t)
+	data := readFixture(t)
+	ds, duplicates := buildDataset(data)
+	a.Empty(duplicates)
+	a.Equal(data, ds.bocData())
+}
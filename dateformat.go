@@ -0,0 +1,162 @@
+package boc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// timestampLayouts are the layouts of dates with a time component
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// namedLayouts are the layouts of dates with a month name
+var namedLayouts = []string{
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"Jan 2 2006",
+	"January 2 2006",
+	"2 Jan 2006",
+	"2 January 2006",
+	"2 Jan, 2006",
+	"2 January, 2006",
+	"Mon, Jan 2, 2006",
+	"Monday, January 2, 2006",
+	"Mon, 2 Jan 2006",
+	"Monday, 2 January 2006",
+}
+
+// formatSpecialDate formats the dates that are not made of three numeric parts:
+// YYYYMMDD, dates with a month name and timestamps. It returns false if date
+// is not one of those.
+func formatSpecialDate(date string) (string, bool) {
+	if len(date) == 8 && strings.IndexFunc(date, func(r rune) bool { return !unicode.IsDigit(r) }) == -1 {
+		if t, err := time.Parse("20060102", date); err == nil {
+			return t.Format("2006-01-02"), true
+		}
+		return "", false
+	}
+
+	layouts := []string(nil)
+	if strings.Contains(date, ":") {
+		layouts = timestampLayouts
+	} else if strings.IndexFunc(date, unicode.IsLetter) != -1 {
+		layouts = namedLayouts
+		date = strings.Join(strings.Fields(date), " ")
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t.Format("2006-01-02"), true
+		}
+	}
+	return "", false
+}
+
+// FormatDateStrict is like FormatDate but returns ErrAmbiguousDate instead of
+// guessing when the day and the month cannot be told apart, e.g. "03-04-2024".
+// Dates starting with the year are read as year, month, day.
+func FormatDateStrict(date string) (string, error) {
+	return formatDate(date, true)
+}
+
+// FormatDateLayout formats a date whose numeric parts follow layout, e.g.
+// "DD-MM-YYYY" or "MM/DD/YYYY". The layout is made of YYYY, MM (or M) and DD
+// (or D) in any order; the separators of date do not have to match the layout's.
+// Dates with a month name, YYYYMMDD and timestamps are accepted as with FormatDate.
+func FormatDateLayout(date, layout string) (string, error) {
+	date = strings.TrimSpace(date)
+	order, err := layoutOrder(layout)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.FieldsFunc(date, isDateSeparator)
+	if len(parts) != 3 || strings.IndexFunc(date, unicode.IsLetter) != -1 {
+		if formatted, ok := formatSpecialDate(date); ok {
+			return formatted, nil
+		}
+		return "", fmt.Errorf("date does not match layout %s: %s", layout, date)
+	}
+
+	values := make(map[byte]int)
+	for i, p := range parts {
+		nb, err := strconv.Atoi(p)
+		if err != nil || nb < 0 {
+			return "", fmt.Errorf("part should be digit: %s", p)
+		}
+		if order[i] == 'Y' && len(p) != 4 {
+			return "", fmt.Errorf("year should have 4 digits: %s", p)
+		}
+		values[order[i]] = nb
+	}
+	return validDate(values['Y'], values['M'], values['D'])
+}
+
+// validDate formats a date after checking that it exists in the calendar,
+// rejecting days like February 30 or April 31
+func validDate(year, month, day int) (string, error) {
+	if month < 1 || month > 12 {
+		return "", fmt.Errorf("invalid month: %d", month)
+	}
+	formatted := fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+	if _, err := time.Parse("2006-01-02", formatted); err != nil {
+		return "", fmt.Errorf("invalid day: %s", formatted)
+	}
+	return formatted, nil
+}
+
+// layoutOrder returns the order of the year, month and day in layout as a string like "DMY"
+func layoutOrder(layout string) (string, error) {
+	order := ""
+	for _, token := range strings.FieldsFunc(strings.ToUpper(layout), isDateSeparator) {
+		switch token {
+		case "YYYY":
+			order += "Y"
+		case "MM", "M":
+			order += "M"
+		case "DD", "D":
+			order += "D"
+		default:
+			return "", fmt.Errorf("invalid layout token %q in %s", token, layout)
+		}
+	}
+	if len(order) != 3 || !strings.Contains(order, "Y") || !strings.Contains(order, "M") || !strings.Contains(order, "D") {
+		return "", fmt.Errorf("invalid layout: %s", layout)
+	}
+	return order, nil
+}
+
+func isDateSeparator(r rune) bool {
+	return r == '-' || r == '/' || r == '\\' || r == '.' || unicode.IsSpace(r)
+}
+
+// formatDate formats a date according to the client's date options. The
+// relative dates, see RelativeDate, are resolved first.
+func (b *bocInterests) formatDate(date string) (string, error) {
+	if day, ok, err := RelativeDate(date, b.now()); err != nil {
+		return "", &DataError{Date: date, Err: fmt.Errorf("%w: %w", ErrInvalidDate, err)}
+	} else if ok {
+		return day.Format(time.DateOnly), nil
+	}
+	var formatted string
+	var err error
+	switch {
+	case b.dateLayout != "":
+		formatted, err = FormatDateLayout(date, b.dateLayout)
+	case b.strictDates:
+		formatted, err = FormatDateStrict(date)
+	default:
+		formatted, err = FormatDate(date)
+	}
+	if err != nil {
+		return "", &DataError{Date: date, Err: fmt.Errorf("%w: %w", ErrInvalidDate, err)}
+	}
+	return formatted, nil
+}
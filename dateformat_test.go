@@ -0,0 +1,91 @@
+package boc
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDateStrict(t *testing.T) {
+	tests := []struct {
+		date      string
+		want      string
+		ambiguous bool
+	}{
+		{date: "03-04-2024", ambiguous: true},
+		{date: "04/03/2024", ambiguous: true},
+		{date: "03-03-2024", want: "2024-03-03"},
+		{date: "13-04-2024", want: "2024-04-13"},
+		{date: "04-13-2024", want: "2024-04-13"},
+		{date: "2024-03-04", want: "2024-03-04"},
+		{date: "Mar 4, 2024", want: "2024-03-04"},
+	}
+	for _, tt := range tests {
+		got, err := FormatDateStrict(tt.date)
+		if tt.ambiguous {
+			assert.ErrorIs(t, err, ErrAmbiguousDate, tt.date)
+			continue
+		}
+		assert.NoError(t, err, tt.date)
+		assert.Equal(t, tt.want, got, tt.date)
+	}
+}
+
+func TestFormatDateLayout(t *testing.T) {
+	tests := []struct {
+		date    string
+		layout  string
+		want    string
+		wantErr bool
+	}{
+		{date: "03-04-2024", layout: "DD-MM-YYYY", want: "2024-04-03"},
+		{date: "03-04-2024", layout: "MM-DD-YYYY", want: "2024-03-04"},
+		{date: "3/4/2024", layout: "D/M/YYYY", want: "2024-04-03"},
+		{date: "2024.04.03", layout: "YYYY-MM-DD", want: "2024-04-03"},
+		{date: "2024 03 04", layout: "yyyy/dd/mm", want: "2024-04-03"},
+		{date: "2 January 2024", layout: "MM-DD-YYYY", want: "2024-01-02"},
+		{date: "20240102", layout: "DD-MM-YYYY", want: "2024-01-02"},
+		{date: "13-04-2024", layout: "MM-DD-YYYY", wantErr: true},
+		{date: "31-04-2024", layout: "DD-MM-YYYY", wantErr: true},
+		{date: "29-02-2024", layout: "DD-MM-YYYY", want: "2024-02-29"},
+		{date: "03-04-24", layout: "DD-MM-YYYY", wantErr: true},
+		{date: "03-04", layout: "DD-MM-YYYY", wantErr: true},
+		{date: "03-04-2024", layout: "DD-MM", wantErr: true},
+		{date: "03-04-2024", layout: "DD-DD-YYYY", wantErr: true},
+		{date: "03-04-2024", layout: "Day-MM-YYYY", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := FormatDateLayout(tt.date, tt.layout)
+		if tt.wantErr {
+			assert.Error(t, err, tt.date)
+			continue
+		}
+		assert.NoError(t, err, tt.date)
+		assert.Equal(t, tt.want, got, tt.date)
+	}
+}
+
+func TestDateOptions(t *testing.T) {
+	a := assert.New(t)
+	srv := newTestServer(t, http.StatusOK)
+
+	b, err := NewBOCInterests(WithBaseURL(srv.URL), WithStrictDates())
+	a.NoError(err)
+	_, err = b.GetObservationForDate("05/06/2022")
+	a.ErrorIs(err, ErrAmbiguousDate)
+	a.ErrorIs(err, ErrInvalidDate)
+	_, err = b.Between("05/06/2022", "")
+	a.ErrorIs(err, ErrAmbiguousDate)
+	obs, err := b.GetObservationForDate("2022-05-24")
+	a.NoError(err)
+	a.Equal("2022-05-24", obs.D)
+
+	b, err = NewBOCInterests(WithBaseURL(srv.URL), WithDateLayout("MM/DD/YYYY"))
+	a.NoError(err)
+	obs, err = b.GetObservationForDate("06/01/2022")
+	a.NoError(err)
+	a.Equal("2022-06-01", obs.D)
+	_, err = b.GetObservationForDate("24/05/2022")
+	a.ErrorIs(err, ErrInvalidDate)
+}
@@ -0,0 +1,148 @@
+package boc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+// decodeCheckInterval is the number of observations decoded between two checks of the context
+const decodeCheckInterval = 256
+
+// unknownFunc is called with the date, the key and the value of each series of
+// an observation that is not a field of Observations
+type unknownFunc func(date, key string, raw json.RawMessage)
+
+// decodeData decodes a Valet payload, stopping with the context error when ctx
+// is done. The observations are decoded one by one so that a cancelled fetch
+// does not keep parsing a large history. progress is called with the number of
+// observations decoded so far every decodeCheckInterval observations.
+// unknown and progress can be nil.
+func decodeData(ctx context.Context, r io.Reader, unknown unknownFunc, progress func(int)) (*BOCData, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	data := new(BOCData)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch key := tok.(string); key {
+		case "groupDetail":
+			err = dec.Decode(&data.GroupDetail)
+		case "terms":
+			err = dec.Decode(&data.Terms)
+		case "seriesDetail":
+			err = decodeSeriesDetail(dec, data)
+		case "observations":
+			data.Observations, err = decodeObservations(ctx, dec, unknown, progress)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// decodeSeriesDetail decodes the series details in the fixed fields of
+// SeriesDetail and in the SeriesDetails index
+func decodeSeriesDetail(dec *json.Decoder, data *BOCData) error {
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, &data.SeriesDetail); err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, &data.SeriesDetails)
+}
+
+func decodeObservations(ctx context.Context, dec *json.Decoder, unknown unknownFunc, progress func(int)) ([]Observations, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	if tok != json.Delim('[') {
+		return nil, fmt.Errorf("observations: expected an array, got %v", tok)
+	}
+	observations := make([]Observations, 0)
+	for dec.More() {
+		if len(observations)%decodeCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if progress != nil && len(observations) > 0 {
+				progress(len(observations))
+			}
+		}
+		var obs Observations
+		if err := decodeObservation(dec, &obs, unknown); err != nil {
+			return nil, err
+		}
+		observations = append(observations, obs)
+	}
+	return observations, expectDelim(dec, ']')
+}
+
+// decodeObservation decodes an observation into obs, keeping the values of the
+// series that are not a field of Observations in Extra. unknown is called with
+// them, in the order of their keys.
+func decodeObservation(dec *json.Decoder, obs *Observations, unknown unknownFunc) error {
+	var values map[string]json.RawMessage
+	if err := dec.Decode(&values); err != nil {
+		return err
+	}
+	if raw, ok := values["d"]; ok {
+		if err := json.Unmarshal(raw, &obs.D); err != nil {
+			return err
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if key == "d" {
+			continue
+		}
+		raw := values[key]
+		if field := obs.field(key); field != nil {
+			if err := json.Unmarshal(raw, field); err != nil {
+				return err
+			}
+			continue
+		}
+		if unknown != nil {
+			unknown(obs.D, key, raw)
+		}
+		var v Val
+		if err := json.Unmarshal(raw, &v); err == nil {
+			if obs.Extra == nil {
+				obs.Extra = make(map[string]Val)
+			}
+			obs.Extra[key] = v
+		}
+	}
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("expected %v, got %v", delim, tok)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package boc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeData(t *testing.T) {
+	a := assert.New(t)
+	raw, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+
+	data, err := decodeData(context.Background(), bytes.NewReader(raw), nil, nil)
+	a.NoError(err)
+	a.Len(data.SeriesDetails, 11)
+	a.Equal(data.SeriesDetail.Yield10Year, data.SeriesDetails[SeriesYield10Year])
+	data.SeriesDetails = nil
+	a.Equal(readFixture(t), data)
+
+	data, err = decodeData(context.Background(), strings.NewReader(`{"extra":{"a":[1]},"observations":null}`), nil, nil)
+	a.NoError(err)
+	a.Empty(data.Observations)
+
+	for _, invalid := range []string{``, `[]`, `{"observations":{}}`, `{"observations":[{"d":1}]}`, `{"terms":{}`} {
+		_, err = decodeData(context.Background(), strings.NewReader(invalid), nil, nil)
+		a.Error(err, invalid)
+	}
+}
+
+func TestDecodeDataCancelled(t *testing.T) {
+	a := assert.New(t)
+	var sb strings.Builder
+	sb.WriteString(`{"observations":[`)
+	for i := 0; i < 3*decodeCheckInterval; i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(&sb, `{"d":"2022-05-%02d"}`, i%28+1)
+	}
+	sb.WriteString(`]}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := decodeData(ctx, strings.NewReader(sb.String()), nil, nil)
+	a.ErrorIs(err, context.Canceled)
+
+	data, err := decodeData(context.Background(), strings.NewReader(sb.String()), nil, nil)
+	a.NoError(err)
+	a.Len(data.Observations, 3*decodeCheckInterval)
+}
+
+func TestUnknownSeries(t *testing.T) {
+	a := assert.New(t)
+	payload := `{"observations": [
+		{"d": "2022-05-24", "BD.CDN.2YR.DQ.YLD": {"v": "2.57"}, "BD.CDN.1YR.DQ.YLD": {"v": "2.40"}, "A.NEW": {"v": ""}},
+		{"d": "2022-05-25", "BD.CDN.2YR.DQ.YLD": {"v": "2.53"}, "BD.CDN.1YR.DQ.YLD": 1}
+	]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(payload))
+	}))
+	t.Cleanup(srv.Close)
+
+	type unknown struct{ date, key, value string }
+	var got []unknown
+	b := newBOCInterests(WithBaseURL(srv.URL), WithUnknownSeries(func(date, seriesKey string, value Val) {
+		got = append(got, unknown{date, seriesKey, value.V})
+	}))
+	require.NoError(t, b.load(context.Background()))
+	a.Equal([]unknown{
+		{"2022-05-24", "A.NEW", ""},
+		{"2022-05-24", "BD.CDN.1YR.DQ.YLD", "2.40"},
+	}, got, "the value that is not a Val is skipped")
+
+	obs, err := b.GetObservationForDate("2022-05-25")
+	require.NoError(t, err)
+	a.Equal("2.53", obs.Yield2Year.V)
+}
@@ -0,0 +1,149 @@
+package boc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+)
+
+// Revision is a value that changed between two fetches for a date that was already known
+type Revision struct {
+	Date   string
+	Series string
+	Old    string
+	New    string
+}
+
+// Diff reports what changed in the data between two fetches
+type Diff struct {
+	// Added are the dates that were not known before
+	Added []string
+	// Removed are the dates that are no longer published
+	Removed []string
+	// Revisions are the values that changed for already known dates
+	Revisions []Revision
+}
+
+// Empty reports whether the diff has no change
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Revisions) == 0
+}
+
+// changedDates returns the dates added or revised, in chronological order
+func (d Diff) changedDates() []string {
+	dates := append([]string(nil), d.Added...)
+	for _, rev := range d.Revisions {
+		if len(dates) == 0 || dates[len(dates)-1] != rev.Date {
+			dates = append(dates, rev.Date)
+		}
+	}
+	sort.Strings(dates)
+	return dates
+}
+
+// Refresh implements BOCInterests
+func (b *bocInterests) Refresh(ctx context.Context) error {
+	// the cached payload is what was already loaded, the fresh one replaces it
+	ctx = bypassCache(ctx)
+	b.updateMu.Lock()
+	old := b.current()
+	incremental := !b.fullRefresh && old != nil && len(old.dates) > 0
+	var data *BOCData
+	var err error
+	if incremental {
+		// the latest date is fetched again in case it was published while incomplete
+		var raw []byte
+		data, raw, err = b.fetchPayload(ctx, b.dataURL()+"?"+url.Values{"start_date": {old.dates[len(old.dates)-1]}}.Encode())
+		if err == nil {
+			data = mergeData(old.bocData(), data)
+			b.mergeRawJSON(raw)
+		}
+	} else {
+		data, err = b.fetchAll(ctx)
+	}
+	if err != nil {
+		b.updateMu.Unlock()
+		err = fmt.Errorf("error refreshing data: %w", err)
+		b.refreshErr.Store(&err)
+		if b.staleIfError && old != nil {
+			b.logger.Warn("refresh failed, serving stale data", "error", err, "fetched_at", old.meta.FetchedAt)
+			return nil
+		}
+		return err
+	}
+	data.FetchedAt = b.now()
+	ds := b.newDataset(ctx, data)
+	b.ds.Store(ds)
+	b.refreshErr.Store(nil)
+	b.store(ctx, data)
+	b.updateMu.Unlock()
+
+	diff := diffDatasets(old, ds)
+	for _, rev := range diff.Revisions {
+		b.logger.Warn("value revised", "date", rev.Date, "series", rev.Series, "old", rev.Old, "new", rev.New)
+	}
+	b.logger.Info("refresh finished", "added", len(diff.Added), "removed", len(diff.Removed), "revisions", len(diff.Revisions))
+	if !diff.Empty() {
+		for _, handler := range b.diffHandlers {
+			handler(diff)
+		}
+		b.checkAlerts(ds, diff)
+		b.writeSinks(ctx, ds, diff)
+	}
+	return nil
+}
+
+// mergeData returns the metadata of update with its observations replacing or
+// adding to the observations of data, in chronological order
+func mergeData(data, update *BOCData) *BOCData {
+	merged := *update
+	if data.LastModified.After(merged.LastModified) {
+		merged.LastModified = data.LastModified
+	}
+	updated := make(map[string]bool, len(update.Observations))
+	for _, obs := range update.Observations {
+		updated[obs.D] = true
+	}
+	merged.Observations = make([]Observations, 0, len(data.Observations)+len(update.Observations))
+	for _, obs := range data.Observations {
+		if !updated[obs.D] {
+			merged.Observations = append(merged.Observations, obs)
+		}
+	}
+	merged.Observations = append(merged.Observations, update.Observations...)
+	sort.SliceStable(merged.Observations, func(i, j int) bool {
+		return merged.Observations[i].D < merged.Observations[j].D
+	})
+	return &merged
+}
+
+// diffDatasets compares two datasets, old can be nil
+func diffDatasets(old, ds *dataset) Diff {
+	var diff Diff
+	if old == nil {
+		diff.Added = append(diff.Added, ds.dates...)
+		return diff
+	}
+	for i, date := range ds.dates {
+		j, ok := old.index(date)
+		if !ok {
+			diff.Added = append(diff.Added, date)
+			continue
+		}
+		for c, key := range allSeries {
+			// compared as numbers so that "2.6" published again as "2.60" is no revision
+			oldVal, newVal := old.columns[c][j], ds.columns[c][i]
+			if oldVal != newVal && !(math.IsNaN(oldVal) && math.IsNaN(newVal)) {
+				diff.Revisions = append(diff.Revisions, Revision{Date: date, Series: key, Old: old.format(c, j), New: ds.format(c, i)})
+			}
+		}
+	}
+	for _, date := range old.dates {
+		if _, ok := ds.index(date); !ok {
+			diff.Removed = append(diff.Removed, date)
+		}
+	}
+	return diff
+}
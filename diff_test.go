@@ -0,0 +1,116 @@
+package boc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshDiff(t *testing.T) {
+	a := assert.New(t)
+	data := readFixture(t)
+	srv := newDataServer(t, func() *BOCData { return data })
+
+	diffs := make([]Diff, 0)
+	b := newBOCInterests(WithBaseURL(srv.URL), WithFullRefresh(), WithDiffHandler(func(d Diff) { diffs = append(diffs, d) }))
+	a.NoError(b.load(context.Background()))
+
+	a.NoError(b.Refresh(context.Background()))
+	a.Empty(diffs, "no handler call without change")
+
+	revised := readFixture(t)
+	revised.Observations[1].Yield10Year.V = "2.79"
+	revised.Observations[4].YieldRRB.V = "0.60"
+	revised.Observations[5].Yield2Year.V += "0"
+	revised.Observations = append(revised.Observations[1:], Observations{D: "2022-06-02", Yield2Year: Val{V: "2.80"}})
+	data = revised
+
+	a.NoError(b.Refresh(context.Background()))
+	a.Len(diffs, 1)
+	a.Equal([]string{"2022-06-02"}, diffs[0].Added)
+	a.Equal([]string{"2022-05-20"}, diffs[0].Removed)
+	a.Equal([]Revision{
+		{Date: "2022-05-24", Series: SeriesYield10Year, Old: "2.78", New: "2.79"},
+		{Date: "2022-05-27", Series: SeriesYieldRRB, Old: "", New: "0.60"},
+	}, diffs[0].Revisions, "a value published with more decimals is no revision")
+
+	v, err := b.SeriesValue("2022-05-24", SeriesYield10Year)
+	a.NoError(err)
+	a.Equal(2.79, v)
+}
+
+func TestIncrementalRefresh(t *testing.T) {
+	a := assert.New(t)
+	data := readFixture(t)
+	queries := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		json.NewEncoder(w).Encode(data)
+	}))
+	t.Cleanup(srv.Close)
+
+	diffs := make([]Diff, 0)
+	b := newBOCInterests(WithBaseURL(srv.URL), WithDiffHandler(func(d Diff) { diffs = append(diffs, d) }))
+	a.NoError(b.load(context.Background()))
+
+	update := readFixture(t)
+	update.Observations = []Observations{
+		{D: "2022-06-01", Yield2Year: Val{V: "2.74"}},
+		{D: "2022-06-02", Yield2Year: Val{V: "2.80"}},
+	}
+	data = update
+	a.NoError(b.Refresh(context.Background()))
+	a.Equal([]string{"", "start_date=2022-06-01"}, queries)
+
+	observations := b.Observations()
+	a.Len(observations, 9)
+	a.Equal("2022-05-20", observations[0].D)
+	a.Equal("2022-06-02", observations[8].D)
+	v, err := b.SeriesValue("2022-06-01", SeriesYield2Year)
+	a.NoError(err)
+	a.Equal(2.74, v)
+
+	a.Len(diffs, 1)
+	a.Equal([]string{"2022-06-02"}, diffs[0].Added)
+	a.Empty(diffs[0].Removed)
+	a.Len(diffs[0].Revisions, len(allSeries))
+
+	raw, err := b.RawMap()
+	a.NoError(err)
+	a.Len(raw["observations"], 9, "RawJSON is the payload of the merged data")
+}
+
+func TestMergeData(t *testing.T) {
+	a := assert.New(t)
+	data := &BOCData{Observations: []Observations{{D: "2022-05-24"}, {D: "2022-05-25", Yield2Year: Val{V: "1"}}}}
+	update := &BOCData{
+		GroupDetail:  GroupDetail{Label: "updated"},
+		Observations: []Observations{{D: "2022-05-26"}, {D: "2022-05-25", Yield2Year: Val{V: "2"}}},
+	}
+	merged := mergeData(data, update)
+	a.Equal("updated", merged.GroupDetail.Label)
+	a.Equal([]Observations{{D: "2022-05-24"}, {D: "2022-05-25", Yield2Year: Val{V: "2"}}, {D: "2022-05-26"}}, merged.Observations)
+	a.Len(data.Observations, 2, "data is not modified")
+}
+
+func TestRefreshError(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	srv := newTestServer(t, http.StatusBadGateway)
+	b.baseURL = srv.URL
+
+	a.ErrorIs(b.Refresh(context.Background()), ErrBadStatus)
+	_, err := b.GetObservationForDate("2022-05-24")
+	a.NoError(err, "data is kept when refresh fails")
+}
+
+func TestDiffEmpty(t *testing.T) {
+	a := assert.New(t)
+	a.True(Diff{}.Empty())
+	a.False(Diff{Added: []string{"2022-05-24"}}.Empty())
+	a.False(Diff{Revisions: []Revision{{Date: "2022-05-24"}}}.Empty())
+}
@@ -0,0 +1,26 @@
+package boc
+
+// Cashflow is an amount paid in a number of years
+type Cashflow struct {
+	Years  float64
+	Amount float64
+}
+
+// DiscountFactor returns the discount factor for a maturity in years using the
+// zero curve of client for a date
+func DiscountFactor(client ObservationReader, date string, years float64) (float64, error) {
+	z, err := BootstrapCurve(client, date)
+	if err != nil {
+		return 0, err
+	}
+	return z.DiscountFactor(years), nil
+}
+
+// PV returns the present value of cashflows discounted with the zero curve of client for a date
+func PV(client ObservationReader, cashflows []Cashflow, date string) (float64, error) {
+	z, err := BootstrapCurve(client, date)
+	if err != nil {
+		return 0, err
+	}
+	return z.PV(cashflows), nil
+}
@@ -0,0 +1,47 @@
+package boc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscountFactor(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	z, err := BootstrapCurve(b, "2022-05-24")
+	a.NoError(err)
+
+	df, err := DiscountFactor(b, "2022-05-24", 5)
+	a.NoError(err)
+	a.Equal(z.DiscountFactor(5), df)
+
+	df, err = DiscountFactor(b, "2022-05-24", 0)
+	a.NoError(err)
+	a.Equal(1.0, df)
+
+	_, err = DiscountFactor(b, "2022-05-23", 5)
+	a.ErrorIs(err, ErrNoData)
+}
+
+func TestPV(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	// a 5 year bond paying the 5 year par yield is worth par
+	cashflows := make([]Cashflow, 0)
+	for k := 1; k <= 10; k++ {
+		cashflows = append(cashflows, Cashflow{Years: float64(k) / 2, Amount: 2.64 / 2})
+	}
+	cashflows = append(cashflows, Cashflow{Years: 5, Amount: 100})
+	pv, err := PV(b, cashflows, "2022-05-24")
+	a.NoError(err)
+	a.InDelta(100, pv, 1e-9)
+
+	pv, err = PV(b, nil, "2022-05-24")
+	a.NoError(err)
+	a.Equal(0.0, pv)
+
+	_, err = PV(b, cashflows, "2022-05-23")
+	a.ErrorIs(err, ErrNoData)
+}
@@ -0,0 +1,95 @@
+package boc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidDate is returned when a date cannot be parsed
+	ErrInvalidDate = errors.New("invalid date format")
+	// ErrAmbiguousDate is returned in strict mode when the day and the month of a date cannot be told apart
+	ErrAmbiguousDate = errors.New("ambiguous date")
+	// ErrNoData is returned when there is no observation for a date
+	ErrNoData = errors.New("no data for this date")
+	// ErrUnknownSeries is returned when a series key is not part of the group
+	ErrUnknownSeries = errors.New("unknown series")
+	// ErrNoValue is returned when an observation has no value for a series
+	ErrNoValue = errors.New("no value for this series")
+	// ErrNoLegacySeries is returned by LongRun for a series without long-run monthly series
+	ErrNoLegacySeries = errors.New("no long-run monthly series")
+	// ErrBadStatus is returned when the Valet API answers with a non 200 status code
+	ErrBadStatus = errors.New("invalid response code")
+	// ErrRateLimited is returned when the Valet API answers 429 Too Many
+	// Requests. It wraps ErrBadStatus.
+	ErrRateLimited = fmt.Errorf("%w: rate limited", ErrBadStatus)
+	// ErrTimeout is returned when a request to the Valet API takes longer than the timeout
+	ErrTimeout = errors.New("request timed out")
+	// ErrCircuitOpen is returned without contacting the Valet API while the circuit breaker is open
+	ErrCircuitOpen = errors.New("circuit breaker open")
+	// ErrInvalidMortgage is returned for a Mortgage that cannot be amortized, e.g. over 0 years
+	ErrInvalidMortgage = errors.New("invalid mortgage")
+	// ErrCorruptSnapshot is returned when a snapshot is truncated or does not match its checksum
+	ErrCorruptSnapshot = errors.New("corrupt snapshot")
+)
+
+// snippetSize is the maximum number of bytes of a response body kept in a DataError
+const snippetSize = 512
+
+// DataError is returned from lookups and fetches. It carries the details needed
+// to present an actionable message or to fall back on another date.
+type DataError struct {
+	// Date is the date that was requested, as given by the caller
+	Date string
+	// NearestDate is the closest date that has data, empty if unknown
+	NearestDate string
+	// Series is the series key involved in the error, if any
+	Series string
+	// StatusCode is the HTTP status code of the response, if any
+	StatusCode int
+	// Snippet is the beginning of the response body, if any
+	Snippet string
+	// RetryAfter is the wait requested by the Retry-After header of a 429 or 503 response, if any
+	RetryAfter time.Duration
+	// Err is the underlying error
+	Err error
+}
+
+// Error implements error
+func (e *DataError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Err.Error())
+	if e.Date != "" {
+		fmt.Fprintf(&sb, ": %s", e.Date)
+	}
+	if e.Series != "" {
+		fmt.Fprintf(&sb, " (series %s)", e.Series)
+	}
+	if e.NearestDate != "" {
+		fmt.Fprintf(&sb, " (nearest date with data: %s)", e.NearestDate)
+	}
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&sb, ": %d", e.StatusCode)
+	}
+	if e.RetryAfter > 0 {
+		fmt.Fprintf(&sb, " (retry after %s)", e.RetryAfter)
+	}
+	if e.Snippet != "" {
+		fmt.Fprintf(&sb, "\n\nResp data: %s", e.Snippet)
+	}
+	return sb.String()
+}
+
+// Unwrap returns the underlying error
+func (e *DataError) Unwrap() error {
+	return e.Err
+}
+
+func snippet(data []byte) string {
+	if len(data) > snippetSize {
+		data = data[:snippetSize]
+	}
+	return string(data)
+}
@@ -0,0 +1,72 @@
+package boc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataErrorLookup(t *testing.T) {
+	b := newTestBOC(t)
+
+	tests := []struct {
+		name    string
+		date    string
+		nearest string
+		wantErr error
+	}{
+		{
+			name:    "holiday",
+			date:    "2022-05-23",
+			nearest: "2022-05-24",
+			wantErr: ErrNoData,
+		},
+		{
+			name:    "weekend tie prefers earlier date",
+			date:    "2022-05-22",
+			nearest: "2022-05-20",
+			wantErr: ErrNoData,
+		},
+		{
+			name:    "after last date",
+			date:    "2023-01-01",
+			nearest: "2022-06-01",
+			wantErr: ErrNoData,
+		},
+		{
+			name:    "invalid date",
+			date:    "not-a-date",
+			wantErr: ErrInvalidDate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			obs, err := b.GetObservationForDate(tt.date)
+			a.Nil(obs)
+			a.ErrorIs(err, tt.wantErr)
+
+			var dataErr *DataError
+			a.True(errors.As(err, &dataErr))
+			a.Equal(tt.nearest, dataErr.NearestDate)
+		})
+	}
+}
+
+func TestDataErrorFetch(t *testing.T) {
+	a := assert.New(t)
+	srv := newTestServer(t, http.StatusServiceUnavailable)
+	b := newBOCInterests(WithBaseURL(srv.URL))
+
+	err := b.load(context.Background())
+	a.ErrorIs(err, ErrBadStatus)
+
+	var dataErr *DataError
+	a.True(errors.As(err, &dataErr))
+	a.Equal(http.StatusServiceUnavailable, dataErr.StatusCode)
+	a.Equal("service unavailable", dataErr.Snippet)
+	a.Contains(err.Error(), "503")
+}
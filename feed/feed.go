@@ -0,0 +1,226 @@
+// Package feed builds RSS 2.0 and Atom feeds of the daily observations and of
+// the alert events of a client, for feed readers and automation tools.
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// DefaultEntries is the number of observations in a feed built by Observations
+const DefaultEntries = 30
+
+// Entry is an item of a feed
+type Entry struct {
+	// ID is a stable URN identifying the entry
+	ID      string
+	Title   string
+	Content string
+	Updated time.Time
+}
+
+// Feed is a list of entries, newest first
+type Feed struct {
+	Title string
+	Link  string
+	// Rights is the attribution of the data, see boc.Attribution
+	Rights  string
+	Entries []Entry
+}
+
+// Observations returns the entries of the latest n observations of client,
+// newest first, DefaultEntries if n is not positive
+func Observations(client boc.ObservationReader, n int) []Entry {
+	if n <= 0 {
+		n = DefaultEntries
+	}
+	observations := client.Observations()
+	observations = observations[max(len(observations)-n, 0):]
+	entries := make([]Entry, 0, len(observations))
+	for i := len(observations) - 1; i >= 0; i-- {
+		obs := observations[i]
+		updated, err := time.Parse("2006-01-02", obs.D)
+		if err != nil {
+			continue
+		}
+		var content strings.Builder
+		for _, key := range boc.AllSeries() {
+			if v, ok := obs.Value(key); ok {
+				label, _ := boc.SeriesLabel(key)
+				fmt.Fprintf(&content, "%s: %.2f%%\n", label, v)
+			}
+		}
+		entries = append(entries, Entry{
+			ID:      "urn:boc:observation:" + obs.D,
+			Title:   "Bank of Canada yields for " + obs.D,
+			Content: content.String(),
+			Updated: updated,
+		})
+	}
+	return entries
+}
+
+// AlertLog keeps the latest alert events, to publish them in a feed
+type AlertLog struct {
+	mu      sync.Mutex
+	size    int
+	now     func() time.Time
+	entries []Entry
+}
+
+// NewAlertLog returns an AlertLog keeping the latest size events
+func NewAlertLog(size int) *AlertLog {
+	return &AlertLog{size: size, now: time.Now}
+}
+
+// Handler returns a handler for boc.WithAlert recording the events
+func (l *AlertLog) Handler() func(boc.AlertEvent) {
+	return func(event boc.AlertEvent) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.entries = append(l.entries, Entry{
+			ID:      "urn:boc:alert:" + url.PathEscape(event.Name) + ":" + event.Date,
+			Title:   fmt.Sprintf("Alert: %s on %s", event.Name, event.Date),
+			Content: fmt.Sprintf("The alert %s was triggered by the observation of %s.", event.Name, event.Date),
+			Updated: l.now(),
+		})
+		if len(l.entries) > l.size {
+			l.entries = l.entries[len(l.entries)-l.size:]
+		}
+	}
+}
+
+// Entries returns the entries of the recorded events, newest first
+func (l *AlertLog) Entries() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := make([]Entry, len(l.entries))
+	for i, entry := range l.entries {
+		entries[len(entries)-1-i] = entry
+	}
+	return entries
+}
+
+// Merge returns the entries of the lists, newest first
+func Merge(lists ...[]Entry) []Entry {
+	var entries []Entry
+	for _, list := range lists {
+		entries = append(entries, list...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Updated.After(entries[j].Updated) })
+	return entries
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Rights  string      `xml:"rights,omitempty"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Content atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// WriteAtom writes the feed as an Atom 1.0 document
+func (f *Feed) WriteAtom(w io.Writer) error {
+	doc := atomFeed{ID: f.Link, Title: f.Title, Updated: f.updated().Format(time.RFC3339), Link: atomLink{Href: f.Link, Rel: "self"}, Rights: f.Rights}
+	for _, entry := range f.Entries {
+		doc.Entries = append(doc.Entries, atomEntry{
+			ID:      entry.ID,
+			Title:   entry.Title,
+			Updated: entry.Updated.UTC().Format(time.RFC3339),
+			Content: atomContent{Type: "text", Body: entry.Content},
+		})
+	}
+	return writeXML(w, doc)
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Copyright     string    `xml:"copyright,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	GUID        rssGUID `xml:"guid"`
+	Title       string  `xml:"title"`
+	Description string  `xml:"description"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	ID          string `xml:",chardata"`
+}
+
+// WriteRSS writes the feed as an RSS 2.0 document
+func (f *Feed) WriteRSS(w io.Writer) error {
+	doc := rssFeed{Version: "2.0", Channel: rssChannel{Title: f.Title, Link: f.Link, Description: f.Title, Copyright: f.Rights, LastBuildDate: f.updated().Format(time.RFC1123Z)}}
+	for _, entry := range f.Entries {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			GUID:        rssGUID{ID: entry.ID},
+			Title:       entry.Title,
+			Description: entry.Content,
+			PubDate:     entry.Updated.UTC().Format(time.RFC1123Z),
+		})
+	}
+	return writeXML(w, doc)
+}
+
+// updated returns the time of the newest entry
+func (f *Feed) updated() time.Time {
+	var updated time.Time
+	for _, entry := range f.Entries {
+		if entry.Updated.After(updated) {
+			updated = entry.Updated
+		}
+	}
+	return updated.UTC()
+}
+
+func writeXML(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,99 @@
+package feed
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+	"github.com/clauderoy790/bank-of-canada-interests-rates/internal/testutil"
+)
+
+func TestObservations(t *testing.T) {
+	a := assert.New(t)
+	client := testutil.NewClient(t)
+
+	entries := Observations(client, 2)
+	require.Len(t, entries, 2)
+	a.Equal("urn:boc:observation:2022-06-01", entries[0].ID)
+	a.Equal("Bank of Canada yields for 2022-06-01", entries[0].Title)
+	a.Equal(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC), entries[0].Updated)
+	a.Contains(entries[0].Content, "10 year benchmark yield: 2.97%\n")
+	a.Equal("urn:boc:observation:2022-05-31", entries[1].ID)
+
+	a.Len(Observations(client, 0), 8, "all the observations are fewer than DefaultEntries")
+}
+
+func TestAlertLog(t *testing.T) {
+	a := assert.New(t)
+	log := NewAlertLog(2)
+	now := time.Date(2022, 6, 1, 16, 0, 0, 0, time.UTC)
+	log.now = func() time.Time { return now }
+	handler := log.Handler()
+	for _, name := range []string{"a", "b", "c"} {
+		handler(boc.AlertEvent{Name: name, Date: "2022-06-01"})
+		now = now.Add(time.Minute)
+	}
+	entries := log.Entries()
+	require.Len(t, entries, 2)
+	a.Equal("urn:boc:alert:c:2022-06-01", entries[0].ID)
+	a.Equal("Alert: b on 2022-06-01", entries[1].Title)
+
+	merged := Merge([]Entry{{ID: "obs", Updated: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)}}, entries)
+	a.Equal([]string{"urn:boc:alert:c:2022-06-01", "urn:boc:alert:b:2022-06-01", "obs"}, []string{merged[0].ID, merged[1].ID, merged[2].ID})
+}
+
+func testFeed() *Feed {
+	return &Feed{
+		Title:  "Bank of Canada bond yields",
+		Link:   "http://example.com/feed.atom",
+		Rights: "Source: Bank of Canada. https://www.bankofcanada.ca/terms/",
+		Entries: []Entry{
+			{ID: "urn:boc:observation:2022-06-01", Title: "Bank of Canada yields for 2022-06-01", Content: "2 year benchmark yield: 2.73%\n", Updated: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+}
+
+func TestWriteAtom(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, testFeed().WriteAtom(&sb))
+	assert.Equal(t, xml.Header+`<feed xmlns="http://www.w3.org/2005/Atom">
+  <id>http://example.com/feed.atom</id>
+  <title>Bank of Canada bond yields</title>
+  <updated>2022-06-01T00:00:00Z</updated>
+  <link href="http://example.com/feed.atom" rel="self"></link>
+  <rights>Source: Bank of Canada. https://www.bankofcanada.ca/terms/</rights>
+  <entry>
+    <id>urn:boc:observation:2022-06-01</id>
+    <title>Bank of Canada yields for 2022-06-01</title>
+    <updated>2022-06-01T00:00:00Z</updated>
+    <content type="text">2 year benchmark yield: 2.73%&#xA;</content>
+  </entry>
+</feed>
+`, sb.String())
+}
+
+func TestWriteRSS(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, testFeed().WriteRSS(&sb))
+	assert.Equal(t, xml.Header+`<rss version="2.0">
+  <channel>
+    <title>Bank of Canada bond yields</title>
+    <link>http://example.com/feed.atom</link>
+    <description>Bank of Canada bond yields</description>
+    <copyright>Source: Bank of Canada. https://www.bankofcanada.ca/terms/</copyright>
+    <lastBuildDate>Wed, 01 Jun 2022 00:00:00 +0000</lastBuildDate>
+    <item>
+      <guid isPermaLink="false">urn:boc:observation:2022-06-01</guid>
+      <title>Bank of Canada yields for 2022-06-01</title>
+      <description>2 year benchmark yield: 2.73%&#xA;</description>
+      <pubDate>Wed, 01 Jun 2022 00:00:00 +0000</pubDate>
+    </item>
+  </channel>
+</rss>
+`, sb.String())
+}
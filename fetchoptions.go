@@ -0,0 +1,90 @@
+package boc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Order is the order of the observations returned by the Valet API
+type Order string
+
+const (
+	// OrderAsc returns the oldest observations first, the default
+	OrderAsc Order = "asc"
+	// OrderDesc returns the most recent observations first
+	OrderDesc Order = "desc"
+)
+
+// FetchOption sets a parameter of the Valet API for Fetch
+type FetchOption func(*fetchParams)
+
+type fetchParams struct {
+	recent     int
+	order      Order
+	start, end string
+}
+
+// Recent limits the fetch to the n most recent observations
+func Recent(n int) FetchOption {
+	return func(p *fetchParams) {
+		p.recent = n
+	}
+}
+
+// Ordered sets the order of the observations returned by the Valet API
+func Ordered(order Order) FetchOption {
+	return func(p *fetchParams) {
+		p.order = order
+	}
+}
+
+// DateRange limits the fetch to the observations from start to end inclusively.
+// An empty start or end leaves that side of the range open.
+func DateRange(start, end string) FetchOption {
+	return func(p *fetchParams) {
+		p.start, p.end = start, end
+	}
+}
+
+// Fetch implements BOCInterests
+func (b *bocInterests) Fetch(ctx context.Context, opts ...FetchOption) ([]Observations, []byte, error) {
+	p := new(fetchParams)
+	for _, opt := range opts {
+		opt(p)
+	}
+	start, end, err := b.formatRange(p.start, p.end)
+	if err != nil {
+		return nil, nil, err
+	}
+	query := url.Values{}
+	if p.recent < 0 {
+		return nil, nil, fmt.Errorf("invalid number of recent observations: %d", p.recent)
+	}
+	if p.recent > 0 {
+		query.Set("recent", strconv.Itoa(p.recent))
+	}
+	switch p.order {
+	case "":
+	case OrderAsc, OrderDesc:
+		query.Set("order_dir", string(p.order))
+	default:
+		return nil, nil, fmt.Errorf("invalid order: %q", p.order)
+	}
+	if start != "" {
+		query.Set("start_date", start)
+	}
+	if end != "" {
+		query.Set("end_date", end)
+	}
+	u := b.dataURL()
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	data, raw, err := b.fetchPayload(ctx, u)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data.Observations, raw, nil
+}
@@ -0,0 +1,64 @@
+package boc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetch(t *testing.T) {
+	a := assert.New(t)
+	fixture := readFixture(t)
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		queries = append(queries, query)
+		data := *fixture
+		observations := append([]Observations(nil), data.Observations...)
+		if query.Get("order_dir") == "desc" {
+			for i, j := 0, len(observations)-1; i < j; i, j = i+1, j-1 {
+				observations[i], observations[j] = observations[j], observations[i]
+			}
+		}
+		if n, err := strconv.Atoi(query.Get("recent")); err == nil {
+			observations = observations[:n]
+		}
+		data.Observations = observations
+		json.NewEncoder(w).Encode(&data)
+	}))
+	t.Cleanup(srv.Close)
+	b := newBOCInterests(WithBaseURL(srv.URL))
+	require.NoError(t, b.load(context.Background()))
+
+	loaded := b.RawJSON()
+	observations, raw, err := b.Fetch(context.Background(), Recent(2), Ordered(OrderDesc))
+	require.NoError(t, err)
+	a.Equal(loaded, b.RawJSON(), "RawJSON is still the payload of the data in use")
+	var payload BOCData
+	require.NoError(t, json.Unmarshal(raw, &payload))
+	a.Len(payload.Observations, 2, "the payload of the fetch is returned")
+	require.Len(t, observations, 2)
+	a.Equal("2022-06-01", observations[0].D)
+	a.Equal("2022-05-31", observations[1].D)
+	a.Equal(url.Values{"recent": {"2"}, "order_dir": {"desc"}}, queries[1])
+	a.Len(b.Observations(), 8, "the data in use is unchanged")
+
+	_, _, err = b.Fetch(context.Background(), DateRange("24-05-2022", ""))
+	require.NoError(t, err)
+	a.Equal(url.Values{"start_date": {"2022-05-24"}}, queries[2])
+
+	_, _, err = b.Fetch(context.Background(), DateRange("", "foo"))
+	a.ErrorIs(err, ErrInvalidDate)
+	_, _, err = b.Fetch(context.Background(), Ordered("random"))
+	a.Error(err)
+	_, _, err = b.Fetch(context.Background(), Recent(-1))
+	a.Error(err)
+	a.Len(queries, 3)
+}
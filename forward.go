@@ -0,0 +1,26 @@
+package boc
+
+import (
+	"fmt"
+	"math"
+)
+
+// ForwardRate returns the forward rate in percent, compounded semi-annually,
+// between two maturities in years. The 5y5y forward is ForwardRate(5, 10).
+func (z *ZeroCurve) ForwardRate(start, end float64) (float64, error) {
+	if start < 0 || end <= start {
+		return 0, fmt.Errorf("invalid forward period from %g to %g years", start, end)
+	}
+	ratio := z.DiscountFactor(start) / z.DiscountFactor(end)
+	return 200 * (math.Pow(ratio, 1/(2*(end-start))) - 1), nil
+}
+
+// ForwardRate returns the forward rate in percent between two maturities in
+// years from the zero curve of client for a date
+func ForwardRate(client ObservationReader, date string, start, end float64) (float64, error) {
+	z, err := BootstrapCurve(client, date)
+	if err != nil {
+		return 0, err
+	}
+	return z.ForwardRate(start, end)
+}
@@ -0,0 +1,51 @@
+package boc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZeroCurveForwardRate(t *testing.T) {
+	a := assert.New(t)
+	flat := (&Curve{Tenors: []float64{2, 10}, Yields: []float64{3, 3}}).Bootstrap()
+	z := (&Curve{Tenors: []float64{2, 5, 10}, Yields: []float64{2, 3, 3.5}}).Bootstrap()
+
+	f, err := flat.ForwardRate(5, 10)
+	a.NoError(err)
+	a.InDelta(3, f, 1e-9)
+
+	f, err = z.ForwardRate(0, 5)
+	a.NoError(err)
+	a.InDelta(z.ZeroRate(5), f, 1e-9)
+
+	// investing 5 years then rolling at the 5y5y forward earns the 10 year zero rate
+	f, err = z.ForwardRate(5, 10)
+	a.NoError(err)
+	a.Greater(f, z.ZeroRate(10))
+	a.InDelta(math.Pow(1+z.ZeroRate(10)/200, 20), math.Pow(1+z.ZeroRate(5)/200, 10)*math.Pow(1+f/200, 10), 1e-9)
+
+	_, err = z.ForwardRate(5, 5)
+	a.Error(err)
+	_, err = z.ForwardRate(-1, 5)
+	a.Error(err)
+}
+
+func TestForwardRate(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	z, err := BootstrapCurve(b, "2022-05-24")
+	a.NoError(err)
+	want, err := z.ForwardRate(5, 10)
+	a.NoError(err)
+	f, err := ForwardRate(b, "2022-05-24", 5, 10)
+	a.NoError(err)
+	a.Equal(want, f)
+
+	_, err = ForwardRate(b, "2022-05-23", 5, 10)
+	a.ErrorIs(err, ErrNoData)
+	_, err = ForwardRate(b, "2022-05-24", 10, 5)
+	a.Error(err)
+}
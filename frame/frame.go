@@ -0,0 +1,51 @@
+// Package frame exports the observations of a client as a gota dataframe.
+package frame
+
+import (
+	"math"
+
+	"github.com/go-gota/gota/dataframe"
+	"github.com/go-gota/gota/series"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// DateColumn is the name of the column holding the dates, formatted as YYYY-MM-DD
+const DateColumn = "date"
+
+// ToDataFrame returns a dataframe with one row per date from start to end and
+// a DateColumn followed by one float column per series, named by series key.
+// All the series of the group are used if seriesKeys is empty. Missing values are NaN.
+func ToDataFrame(client boc.ObservationReader, seriesKeys []string, start, end string, opts ...boc.QueryOption) (dataframe.DataFrame, error) {
+	if len(seriesKeys) == 0 {
+		seriesKeys = boc.AllSeries()
+	}
+	for _, key := range seriesKeys {
+		if _, ok := boc.SeriesLabel(key); !ok {
+			return dataframe.DataFrame{}, &boc.DataError{Series: key, Err: boc.ErrUnknownSeries}
+		}
+	}
+	seq, err := client.Between(start, end, opts...)
+	if err != nil {
+		return dataframe.DataFrame{}, err
+	}
+	dates := make([]string, 0)
+	columns := make([][]float64, len(seriesKeys))
+	for date, obs := range seq {
+		dates = append(dates, date)
+		for i, key := range seriesKeys {
+			v, ok := obs.Value(key)
+			if !ok {
+				v = math.NaN()
+			}
+			columns[i] = append(columns[i], v)
+		}
+	}
+	cols := make([]series.Series, 0, len(seriesKeys)+1)
+	cols = append(cols, series.New(dates, series.String, DateColumn))
+	for i, key := range seriesKeys {
+		cols = append(cols, series.New(columns[i], series.Float, key))
+	}
+	df := dataframe.New(cols...)
+	return df, df.Err
+}
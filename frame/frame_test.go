@@ -0,0 +1,35 @@
+package frame
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+	"github.com/clauderoy790/bank-of-canada-interests-rates/internal/testutil"
+)
+
+func TestToDataFrame(t *testing.T) {
+	a := assert.New(t)
+	client := testutil.NewClient(t)
+
+	df, err := ToDataFrame(client, []string{boc.SeriesYield2Year, boc.SeriesYieldRRB}, "2022-05-26", "2022-05-30")
+	require.NoError(t, err)
+	a.Equal([]string{DateColumn, boc.SeriesYield2Year, boc.SeriesYieldRRB}, df.Names())
+	a.Equal(3, df.Nrow())
+	a.Equal([]string{"2022-05-26", "2022-05-27", "2022-05-30"}, df.Col(DateColumn).Records())
+	a.Equal(2.65, df.Col(boc.SeriesYield2Year).Float()[2])
+	a.True(math.IsNaN(df.Col(boc.SeriesYieldRRB).Float()[1]))
+
+	df, err = ToDataFrame(client, nil, "", "")
+	require.NoError(t, err)
+	a.Equal(12, df.Ncol())
+	a.Equal(8, df.Nrow())
+
+	_, err = ToDataFrame(client, []string{"foo"}, "", "")
+	a.ErrorIs(err, boc.ErrUnknownSeries)
+	_, err = ToDataFrame(client, nil, "foo", "")
+	a.ErrorIs(err, boc.ErrInvalidDate)
+}
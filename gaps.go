@@ -0,0 +1,69 @@
+package boc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Gap is a calendar date without observation
+type Gap struct {
+	Date string
+	// Reason explains an expected gap, e.g. "weekend" or "holiday (Victoria Day)"
+	Reason string
+}
+
+// GapReport lists the dates without observation in a range
+type GapReport struct {
+	// Expected are the weekends and holidays
+	Expected []Gap
+	// Unexpected are the business days without observation
+	Unexpected []Gap
+}
+
+// Gaps returns the calendar dates of client without observation from start to
+// end, split into the expected weekends and holidays and the unexpected business
+// days. The range starts on the first date with data if start is empty or before
+// it, and ends on the last date with data if end is empty. ctx bounds the refresh
+// of stale data, see WithMaxStaleness.
+func Gaps(ctx context.Context, client ObservationReader, start, end string) (*GapReport, error) {
+	seq, err := client.Between(start, end, Context(ctx))
+	if err != nil {
+		return nil, err
+	}
+	observed := make(map[string]bool)
+	for date := range seq {
+		observed[date] = true
+	}
+	// the forward filled observations are every calendar day of the range
+	days, err := client.Between(start, end, ForwardFill(), Context(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	var report *GapReport
+	for formatted := range days {
+		if report == nil {
+			report = new(GapReport)
+		}
+		if observed[formatted] {
+			continue
+		}
+		day, err := time.Parse(time.DateOnly, formatted)
+		if err != nil {
+			return nil, &DataError{Date: formatted, Err: fmt.Errorf("%w: %w", ErrInvalidDate, err)}
+		}
+		switch name, holiday := HolidayName(day); {
+		case holiday:
+			report.Expected = append(report.Expected, Gap{Date: formatted, Reason: "holiday (" + name + ")"})
+		case day.Weekday() == time.Saturday || day.Weekday() == time.Sunday:
+			report.Expected = append(report.Expected, Gap{Date: formatted, Reason: "weekend"})
+		default:
+			report.Unexpected = append(report.Unexpected, Gap{Date: formatted})
+		}
+	}
+	if report == nil {
+		return nil, &DataError{Date: start, Err: ErrNoData}
+	}
+	return report, nil
+}
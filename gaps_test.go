@@ -0,0 +1,60 @@
+package boc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGaps(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	report, err := Gaps(context.Background(), b, "", "")
+	require.NoError(t, err)
+	a.Equal([]Gap{
+		{Date: "2022-05-21", Reason: "weekend"},
+		{Date: "2022-05-22", Reason: "weekend"},
+		{Date: "2022-05-23", Reason: "holiday (Victoria Day)"},
+		{Date: "2022-05-28", Reason: "weekend"},
+		{Date: "2022-05-29", Reason: "weekend"},
+	}, report.Expected)
+	a.Empty(report.Unexpected)
+
+	report, err = Gaps(context.Background(), b, "2022-05-30", "2022-06-04")
+	require.NoError(t, err)
+	a.Equal([]Gap{{Date: "2022-06-04", Reason: "weekend"}}, report.Expected)
+	a.Equal([]Gap{{Date: "2022-06-02"}, {Date: "2022-06-03"}}, report.Unexpected)
+
+	_, err = Gaps(context.Background(), b, "foo", "")
+	a.ErrorIs(err, ErrInvalidDate)
+	var dataErr *DataError
+	a.ErrorAs(err, &dataErr)
+
+	b.ds.Store(b.newDataset(context.Background(), &BOCData{}))
+	_, err = Gaps(context.Background(), b, "2022-05-30", "")
+	a.ErrorIs(err, ErrNoData)
+	if a.ErrorAs(err, &dataErr) {
+		a.Equal("2022-05-30", dataErr.Date)
+	}
+}
+
+func TestGapsContext(t *testing.T) {
+	a := assert.New(t)
+	srv, calls := newFlakyServer(t, 0)
+	b := newTestBOC(t)
+	b.maxStaleness = time.Hour
+	b.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	b.baseURL = srv.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Gaps(ctx, b, "", "")
+	var staleErr *StaleDataError
+	a.ErrorAs(err, &staleErr)
+	a.ErrorIs(err, context.Canceled)
+	a.Zero(calls.Load())
+}
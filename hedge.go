@@ -0,0 +1,46 @@
+package boc
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// hedgedRequest sends the request, and a second one if the first has not
+// answered after the delay of WithHedging. The first successful response is
+// used and the other request cancelled; an error is returned only once both failed.
+func (b *bocInterests) hedgedRequest(ctx context.Context, url string) (*http.Response, []byte, error) {
+	if b.hedgeDelay <= 0 {
+		return b.doRequest(ctx, url)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		resp *http.Response
+		body []byte
+		err  error
+	}
+	results := make(chan result, 2)
+	send := func() {
+		resp, body, err := b.doRequest(ctx, url)
+		results <- result{resp, body, err}
+	}
+	go send()
+	timer := time.NewTimer(b.hedgeDelay)
+	defer timer.Stop()
+	hedged, pending := false, 1
+	for {
+		select {
+		case <-timer.C:
+			b.logger.Info("hedging fetch", "url", url, "after", b.hedgeDelay)
+			hedged = true
+			pending++
+			go send()
+		case r := <-results:
+			pending--
+			if r.err == nil && r.resp.StatusCode == http.StatusOK || !hedged || pending == 0 {
+				return r.resp, r.body, r.err
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package boc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSlowServer returns a server whose first answer takes a second and whose
+// others answer right away with status
+func newSlowServer(t *testing.T, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	data, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	calls := new(atomic.Int32)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(time.Second):
+			}
+		} else if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, calls
+}
+
+func TestHedging(t *testing.T) {
+	a := assert.New(t)
+	srv, calls := newSlowServer(t, http.StatusOK)
+	b := newBOCInterests(WithBaseURL(srv.URL), WithHedging(20*time.Millisecond))
+
+	start := time.Now()
+	require.NoError(t, b.load(context.Background()))
+	a.Less(time.Since(start), 500*time.Millisecond)
+	a.Equal(int32(2), calls.Load())
+	a.Len(b.Observations(), 8)
+}
+
+func TestHedgingFailedHedge(t *testing.T) {
+	a := assert.New(t)
+	srv, calls := newSlowServer(t, http.StatusServiceUnavailable)
+	b := newBOCInterests(WithBaseURL(srv.URL), WithHedging(20*time.Millisecond))
+
+	require.NoError(t, b.load(context.Background()), "the slow success wins over the failed hedge")
+	a.Equal(int32(2), calls.Load())
+}
+
+func TestHedgingFastResponse(t *testing.T) {
+	srv, calls := newFlakyServer(t, 0)
+	b := newBOCInterests(WithBaseURL(srv.URL), WithHedging(time.Second))
+	require.NoError(t, b.load(context.Background()))
+	assert.Equal(t, int32(1), calls.Load(), "no hedge before the delay")
+}
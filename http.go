@@ -0,0 +1,214 @@
+package boc
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Hooks are called around the requests made to the Valet API
+type Hooks struct {
+	// OnRequest is called before a request is sent. It can modify the request,
+	// for example to add headers.
+	OnRequest func(req *http.Request)
+	// OnResponse is called when a response is received, before its body is read.
+	OnResponse func(req *http.Request, resp *http.Response, elapsed time.Duration)
+	// OnRetry is called before a failed request is retried. attempt starts at 1.
+	OnRetry func(attempt int, err error)
+	// OnRetryWait is called with the time waited before a retry, the backoff
+	// of WithRetry or the Retry-After of the response
+	OnRetryWait func(attempt int, wait time.Duration)
+	// OnPayload is called when a response body is read, with its size on the
+	// wire and once decompressed
+	OnPayload func(req *http.Request, wireBytes, decodedBytes int)
+}
+
+// DefaultUserAgent is the User-Agent of the requests unless WithUserAgent is used
+const DefaultUserAgent = "bank-of-canada-interests-rates (+https://github.com/clauderoy790/bank-of-canada-interests-rates)"
+
+// TransportFunc is an http.RoundTripper implemented by a function, see WithTransport
+type TransportFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper
+func (f TransportFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// download gets url, retrying on network errors and retryable status codes.
+// The returned response body is already read and closed.
+func (b *bocInterests) download(ctx context.Context, url string) (*http.Response, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		resp, body, err := b.hedgedRequest(ctx, url)
+		if attempt >= b.retries || !shouldRetry(resp, err) {
+			return resp, body, err
+		}
+		wait := b.backoff << attempt
+		if err == nil {
+			statusErr := statusError(resp, body)
+			if statusErr.RetryAfter > b.maxRetryAfter() {
+				b.logger.Warn("fetch not retried, Retry-After too long", "url", url, "retry_after", statusErr.RetryAfter)
+				return resp, body, nil
+			}
+			wait = max(wait, statusErr.RetryAfter)
+			err = statusErr
+		}
+		b.logger.Warn("retrying fetch", "url", url, "attempt", attempt+1, "wait", wait, "error", err)
+		if b.hooks.OnRetry != nil {
+			b.hooks.OnRetry(attempt+1, err)
+		}
+		if b.hooks.OnRetryWait != nil {
+			b.hooks.OnRetryWait(attempt+1, wait)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (b *bocInterests) doRequest(ctx context.Context, url string) (*http.Response, []byte, error) {
+	reqCtx := ctx
+	if b.timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, b.timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", DefaultUserAgent)
+	if runtime.GOOS != "js" {
+		// under js/wasm the browser negotiates the encoding and decompresses
+		req.Header.Set("Accept-Encoding", "gzip, deflate")
+	}
+	for key, values := range b.headers {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	if b.hooks.OnRequest != nil {
+		b.hooks.OnRequest(req)
+	}
+	start := time.Now()
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			// only this attempt timed out, it can be retried
+			return nil, nil, fmt.Errorf("%w after %s", ErrTimeout, b.timeout)
+		}
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	if b.hooks.OnResponse != nil {
+		b.hooks.OnResponse(req, resp, time.Since(start))
+	}
+	var r io.Reader = resp.Body
+	if b.progress != nil {
+		r = &progressReader{r: resp.Body, progress: Progress{URL: url, TotalBytes: resp.ContentLength}, report: b.progress}
+	}
+	wire, err := io.ReadAll(r)
+	if err != nil {
+		return resp, nil, fmt.Errorf("error reading body data: %w", err)
+	}
+	body := wire
+	if req.Header.Get("Accept-Encoding") != "" {
+		if body, err = decompress(resp.Header.Get("Content-Encoding"), wire); err != nil {
+			return resp, nil, fmt.Errorf("error decompressing body data: %w", err)
+		}
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("http.response_wire_size", len(wire)))
+	if b.hooks.OnPayload != nil {
+		b.hooks.OnPayload(req, len(wire), len(body))
+	}
+	return resp, body, nil
+}
+
+// decompress decodes a body according to its Content-Encoding. The
+// transport only decompresses gzip transparently when it set Accept-Encoding itself.
+func decompress(encoding string, body []byte) ([]byte, error) {
+	var r io.ReadCloser
+	var err error
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		r, err = gzip.NewReader(bytes.NewReader(body))
+	case "deflate":
+		// deflate is meant to be zlib wrapped, some servers send it raw
+		if r, err = zlib.NewReader(bytes.NewReader(body)); err != nil {
+			r, err = flate.NewReader(bytes.NewReader(body)), nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// statusError returns the error of a response with a status other than 200,
+// ErrRateLimited for 429, with the wait requested by its Retry-After header
+func statusError(resp *http.Response, body []byte) *DataError {
+	err := &DataError{StatusCode: resp.StatusCode, Snippet: snippet(body), Err: ErrBadStatus}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		err.Err = ErrRateLimited
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		err.RetryAfter = retryAfter(resp.Header.Get("Retry-After"), time.Now())
+	}
+	return err
+}
+
+// retryAfter parses a Retry-After header, in seconds or as an HTTP date, and
+// returns 0 if it is missing or invalid
+func retryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
+
+// maxRetryAfter returns the longest Retry-After waited for, see WithMaxRetryAfter
+func (b *bocInterests) maxRetryAfter() time.Duration {
+	if b.retryAfterLimit > 0 {
+		return b.retryAfterLimit
+	}
+	return defaultMaxRetryAfter
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return resp == nil && ctxErr(err) == nil
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// ctxErr returns the context error wrapped in err, if any
+func ctxErr(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package boc
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFlakyServer fails the first failures requests with 503 and then serves the fixture
+func newFlakyServer(t *testing.T, failures int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	data, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	calls := new(atomic.Int32)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, calls
+}
+
+func TestHooks(t *testing.T) {
+	a := assert.New(t)
+	srv, calls := newFlakyServer(t, 2)
+
+	var requests, responses int
+	retries := make([]int, 0)
+	statuses := make([]int, 0)
+	b := newBOCInterests(
+		WithRetry(3, time.Millisecond),
+		WithHooks(Hooks{
+			OnRequest: func(req *http.Request) {
+				requests++
+				req.Header.Set("X-Test", "yes")
+			},
+			OnResponse: func(req *http.Request, resp *http.Response, elapsed time.Duration) {
+				responses++
+				a.Equal("yes", req.Header.Get("X-Test"))
+				statuses = append(statuses, resp.StatusCode)
+			},
+			OnRetry: func(attempt int, err error) {
+				retries = append(retries, attempt)
+				a.ErrorIs(err, ErrBadStatus)
+			},
+		}),
+		WithBaseURL(srv.URL),
+	)
+
+	a.NoError(b.load(context.Background()))
+	a.Equal(int32(3), calls.Load())
+	a.Equal(3, requests)
+	a.Equal(3, responses)
+	a.Equal([]int{1, 2}, retries)
+	a.Equal([]int{503, 503, 200}, statuses)
+}
+
+func TestRetryExhausted(t *testing.T) {
+	a := assert.New(t)
+	srv, calls := newFlakyServer(t, 5)
+	b := newBOCInterests(WithRetry(2, time.Millisecond), WithBaseURL(srv.URL))
+
+	a.ErrorIs(b.load(context.Background()), ErrBadStatus)
+	a.Equal(int32(3), calls.Load())
+}
+
+func TestNoRetryByDefault(t *testing.T) {
+	a := assert.New(t)
+	srv, calls := newFlakyServer(t, 1)
+	b := newBOCInterests(WithBaseURL(srv.URL))
+
+	a.ErrorIs(b.load(context.Background()), ErrBadStatus)
+	a.Equal(int32(1), calls.Load())
+}
+
+func TestRetryAfter(t *testing.T) {
+	a := assert.New(t)
+	data, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	calls := new(atomic.Int32)
+	retryAfter := "1"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+
+	var waits []time.Duration
+	hooks := Hooks{OnRetryWait: func(attempt int, wait time.Duration) { waits = append(waits, wait) }}
+	b := newBOCInterests(WithBaseURL(srv.URL), WithRetry(1, time.Millisecond), WithHooks(hooks))
+	start := time.Now()
+	a.NoError(b.load(context.Background()))
+	a.GreaterOrEqual(time.Since(start), time.Second)
+	a.Equal([]time.Duration{time.Second}, waits)
+
+	calls.Store(0)
+	retryAfter = "3600"
+	b = newBOCInterests(WithBaseURL(srv.URL), WithRetry(1, time.Millisecond), WithMaxRetryAfter(time.Minute))
+	err = b.load(context.Background())
+	a.ErrorIs(err, ErrRateLimited)
+	a.ErrorIs(err, ErrBadStatus)
+	var dataErr *DataError
+	require.ErrorAs(t, err, &dataErr)
+	a.Equal(time.Hour, dataErr.RetryAfter)
+	a.Equal(int32(1), calls.Load(), "a longer wait than the limit is not retried")
+
+	calls.Store(0)
+	err = newBOCInterests(WithBaseURL(srv.URL)).load(context.Background())
+	a.ErrorContains(err, "rate limited: 429 (retry after 1h0m0s)")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{header: "", want: 0},
+		{header: "120", want: 2 * time.Minute},
+		{header: "-5", want: 0},
+		{header: "Wed, 01 Jun 2022 12:00:30 GMT", want: 30 * time.Second},
+		{header: "Wed, 01 Jun 2022 11:00:00 GMT", want: 0},
+		{header: "soon", want: 0},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, retryAfter(tt.header, now), tt.header)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	a := assert.New(t)
+	data, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	calls := new(atomic.Int32)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+
+	b := newBOCInterests(WithBaseURL(srv.URL), WithTimeout(20*time.Millisecond))
+	a.ErrorIs(b.load(context.Background()), ErrTimeout)
+
+	calls.Store(0)
+	b = newBOCInterests(WithBaseURL(srv.URL), WithTimeout(20*time.Millisecond), WithRetry(1, time.Millisecond))
+	a.NoError(b.load(context.Background()), "a timed out request is retried")
+	a.Equal(int32(2), calls.Load())
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	a := assert.New(t)
+	srv := newTestServer(t, http.StatusOK)
+	var used bool
+	client := &http.Client{Transport: TransportFunc(func(req *http.Request) (*http.Response, error) {
+		used = true
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+
+	b := newBOCInterests(WithBaseURL(srv.URL), WithHTTPClient(client))
+	a.NoError(b.load(context.Background()))
+	a.True(used)
+	a.Equal(defaultTimeout, b.timeout)
+
+	b = newBOCInterests(WithBaseURL(srv.URL), WithHTTPClient(client), WithHTTPClient(nil))
+	a.Same(client, b.httpClient, "a nil client is ignored")
+	b = newBOCInterests(WithBaseURL(srv.URL), WithHTTPClient(nil))
+	a.NoError(b.load(context.Background()), "the default client is kept")
+}
+
+func TestWithTransport(t *testing.T) {
+	a := assert.New(t)
+	data, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	urls := make([]string, 0)
+	transport := TransportFunc(func(req *http.Request) (*http.Response, error) {
+		urls = append(urls, req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(bytes.NewReader(data)),
+			Request:    req,
+		}, nil
+	})
+
+	b := newBOCInterests(WithTransport(transport))
+	a.NoError(b.load(context.Background()), "no network needed")
+	a.Equal([]string{b.dataURL()}, urls)
+	obs, err := b.GetObservationForDate("2022-05-24")
+	a.NoError(err)
+	a.Equal("2.57", obs.Yield2Year.V)
+}
+
+func TestHeaders(t *testing.T) {
+	a := assert.New(t)
+	data, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	var headers []http.Header
+	transport := TransportFunc(func(req *http.Request) (*http.Response, error) {
+		headers = append(headers, req.Header.Clone())
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(bytes.NewReader(data)), Request: req}, nil
+	})
+
+	b := newBOCInterests(WithTransport(transport))
+	require.NoError(t, b.load(context.Background()))
+	a.Equal(DefaultUserAgent, headers[0].Get("User-Agent"))
+
+	b = newBOCInterests(WithTransport(transport), WithUserAgent("rates-dashboard/1.2 (ops@example.com)"),
+		WithHeader("X-Client-Id", "abc"), WithHeader("X-Client-Id", "def"))
+	require.NoError(t, b.load(context.Background()))
+	a.Equal("rates-dashboard/1.2 (ops@example.com)", headers[1].Get("User-Agent"))
+	a.Equal([]string{"def"}, headers[1].Values("X-Client-Id"))
+}
+
+func TestCompressedResponse(t *testing.T) {
+	data, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	compress := map[string]func(io.Writer) io.WriteCloser{
+		"gzip":         func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		"deflate":      func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) },
+		"raw deflate":  func(w io.Writer) io.WriteCloser { fw, _ := flate.NewWriter(w, flate.DefaultCompression); return fw },
+		"uncompressed": nil,
+	}
+	for name, newWriter := range compress {
+		t.Run(name, func(t *testing.T) {
+			a := assert.New(t)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				a.Equal("gzip, deflate", r.Header.Get("Accept-Encoding"))
+				if newWriter == nil {
+					w.Write(data)
+					return
+				}
+				w.Header().Set("Content-Encoding", strings.Fields(name)[len(strings.Fields(name))-1])
+				cw := newWriter(w)
+				cw.Write(data)
+				cw.Close()
+			}))
+			t.Cleanup(srv.Close)
+
+			var wire, decoded int
+			b := newBOCInterests(WithBaseURL(srv.URL), WithHooks(Hooks{OnPayload: func(_ *http.Request, wireBytes, decodedBytes int) {
+				wire, decoded = wireBytes, decodedBytes
+			}}))
+			require.NoError(t, b.load(context.Background()))
+			a.Len(b.Observations(), 8)
+			a.Equal(len(data), decoded)
+			if newWriter == nil {
+				a.Equal(len(data), wire)
+			} else {
+				a.Less(wire*3, decoded, "compressed")
+			}
+		})
+	}
+}
+
+func TestDecompressInvalid(t *testing.T) {
+	_, err := decompress("br", []byte("x"))
+	assert.ErrorContains(t, err, "unsupported content encoding")
+	_, err = decompress("gzip", []byte("not gzip"))
+	assert.Error(t, err)
+}
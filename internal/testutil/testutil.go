@@ -0,0 +1,44 @@
+// Package testutil holds the test helpers shared by the packages of the module
+package testutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// Fixture returns the content of the bond_yields_all.json fixture of the testdata directory of the module
+func Fixture(t testing.TB) []byte {
+	t.Helper()
+	_, file, _, ok := runtime.Caller(0)
+	require.True(t, ok)
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(file), "..", "..", "testdata", "bond_yields_all.json"))
+	require.NoError(t, err)
+	return data
+}
+
+// NewValetServer returns a server answering every request with the fixture, see Fixture
+func NewValetServer(t testing.TB) *httptest.Server {
+	t.Helper()
+	data := Fixture(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// NewClient returns a client loaded from the fixture, served by NewValetServer
+func NewClient(t testing.TB, opts ...boc.Option) boc.BOCInterests {
+	t.Helper()
+	client, err := boc.NewBOCInterests(append([]boc.Option{boc.WithBaseURL(NewValetServer(t).URL)}, opts...)...)
+	require.NoError(t, err)
+	return client
+}
@@ -0,0 +1,98 @@
+package boc
+
+import (
+	"iter"
+	"sort"
+)
+
+// All implements BOCInterests
+func (b *bocInterests) All() iter.Seq2[string, *Observations] {
+	ds := b.current()
+	return ds.rangeSeq(0, len(ds.dates))
+}
+
+// Between implements BOCInterests
+func (b *bocInterests) Between(start, end string, opts ...QueryOption) (iter.Seq2[string, *Observations], error) {
+	start, end, err := b.formatRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	q := newQuery(opts)
+	ds, err := b.fresh(q.context())
+	if err != nil {
+		return nil, err
+	}
+	if q.interpolate {
+		return ds.interpolatedSeq(start, end), nil
+	}
+	if q.forwardFill {
+		return ds.filledSeq(start, end), nil
+	}
+	from, to := ds.bounds(start, end)
+	return ds.rangeSeq(from, to), nil
+}
+
+// between returns the observations of client from the formatted start to end,
+// e.g. computed dates that the date options of the client may not read. It
+// iterates over the open range of Between until end.
+func between(client ObservationReader, start, end string, opts ...QueryOption) (iter.Seq2[string, *Observations], error) {
+	seq, err := client.Between("", "", opts...)
+	if err != nil {
+		return nil, err
+	}
+	return func(yield func(string, *Observations) bool) {
+		for date, obs := range seq {
+			if date < start {
+				continue
+			}
+			if end != "" && date > end {
+				return
+			}
+			if !yield(date, obs) {
+				return
+			}
+		}
+	}, nil
+}
+
+// formatRange formats the start and end of a range, keeping them empty if they are
+func (b *bocInterests) formatRange(start, end string) (string, string, error) {
+	var err error
+	if start != "" {
+		if start, err = b.formatDate(start); err != nil {
+			return "", "", err
+		}
+	}
+	if end != "" {
+		if end, err = b.formatDate(end); err != nil {
+			return "", "", err
+		}
+	}
+	return start, end, nil
+}
+
+// bounds returns the indexes in d.dates of the inclusive formatted start and end dates.
+// An empty start or end leaves that side of the range open.
+func (d *dataset) bounds(start, end string) (int, int) {
+	from, to := 0, len(d.dates)
+	if start != "" {
+		from = sort.SearchStrings(d.dates, start)
+	}
+	if end != "" {
+		to = sort.Search(len(d.dates), func(i int) bool { return d.dates[i] > end })
+	}
+	if from > to {
+		from = to
+	}
+	return from, to
+}
+
+func (d *dataset) rangeSeq(from, to int) iter.Seq2[string, *Observations] {
+	return func(yield func(string, *Observations) bool) {
+		for i := from; i < to; i++ {
+			if !yield(d.dates[i], d.observation(i)) {
+				return
+			}
+		}
+	}
+}
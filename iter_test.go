@@ -0,0 +1,89 @@
+package boc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	dates := make([]string, 0)
+	for date, obs := range b.All() {
+		a.Equal(date, obs.D)
+		dates = append(dates, date)
+	}
+	a.Equal([]string{"2022-05-20", "2022-05-24", "2022-05-25", "2022-05-26", "2022-05-27", "2022-05-30", "2022-05-31", "2022-06-01"}, dates)
+
+	count := 0
+	for range b.All() {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	a.Equal(2, count)
+}
+
+func TestBetween(t *testing.T) {
+	b := newTestBOC(t)
+	tests := []struct {
+		name    string
+		start   string
+		end     string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "inclusive",
+			start: "2022-05-24",
+			end:   "2022-05-26",
+			want:  []string{"2022-05-24", "2022-05-25", "2022-05-26"},
+		},
+		{
+			name:  "bounds without data",
+			start: "2022-05-21",
+			end:   "2022-05-29",
+			want:  []string{"2022-05-24", "2022-05-25", "2022-05-26", "2022-05-27"},
+		},
+		{
+			name: "open start",
+			end:  "24/05/2022",
+			want: []string{"2022-05-20", "2022-05-24"},
+		},
+		{
+			name:  "open end",
+			start: "2022-05-31",
+			want:  []string{"2022-05-31", "2022-06-01"},
+		},
+		{
+			name:  "empty",
+			start: "2022-06-01",
+			end:   "2022-05-01",
+			want:  []string{},
+		},
+		{
+			name:    "invalid",
+			start:   "abc",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			seq, err := b.Between(tt.start, tt.end)
+			if tt.wantErr {
+				a.ErrorIs(err, ErrInvalidDate)
+				return
+			}
+			a.NoError(err)
+			dates := make([]string, 0)
+			for date := range seq {
+				dates = append(dates, date)
+			}
+			a.Equal(tt.want, dates)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package boc
+
+import "strings"
+
+// Language selects the Valet endpoint, and so the language of the labels and
+// descriptions returned in GroupDetail and SeriesDetail
+type Language string
+
+const (
+	// English uses the bankofcanada.ca endpoint
+	English Language = "en"
+	// French uses the banqueducanada.ca endpoint
+	French Language = "fr"
+)
+
+const (
+	englishBaseURL = "https://www.bankofcanada.ca/valet"
+	frenchBaseURL  = "https://www.banqueducanada.ca/valet"
+)
+
+const bondYieldsGroup = "bond_yields_all"
+
+// baseURL returns the Valet endpoint for the language, defaulting to French
+func (l Language) baseURL() string {
+	if l == English {
+		return englishBaseURL
+	}
+	return frenchBaseURL
+}
+
+// dataURL returns the URL of the group observations, or of the series selected with WithSeries
+func (b *bocInterests) dataURL() string {
+	if len(b.series) > 0 {
+		return b.baseURL + "/observations/" + strings.Join(b.series, ",") + "/json"
+	}
+	return b.groupURL(b.group)
+}
+
+// groupURL returns the URL of the observations of any group
+func (b *bocInterests) groupURL(group string) string {
+	return b.baseURL + "/observations/group/" + group + "/json"
+}
+
+// Language implements BOCInterests
+func (b *bocInterests) Language() Language {
+	return b.language
+}
@@ -0,0 +1,69 @@
+package boc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLanguage(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		lang    Language
+		wantURL string
+	}{
+		{
+			name:    "default",
+			lang:    French,
+			wantURL: "https://www.banqueducanada.ca/valet/observations/group/bond_yields_all/json",
+		},
+		{
+			name:    "english",
+			opts:    []Option{WithLanguage(English)},
+			lang:    English,
+			wantURL: "https://www.bankofcanada.ca/valet/observations/group/bond_yields_all/json",
+		},
+		{
+			name:    "french",
+			opts:    []Option{WithLanguage(French)},
+			lang:    French,
+			wantURL: "https://www.banqueducanada.ca/valet/observations/group/bond_yields_all/json",
+		},
+		{
+			name:    "base url",
+			opts:    []Option{WithLanguage(English), WithBaseURL("http://localhost:8080/valet/")},
+			lang:    English,
+			wantURL: "http://localhost:8080/valet/observations/group/bond_yields_all/json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBOCInterests(tt.opts...)
+			assert.Equal(t, tt.lang, b.Language())
+			assert.Equal(t, tt.wantURL, b.dataURL())
+		})
+	}
+}
+
+func TestLocalizedLabels(t *testing.T) {
+	a := assert.New(t)
+	data, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write(data)
+	}))
+	defer srv.Close()
+
+	b, err := NewBOCInterests(WithLanguage(English), WithBaseURL(srv.URL+"/valet"))
+	a.NoError(err)
+	a.Equal("/valet/observations/group/bond_yields_all/json", path)
+	a.Equal("2 year", b.SeriesDetail().Yield2Year.Label)
+	a.Equal(English, b.Language())
+}
@@ -0,0 +1,65 @@
+package boc
+
+import (
+	"context"
+	"sort"
+)
+
+// legacySeries are the keys of the long-run monthly series of the Valet API,
+// published since the 1950s and later, by key of the daily benchmark series
+// whose history they extend
+var legacySeries = map[string]string{
+	SeriesYield2Year:  "V122538",
+	SeriesYield3Year:  "V122539",
+	SeriesYield5Year:  "V122540",
+	SeriesYield7Year:  "V122541",
+	SeriesYield10Year: "V122487",
+	SeriesYieldLong:   "V122544",
+}
+
+// LegacySeries returns the key of the long-run monthly series extending the
+// history of a daily series, and false if there is none
+func LegacySeries(seriesKey string) (string, bool) {
+	key, ok := legacySeries[seriesKey]
+	return key, ok
+}
+
+// legacyKey returns the monthly series of a daily series, those of
+// WithLegacySeries first
+func (b *bocInterests) legacyKey(seriesKey string) (string, bool) {
+	if key, ok := b.legacy[seriesKey]; ok {
+		return key, true
+	}
+	return LegacySeries(seriesKey)
+}
+
+// LongRun implements BOCInterests
+func (b *bocInterests) LongRun(ctx context.Context, seriesKey string) ([]Point, error) {
+	if err := checkSeries(seriesKey); err != nil {
+		return nil, err
+	}
+	monthlyKey, ok := b.legacyKey(seriesKey)
+	if !ok {
+		return nil, &DataError{Series: seriesKey, Err: ErrNoLegacySeries}
+	}
+	ds, err := b.fresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+	daily := ds.points(seriesKey, 0, len(ds.dates))
+
+	data, err := b.fetchValues(ctx, b.baseURL+"/observations/"+monthlyKey+"/json")
+	if err != nil {
+		return nil, err
+	}
+	points := make([]Point, 0, len(data.values)+len(daily))
+	for date, values := range data.values {
+		v, ok := values[monthlyKey]
+		// the months covered by the daily data, even partly, are left to it
+		if ok && (len(daily) == 0 || date[:7] < daily[0].Date[:7]) {
+			points = append(points, Point{Date: date, Value: v})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Date < points[j].Date })
+	return append(points, daily...), nil
+}
@@ -0,0 +1,57 @@
+package boc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLongRun(t *testing.T) {
+	a := assert.New(t)
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"seriesDetail": {"V122538": {"label": "V122538"}}, "observations": [
+			{"d": "2022-05-01", "V122538": {"v": "2.60"}},
+			{"d": "2022-04-01", "V122538": {"v": "2.50"}},
+			{"d": "2022-03-01", "V122538": {"v": ""}},
+			{"d": "2022-02-01", "V122538": {"v": "1.40"}}
+		]}`))
+	}))
+	t.Cleanup(srv.Close)
+	b := newTestBOC(t)
+	b.baseURL = srv.URL
+
+	points, err := b.LongRun(context.Background(), SeriesYield2Year)
+	require.NoError(t, err)
+	a.Equal("/observations/V122538/json", path)
+	require.Len(t, points, 10)
+	a.Equal([]Point{{Date: "2022-02-01", Value: 1.4}, {Date: "2022-04-01", Value: 2.5}, {Date: "2022-05-20", Value: 2.59}}, points[:3])
+	a.Equal(Point{Date: "2022-06-01", Value: 2.73}, points[9])
+
+	b.legacy = map[string]string{SeriesYield10Year: "V1"}
+	_, err = b.LongRun(context.Background(), SeriesYield10Year)
+	a.NoError(err)
+	a.Equal("/observations/V1/json", path)
+
+	_, err = b.LongRun(context.Background(), SeriesYieldRRB)
+	a.ErrorIs(err, ErrNoLegacySeries)
+	_, err = b.LongRun(context.Background(), "foo")
+	a.ErrorIs(err, ErrUnknownSeries)
+	b.baseURL = newTestServer(t, http.StatusServiceUnavailable).URL
+	_, err = b.LongRun(context.Background(), SeriesYield2Year)
+	a.ErrorIs(err, ErrBadStatus)
+}
+
+func TestWithLegacySeries(t *testing.T) {
+	b := newBOCInterests(WithLegacySeries(SeriesYieldRRB, "V2"))
+	key, ok := b.legacyKey(SeriesYieldRRB)
+	assert.True(t, ok)
+	assert.Equal(t, "V2", key)
+	key, _ = b.legacyKey(SeriesYield10Year)
+	assert.Equal(t, "V122487", key)
+}
@@ -0,0 +1,66 @@
+// Package matrix exports the series of a client as gonum matrices.
+package matrix
+
+import (
+	"fmt"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// Dense returns a matrix with one row per date from start to end and one
+// column per series, in the order of seriesKeys, with the dates of the rows.
+// Only the dates where every series has a value are kept, so that the matrix
+// can be used directly with gonum/stat.
+func Dense(client boc.ObservationReader, seriesKeys []string, start, end string, opts ...boc.QueryOption) (*mat.Dense, []time.Time, error) {
+	if len(seriesKeys) == 0 {
+		return nil, nil, fmt.Errorf("no series selected")
+	}
+	for _, key := range seriesKeys {
+		if _, ok := boc.SeriesLabel(key); !ok {
+			return nil, nil, &boc.DataError{Series: key, Err: boc.ErrUnknownSeries}
+		}
+	}
+	seq, err := client.Between(start, end, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	var data []float64
+	var times []time.Time
+	row := make([]float64, len(seriesKeys))
+	for date, obs := range seq {
+		complete := true
+		for i, key := range seriesKeys {
+			v, ok := obs.Value(key)
+			if !ok {
+				complete = false
+				break
+			}
+			row[i] = v
+		}
+		t, err := time.Parse("2006-01-02", date)
+		if !complete || err != nil {
+			continue
+		}
+		data = append(data, row...)
+		times = append(times, t)
+	}
+	if len(times) == 0 {
+		return nil, nil, &boc.DataError{Date: start, Err: boc.ErrNoData}
+	}
+	return mat.NewDense(len(times), len(seriesKeys), data), times, nil
+}
+
+// Vector returns the values of a series from start to end as a vector, with their dates
+func Vector(client boc.ObservationReader, seriesKey, start, end string, opts ...boc.QueryOption) (*mat.VecDense, []time.Time, error) {
+	times, values, err := client.TimeSeries(seriesKey, start, end, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(values) == 0 {
+		return nil, nil, &boc.DataError{Date: start, Series: seriesKey, Err: boc.ErrNoValue}
+	}
+	return mat.NewVecDense(len(values), values), times, nil
+}
@@ -0,0 +1,54 @@
+package matrix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+	"github.com/clauderoy790/bank-of-canada-interests-rates/internal/testutil"
+)
+
+func TestDense(t *testing.T) {
+	a := assert.New(t)
+	client := testutil.NewClient(t)
+
+	m, times, err := Dense(client, []string{boc.SeriesYield2Year, boc.SeriesYieldRRB}, "2022-05-26", "2022-05-30")
+	require.NoError(t, err)
+	rows, cols := m.Dims()
+	a.Equal(2, rows, "the date without RRB value is skipped")
+	a.Equal(2, cols)
+	a.Equal(time.Date(2022, 5, 30, 0, 0, 0, 0, time.UTC), times[1])
+	a.Equal(2.65, m.At(1, 0))
+	a.Equal(0.63, m.At(1, 1))
+
+	m, _, err = Dense(client, []string{boc.SeriesYield2Year, boc.SeriesYield10Year}, "", "")
+	require.NoError(t, err)
+	corr := stat.Correlation(mat.Col(nil, 0, m), mat.Col(nil, 1, m), nil)
+	a.Greater(corr, 0.0)
+
+	_, _, err = Dense(client, nil, "", "")
+	a.Error(err)
+	_, _, err = Dense(client, []string{"foo"}, "", "")
+	a.ErrorIs(err, boc.ErrUnknownSeries)
+	_, _, err = Dense(client, []string{boc.SeriesYield2Year}, "2023-01-01", "")
+	a.ErrorIs(err, boc.ErrNoData)
+}
+
+func TestVector(t *testing.T) {
+	a := assert.New(t)
+	client := testutil.NewClient(t)
+
+	v, times, err := Vector(client, boc.SeriesYield2Year, "2022-05-24", "2022-05-25")
+	require.NoError(t, err)
+	a.Equal(2, v.Len())
+	a.Equal(2.53, v.AtVec(1))
+	a.Len(times, 2)
+
+	_, _, err = Vector(client, boc.SeriesYield2Year, "2023-01-01", "")
+	a.ErrorIs(err, boc.ErrNoValue)
+}
@@ -0,0 +1,98 @@
+package boc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StaleDataError is returned by the lookups when the data in use is older than
+// the limit of WithMaxStaleness and could not be refreshed, or WithStaleDataErrors is used
+type StaleDataError struct {
+	// Age is how old the data in use is, see WithMaxStaleness
+	Age time.Duration
+	// MaxStaleness is the limit of WithMaxStaleness
+	MaxStaleness time.Duration
+	// LatestDate is the date of the latest observation of the data in use
+	LatestDate string
+	// Err is the error of the refresh, nil with WithStaleDataErrors
+	Err error
+}
+
+// Error implements error
+func (e *StaleDataError) Error() string {
+	msg := fmt.Sprintf("data is %s old, more than %s (latest date %s)", e.Age.Round(time.Second), e.MaxStaleness, e.LatestDate)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the error of the refresh
+func (e *StaleDataError) Unwrap() error {
+	return e.Err
+}
+
+// age returns how old the data of the dataset is: the time since it was
+// fetched, or since its latest observation date if the fetch time is unknown
+func (d *dataset) age(now time.Time) time.Duration {
+	if !d.meta.FetchedAt.IsZero() {
+		return now.Sub(d.meta.FetchedAt)
+	}
+	if len(d.dates) == 0 {
+		return 0
+	}
+	latest, err := time.Parse(time.DateOnly, d.dates[len(d.dates)-1])
+	if err != nil {
+		return 0
+	}
+	return now.Sub(latest)
+}
+
+// staleRetryInterval is how long fresh waits before refreshing stale data again
+// after a refresh failed, at most the limit of WithMaxStaleness
+const staleRetryInterval = time.Minute
+
+// fresh returns the dataset in use for a lookup. With WithMaxStaleness, data
+// older than the limit is refreshed first with ctx, or is a StaleDataError with
+// WithStaleDataErrors. After a failed refresh, the lookups do not refresh again
+// for staleRetryInterval: they fail with the error of the refresh, or are served
+// the stale data with WithStaleIfError.
+func (b *bocInterests) fresh(ctx context.Context) (*dataset, error) {
+	ds := b.current()
+	if b.maxStaleness <= 0 {
+		return ds, nil
+	}
+	age := ds.age(b.now())
+	if age <= b.maxStaleness {
+		return ds, nil
+	}
+	if b.staleErrors {
+		return nil, &StaleDataError{Age: age, MaxStaleness: b.maxStaleness, LatestDate: b.LatestDate()}
+	}
+
+	b.staleMu.Lock()
+	defer b.staleMu.Unlock()
+	// another lookup may have refreshed the data while this one waited
+	if ds = b.current(); ds.age(b.now()) <= b.maxStaleness {
+		return ds, nil
+	}
+	if b.now().Sub(b.staleAttempt) >= min(staleRetryInterval, b.maxStaleness) {
+		b.logger.Info("data is stale, refreshing", "age", ds.age(b.now()), "max_staleness", b.maxStaleness)
+		err := b.Refresh(ctx)
+		if ds = b.current(); ds.age(b.now()) <= b.maxStaleness {
+			return ds, nil
+		}
+		// a cancelled lookup does not hold back the others
+		if ctx.Err() == nil {
+			b.staleAttempt, b.staleErr = b.now(), err
+		} else if err != nil {
+			return nil, &StaleDataError{Age: ds.age(b.now()), MaxStaleness: b.maxStaleness, LatestDate: b.LatestDate(), Err: err}
+		}
+	}
+	if b.staleErr == nil {
+		// the refresh failed with WithStaleIfError
+		return ds, nil
+	}
+	return nil, &StaleDataError{Age: ds.age(b.now()), MaxStaleness: b.maxStaleness, LatestDate: b.LatestDate(), Err: b.staleErr}
+}
@@ -0,0 +1,139 @@
+package boc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMaxStaleness(t *testing.T) {
+	a := assert.New(t)
+	srv, calls := newFlakyServer(t, 0)
+	b := newBOCInterests(WithBaseURL(srv.URL), WithMaxStaleness(time.Hour))
+	require.NoError(t, b.load(context.Background()))
+
+	_, err := b.GetObservationForDate("2022-05-24")
+	a.NoError(err)
+	a.Equal(int32(1), calls.Load(), "fresh data is not refreshed")
+
+	data := b.current().bocData()
+	data.FetchedAt = time.Now().Add(-2 * time.Hour)
+	b.ds.Store(b.newDataset(context.Background(), data))
+	_, err = b.Between("2022-05-24", "2022-05-25")
+	a.NoError(err)
+	a.Equal(int32(2), calls.Load(), "stale data is refreshed first")
+	a.Less(b.Staleness().Age(), time.Minute)
+
+	data.FetchedAt = time.Time{}
+	b.ds.Store(b.newDataset(context.Background(), data))
+	b.baseURL = newTestServer(t, http.StatusServiceUnavailable).URL
+	_, err = Summarize(b)
+	var staleErr *StaleDataError
+	require.ErrorAs(t, err, &staleErr)
+	a.ErrorIs(err, ErrBadStatus)
+	a.Equal(time.Hour, staleErr.MaxStaleness)
+	a.Equal("2022-06-01", staleErr.LatestDate)
+	a.Greater(staleErr.Age, 24*time.Hour, "aged by the latest observation without fetch time")
+}
+
+func TestWithStaleDataErrors(t *testing.T) {
+	a := assert.New(t)
+	srv, calls := newFlakyServer(t, 0)
+	b := newBOCInterests(WithBaseURL(srv.URL), WithMaxStaleness(time.Hour), WithStaleDataErrors())
+	require.NoError(t, b.load(context.Background()))
+	data := b.current().bocData()
+	data.FetchedAt = time.Now().Add(-2 * time.Hour)
+	b.ds.Store(b.newDataset(context.Background(), data))
+
+	_, err := b.GetObservationsForDates([]string{"2022-05-24"})
+	var staleErr *StaleDataError
+	require.ErrorAs(t, err, &staleErr)
+	a.NoError(errors.Unwrap(err))
+	a.InDelta(2*time.Hour, staleErr.Age, float64(time.Minute))
+	a.ErrorContains(err, "data is 2h0m0s old, more than 1h0m0s (latest date 2022-06-01)")
+	a.Equal(int32(1), calls.Load(), "not refreshed")
+
+	_, err = b.PreviousValue(SeriesYield2Year, "2022-05-24")
+	a.ErrorAs(err, &staleErr)
+}
+
+func TestMaxStalenessBackoff(t *testing.T) {
+	a := assert.New(t)
+	srv, calls := newFlakyServer(t, 0)
+	b := newBOCInterests(WithBaseURL(srv.URL), WithMaxStaleness(time.Hour), WithStaleIfError())
+	require.NoError(t, b.load(context.Background()))
+	now := time.Now().Add(2 * time.Hour)
+	b.now = func() time.Time { return now }
+	b.baseURL = newTestServer(t, http.StatusServiceUnavailable).URL
+
+	_, err := b.GetObservationForDate("2022-05-24")
+	a.NoError(err, "the stale data is served")
+	a.True(b.Staleness().Stale)
+	failedAt := b.staleAttempt
+	a.Equal(now, failedAt)
+
+	for _, lookup := range []func() error{
+		func() error { _, err := Volatility(b, SeriesYield2Year, 2); return err },
+		func() error { _, err := Gaps(context.Background(), b, "", ""); return err },
+		func() error { _, err := Resample(b, SeriesYield2Year, Monthly, Last); return err },
+		func() error { _, err := AnnualAverage(b, SeriesYield2Year, 2022); return err },
+		func() error { _, err := SnapshotAt(b, Monthly, "", ""); return err },
+	} {
+		a.NoError(lookup())
+	}
+	a.Equal(failedAt, b.staleAttempt, "not refreshed again before the backoff")
+
+	b.baseURL = srv.URL
+	now = now.Add(staleRetryInterval)
+	_, err = b.GetObservationForDate("2022-05-24")
+	a.NoError(err)
+	a.Equal(int32(2), calls.Load(), "refreshed after the backoff")
+	a.False(b.Staleness().Stale)
+	a.Equal(now, b.current().meta.FetchedAt, "fetch times are by the client clock")
+}
+
+func TestMaxStalenessErrorBackoff(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	b.maxStaleness = time.Hour
+	b.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	srv, calls := newFlakyServer(t, 100)
+	b.baseURL = srv.URL
+	b.retries = 0
+
+	_, err := YieldCurve(b, "2022-05-24")
+	var staleErr *StaleDataError
+	require.ErrorAs(t, err, &staleErr)
+	a.ErrorIs(err, ErrBadStatus)
+	n := calls.Load()
+	_, err = Resample(b, SeriesYield2Year, Monthly, Last)
+	a.ErrorAs(err, &staleErr)
+	a.ErrorIs(err, ErrBadStatus, "the error of the last refresh")
+	a.Equal(n, calls.Load(), "not refreshed again before the backoff")
+}
+
+func TestMaxStalenessContext(t *testing.T) {
+	a := assert.New(t)
+	srv, calls := newFlakyServer(t, 0)
+	b := newTestBOC(t)
+	b.maxStaleness = time.Hour
+	b.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	b.baseURL = srv.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := b.GetObservationForDate("2022-05-24", Context(ctx))
+	var staleErr *StaleDataError
+	require.ErrorAs(t, err, &staleErr)
+	a.ErrorIs(err, context.Canceled)
+	a.True(b.staleAttempt.IsZero(), "a cancelled lookup does not start the backoff")
+
+	_, err = b.GetObservationForDate("2022-05-24")
+	a.NoError(err)
+	a.Equal(int32(1), calls.Load())
+}
@@ -0,0 +1,117 @@
+package boc
+
+import (
+	"fmt"
+	"math"
+)
+
+// Mortgage is a Canadian fixed rate mortgage. As required in Canada, its rate
+// is compounded semi-annually whatever the payment frequency.
+type Mortgage struct {
+	Principal float64
+	// Rate is the annual rate in percent, compounded semi-annually
+	Rate float64
+	// AmortizationYears must be positive
+	AmortizationYears int
+	// PaymentsPerYear is 12 for monthly payments, 26 for bi-weekly, 52 for
+	// weekly. Monthly payments are assumed when it is 0 or less.
+	PaymentsPerYear int
+}
+
+// defaultPaymentsPerYear are the payments per year of a Mortgage without PaymentsPerYear
+const defaultPaymentsPerYear = 12
+
+// payments returns the number of payments per year of the mortgage
+func (m Mortgage) payments() int {
+	if m.PaymentsPerYear <= 0 {
+		return defaultPaymentsPerYear
+	}
+	return m.PaymentsPerYear
+}
+
+// validate returns an error wrapping ErrInvalidMortgage if m cannot be amortized
+func (m Mortgage) validate() error {
+	if m.AmortizationYears <= 0 {
+		return fmt.Errorf("%w: amortization of %d years", ErrInvalidMortgage, m.AmortizationYears)
+	}
+	return nil
+}
+
+// MortgagePayment is a payment of an amortization schedule
+type MortgagePayment struct {
+	Number    int
+	Payment   float64
+	Interest  float64
+	Principal float64
+	Balance   float64
+}
+
+// PeriodicRate returns the rate applied at each payment
+func (m Mortgage) PeriodicRate() float64 {
+	rate := ConvertRate(m.Rate, CompoundSemiAnnual, Compounding(m.payments()))
+	return PercentToDecimal(rate) / float64(m.payments())
+}
+
+// Payment returns the amount of each payment, or an error wrapping
+// ErrInvalidMortgage if the mortgage cannot be amortized
+func (m Mortgage) Payment() (float64, error) {
+	if err := m.validate(); err != nil {
+		return 0, err
+	}
+	n := float64(m.AmortizationYears * m.payments())
+	r := m.PeriodicRate()
+	if r == 0 {
+		return m.Principal / n, nil
+	}
+	return m.Principal * r / (1 - math.Pow(1+r, -n)), nil
+}
+
+// Schedule returns the amortization schedule of the mortgage, or an error
+// wrapping ErrInvalidMortgage if the mortgage cannot be amortized
+func (m Mortgage) Schedule() ([]MortgagePayment, error) {
+	payment, err := m.Payment()
+	if err != nil {
+		return nil, err
+	}
+	n := m.AmortizationYears * m.payments()
+	r := m.PeriodicRate()
+	balance := m.Principal
+	schedule := make([]MortgagePayment, 0, n)
+	for i := 1; i <= n; i++ {
+		interest := balance * r
+		principal := payment - interest
+		if i == n {
+			principal = balance
+		}
+		balance -= principal
+		schedule = append(schedule, MortgagePayment{
+			Number:    i,
+			Payment:   interest + principal,
+			Interest:  interest,
+			Principal: principal,
+			Balance:   balance,
+		})
+	}
+	return schedule, nil
+}
+
+// TotalInterest returns the interest paid over the whole amortization, or an
+// error wrapping ErrInvalidMortgage if the mortgage cannot be amortized
+func (m Mortgage) TotalInterest() (float64, error) {
+	payment, err := m.Payment()
+	if err != nil {
+		return 0, err
+	}
+	return payment*float64(m.AmortizationYears*m.payments()) - m.Principal, nil
+}
+
+// BenchmarkMortgage returns m with its rate set to the value of a series of
+// client at a date plus spread, in percent, e.g. the 5 year yield plus a lender's spread
+func BenchmarkMortgage(client ObservationReader, date, seriesKey string, spread float64, m Mortgage) (Mortgage, error) {
+	v, err := client.SeriesValue(date, seriesKey)
+	if err != nil {
+		return Mortgage{}, err
+	}
+	m.Rate = v + spread
+	return m, nil
+}
@@ -0,0 +1,74 @@
+package boc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMortgage(t *testing.T) {
+	a := assert.New(t)
+	m := Mortgage{Principal: 500000, Rate: 5, AmortizationYears: 25, PaymentsPerYear: 12}
+
+	a.InDelta(0.0041239, m.PeriodicRate(), 1e-7)
+	payment, err := m.Payment()
+	require.NoError(t, err)
+	a.InDelta(2908.02, payment, 0.01)
+
+	schedule, err := m.Schedule()
+	require.NoError(t, err)
+	a.Len(schedule, 300)
+	a.Equal(1, schedule[0].Number)
+	a.InDelta(2061.96, schedule[0].Interest, 0.01)
+	a.InDelta(payment-schedule[0].Interest, schedule[0].Principal, 1e-9)
+	a.InDelta(0, schedule[299].Balance, 1e-6)
+
+	total := 0.0
+	for _, p := range schedule {
+		total += p.Interest
+	}
+	totalInterest, err := m.TotalInterest()
+	require.NoError(t, err)
+	a.InDelta(totalInterest, total, 1e-4)
+
+	zero := Mortgage{Principal: 1200, Rate: 0, AmortizationYears: 1, PaymentsPerYear: 12}
+	payment, err = zero.Payment()
+	a.NoError(err)
+	a.Equal(100.0, payment)
+
+	for _, payments := range []int{0, -1} {
+		unset := m
+		unset.PaymentsPerYear = payments
+		a.Equal(m.PeriodicRate(), unset.PeriodicRate(), "monthly payments by default")
+		got, err := unset.Schedule()
+		a.NoError(err)
+		a.Equal(schedule, got)
+	}
+}
+
+func TestMortgageInvalid(t *testing.T) {
+	a := assert.New(t)
+	for _, years := range []int{0, -5} {
+		m := Mortgage{Principal: 500000, Rate: 5, AmortizationYears: years}
+		_, err := m.Payment()
+		a.ErrorIs(err, ErrInvalidMortgage)
+		_, err = m.Schedule()
+		a.ErrorIs(err, ErrInvalidMortgage)
+		_, err = m.TotalInterest()
+		a.ErrorIs(err, ErrInvalidMortgage)
+	}
+}
+
+func TestBenchmarkMortgage(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	m, err := BenchmarkMortgage(b, "2022-05-24", SeriesYield5Year, 1.5, Mortgage{Principal: 400000, AmortizationYears: 25, PaymentsPerYear: 26})
+	a.NoError(err)
+	a.InDelta(4.14, m.Rate, 1e-9)
+	a.Equal(400000.0, m.Principal)
+
+	_, err = BenchmarkMortgage(b, "2022-05-23", SeriesYield5Year, 1.5, Mortgage{})
+	a.ErrorIs(err, ErrNoData)
+}
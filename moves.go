@@ -0,0 +1,75 @@
+package boc
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Move is the change of a series from a date to a later one
+type Move struct {
+	From      string
+	To        string
+	FromValue float64
+	ToValue   float64
+	// Change is ToValue minus FromValue
+	Change Bps
+}
+
+// LargestMoves returns the n largest changes, up or down, of a series of client
+// between consecutive dates with a value from start to end, the largest first
+func LargestMoves(client ObservationReader, seriesKey, start, end string, n int) ([]Move, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("number of moves must be at least 1, got %d", n)
+	}
+	points, err := seriesBetween(client, seriesKey, start, end)
+	if err != nil {
+		return nil, err
+	}
+	moves := make([]Move, 0, max(len(points)-1, 0))
+	for i := 1; i < len(points); i++ {
+		moves = append(moves, newMove(points[i-1], points[i]))
+	}
+	sort.SliceStable(moves, func(i, j int) bool { return math.Abs(float64(moves[i].Change)) > math.Abs(float64(moves[j].Change)) })
+	return moves[:min(n, len(moves))], nil
+}
+
+// MaxRise returns the largest rise of a series of client from a date to a later one from start to end
+func MaxRise(client ObservationReader, seriesKey, start, end string) (Move, error) {
+	return extremeMove(client, seriesKey, start, end, 1)
+}
+
+// MaxFall returns the largest fall of a series of client from a date to a later
+// one from start to end, its drawdown, with a negative Change. If the series
+// only rose, it is the smallest rise, and likewise for MaxRise.
+func MaxFall(client ObservationReader, seriesKey, start, end string) (Move, error) {
+	return extremeMove(client, seriesKey, start, end, -1)
+}
+
+// extremeMove returns the largest rise, for sign 1, or fall, for sign -1, of a
+// series from a date to any later date from start to end
+func extremeMove(client ObservationReader, seriesKey, start, end string, sign float64) (Move, error) {
+	points, err := seriesBetween(client, seriesKey, start, end)
+	if err != nil {
+		return Move{}, err
+	}
+	if len(points) < 2 {
+		return Move{}, &DataError{Date: start, Series: seriesKey, Err: ErrNoValue}
+	}
+	// low is the lowest value so far for a rise, the highest for a fall
+	low := points[0]
+	best := newMove(points[0], points[1])
+	for _, p := range points[1:] {
+		if sign*(p.Value-low.Value) > sign*(best.ToValue-best.FromValue) {
+			best = newMove(low, p)
+		}
+		if sign*p.Value < sign*low.Value {
+			low = p
+		}
+	}
+	return best, nil
+}
+
+func newMove(from, to Point) Move {
+	return Move{From: from.Date, To: to.Date, FromValue: from.Value, ToValue: to.Value, Change: ToBps(to.Value - from.Value)}
+}
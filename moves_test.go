@@ -0,0 +1,51 @@
+package boc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLargestMoves(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	moves, err := LargestMoves(b, SeriesYield2Year, "", "", 3)
+	require.NoError(t, err)
+	a.Equal([]Move{
+		{From: "2022-05-26", To: "2022-05-27", FromValue: 2.55, ToValue: 2.61, Change: 6},
+		{From: "2022-05-31", To: "2022-06-01", FromValue: 2.68, ToValue: 2.73, Change: 5},
+		{From: "2022-05-24", To: "2022-05-25", FromValue: 2.57, ToValue: 2.53, Change: -4},
+	}, moves, "ties keep the chronological order")
+
+	moves, err = LargestMoves(b, SeriesYieldRRB, "2022-05-26", "2022-05-30", 5)
+	require.NoError(t, err)
+	a.Equal([]Move{{From: "2022-05-26", To: "2022-05-30", FromValue: 0.57, ToValue: 0.63, Change: 6}}, moves,
+		"the date without value is skipped")
+
+	_, err = LargestMoves(b, SeriesYield2Year, "", "", 0)
+	a.Error(err)
+	_, err = LargestMoves(b, "foo", "", "", 1)
+	a.ErrorIs(err, ErrUnknownSeries)
+}
+
+func TestMaxRiseFall(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	rise, err := MaxRise(b, SeriesYield2Year, "", "")
+	require.NoError(t, err)
+	a.Equal(Move{From: "2022-05-25", To: "2022-06-01", FromValue: 2.53, ToValue: 2.73, Change: 20}, rise)
+
+	fall, err := MaxFall(b, SeriesYield2Year, "", "")
+	require.NoError(t, err)
+	a.Equal(Move{From: "2022-05-20", To: "2022-05-25", FromValue: 2.59, ToValue: 2.53, Change: -6}, fall)
+
+	fall, err = MaxFall(b, SeriesYield2Year, "2022-05-26", "")
+	require.NoError(t, err)
+	a.Equal(Bps(3), fall.Change, "the smallest rise when the series only rose")
+
+	_, err = MaxRise(b, SeriesYield2Year, "2022-06-01", "")
+	a.ErrorIs(err, ErrNoValue)
+}
@@ -0,0 +1,194 @@
+package boc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Groups of the Valet API commonly used with NewMultiGroup. Any group name of
+// the Valet API can be used.
+const (
+	// GroupBondYields is the group of the benchmark bond yields used by NewBOCInterests
+	GroupBondYields = bondYieldsGroup
+	// GroupTBills is the group of the treasury bill yields
+	GroupTBills = "tbill_all"
+)
+
+// MultiGroup merges the observations of several Valet groups into one view by
+// date, so that a single lookup returns every rate published for a day
+type MultiGroup struct {
+	client *bocInterests
+	groups []string
+	view   atomic.Pointer[multiView]
+}
+
+type multiView struct {
+	dates   []string
+	series  []string
+	values  map[string]map[string]float64
+	details map[string]Detail
+}
+
+// NewMultiGroup fetches groups and returns their merged view. The options
+// configure the requests as for NewBOCInterests.
+func NewMultiGroup(ctx context.Context, groups []string, opts ...Option) (*MultiGroup, error) {
+	m := &MultiGroup{client: newBOCInterests(opts...), groups: slices.Clone(groups)}
+	if err := m.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Refresh fetches all the groups again, concurrently, and replaces the view in use
+// if they all succeed. The errors of all the groups that failed are joined.
+func (m *MultiGroup) Refresh(ctx context.Context) error {
+	results := make([]*groupData, len(m.groups))
+	errs := make([]error, len(m.groups))
+	workers := m.client.groupWorkers
+	if workers <= 0 {
+		workers = len(m.groups)
+	}
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, group := range m.groups {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = fmt.Errorf("error fetching group %s: %w", group, ctx.Err())
+				return
+			}
+			var err error
+			if results[i], err = m.client.fetchGroup(ctx, group); err != nil {
+				errs[i] = fmt.Errorf("error fetching group %s: %w", group, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	view := &multiView{values: make(map[string]map[string]float64), details: make(map[string]Detail)}
+	series := make(map[string]bool)
+	for _, result := range results {
+		maps.Copy(view.details, result.details)
+		for date, values := range result.values {
+			day := view.values[date]
+			if day == nil {
+				day = make(map[string]float64, len(values))
+				view.values[date] = day
+			}
+			for key, v := range values {
+				day[key] = v
+				series[key] = true
+			}
+		}
+	}
+	view.dates = slices.Sorted(maps.Keys(view.values))
+	view.series = slices.Sorted(maps.Keys(series))
+	m.view.Store(view)
+	return nil
+}
+
+// Groups returns the names of the merged groups
+func (m *MultiGroup) Groups() []string {
+	return slices.Clone(m.groups)
+}
+
+// Dates returns the dates with data in any of the groups, in ascending order
+func (m *MultiGroup) Dates() []string {
+	return slices.Clone(m.view.Load().dates)
+}
+
+// Series returns the keys of the series of all the groups, sorted
+func (m *MultiGroup) Series() []string {
+	return slices.Clone(m.view.Load().series)
+}
+
+// SeriesInfo returns the details of a series of any of the groups
+func (m *MultiGroup) SeriesInfo(key string) (Detail, error) {
+	detail, ok := m.view.Load().details[key]
+	if !ok {
+		return Detail{}, &DataError{Series: key, Err: ErrUnknownSeries}
+	}
+	return detail, nil
+}
+
+// Rates returns the value of every series published for a date, by series key
+func (m *MultiGroup) Rates(date string) (map[string]float64, error) {
+	formatted, err := m.client.formatDate(date)
+	if err != nil {
+		return nil, err
+	}
+	view := m.view.Load()
+	values, ok := view.values[formatted]
+	if !ok {
+		ds := &dataset{dates: view.dates}
+		return nil, &DataError{Date: formatted, NearestDate: ds.nearestDate(formatted), Err: noDataError(formatted)}
+	}
+	return maps.Clone(values), nil
+}
+
+// groupPayload is the part of a Valet group response read by fetchGroup
+type groupPayload struct {
+	SeriesDetail map[string]Detail            `json:"seriesDetail"`
+	Observations []map[string]json.RawMessage `json:"observations"`
+}
+
+// groupData is the data of a group fetched by fetchGroup
+type groupData struct {
+	// values are by date then series key
+	values  map[string]map[string]float64
+	details map[string]Detail
+}
+
+// fetchGroup fetches the observations and the series details of any group.
+// The values that are missing or are not numbers are skipped.
+func (b *bocInterests) fetchGroup(ctx context.Context, group string) (*groupData, error) {
+	return b.fetchValues(ctx, b.groupURL(group))
+}
+
+// fetchValues fetches the observations and the series details of a Valet URL
+// of a group or of series, skipping the values that are missing or are not numbers
+func (b *bocInterests) fetchValues(ctx context.Context, url string) (*groupData, error) {
+	var data *groupData
+	err := b.fetch(ctx, url, func(_ context.Context, raw []byte, _ http.Header) (int, error) {
+		var payload groupPayload
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return 0, err
+		}
+		values := make(map[string]map[string]float64, len(payload.Observations))
+		for _, obs := range payload.Observations {
+			var date string
+			if err := json.Unmarshal(obs["d"], &date); err != nil {
+				return 0, fmt.Errorf("observation without date: %w", err)
+			}
+			day := make(map[string]float64, len(obs)-1)
+			for key, field := range obs {
+				var v Val
+				if key == "d" || json.Unmarshal(field, &v) != nil {
+					continue
+				}
+				if f := parseValue(v.V); !math.IsNaN(f) {
+					day[key] = f
+				}
+			}
+			values[date] = day
+		}
+		data = &groupData{values: values, details: payload.SeriesDetail}
+		return len(values), nil
+	})
+	return data, err
+}
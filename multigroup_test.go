@@ -0,0 +1,131 @@
+package boc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tbillPayload = `{
+	"groupDetail": {"label": "Treasury bills"},
+	"seriesDetail": {"V80691342": {"label": "1-month", "description": "Treasury bills - 1 month", "dimension": {"key": "d", "name": "Date"}}},
+	"observations": [
+		{"d": "2022-05-24", "V80691342": {"v": "1.35"}, "V80691344": {"v": "1.80"}},
+		{"d": "2022-05-23", "V80691342": {"v": "1.30"}},
+		{"d": "2022-05-25", "V80691342": {"v": ""}, "V80691344": {"v": "1.85"}}
+	]
+}`
+
+// newGroupsServer serves the fixture for the bond yields group and tbillPayload for the treasury bills group
+func newGroupsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	bonds, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/"+GroupBondYields+"/"):
+			w.Write(bonds)
+		case strings.Contains(r.URL.Path, "/"+GroupTBills+"/"):
+			w.Write([]byte(tbillPayload))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestMultiGroup(t *testing.T) {
+	a := assert.New(t)
+	srv := newGroupsServer(t)
+	m, err := NewMultiGroup(context.Background(), []string{GroupBondYields, GroupTBills}, WithBaseURL(srv.URL))
+	require.NoError(t, err)
+
+	a.Equal([]string{GroupBondYields, GroupTBills}, m.Groups())
+	a.Len(m.Dates(), 9)
+	a.Equal("2022-05-23", m.Dates()[1])
+	a.Contains(m.Series(), SeriesYield2Year)
+	a.Contains(m.Series(), "V80691344")
+
+	detail, err := m.SeriesInfo("V80691342")
+	a.NoError(err)
+	a.Equal("Treasury bills - 1 month", detail.Description)
+	detail, err = m.SeriesInfo(SeriesYield10Year)
+	a.NoError(err)
+	a.Equal("10 year", detail.Label)
+	_, err = m.SeriesInfo("V80691344")
+	a.ErrorIs(err, ErrUnknownSeries)
+
+	rates, err := m.Rates("2022-05-24")
+	a.NoError(err)
+	a.Equal(2.57, rates[SeriesYield2Year])
+	a.Equal(1.35, rates["V80691342"])
+	a.Equal(1.80, rates["V80691344"])
+
+	rates, err = m.Rates("2022-05-23")
+	a.NoError(err)
+	a.Equal(map[string]float64{"V80691342": 1.30}, rates, "only the treasury bills are published on the holiday")
+
+	rates, err = m.Rates("2022-05-25")
+	a.NoError(err)
+	_, ok := rates["V80691342"]
+	a.False(ok, "missing values are skipped")
+
+	_, err = m.Rates("2022-05-22")
+	a.ErrorIs(err, ErrNoData)
+	_, err = m.Rates("foo")
+	a.ErrorIs(err, ErrInvalidDate)
+}
+
+func TestMultiGroupError(t *testing.T) {
+	srv := newGroupsServer(t)
+	_, err := NewMultiGroup(context.Background(), []string{GroupBondYields, "unknown"}, WithBaseURL(srv.URL))
+	assert.ErrorIs(t, err, ErrBadStatus)
+	assert.ErrorContains(t, err, "unknown")
+}
+
+func TestMultiGroupErrors(t *testing.T) {
+	srv := newGroupsServer(t)
+	_, err := NewMultiGroup(context.Background(), []string{"first", GroupBondYields, "second"}, WithBaseURL(srv.URL))
+	assert.ErrorIs(t, err, ErrBadStatus)
+	assert.ErrorContains(t, err, "group first")
+	assert.ErrorContains(t, err, "group second")
+}
+
+func TestWithGroupWorkers(t *testing.T) {
+	a := assert.New(t)
+	bonds, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	var active, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write(bonds)
+	}))
+	t.Cleanup(srv.Close)
+
+	groups := []string{"a", "b", "c", "d", "e"}
+	_, err = NewMultiGroup(context.Background(), groups, WithBaseURL(srv.URL), WithGroupWorkers(2))
+	require.NoError(t, err)
+	a.Equal(int32(2), peak.Load())
+
+	peak.Store(0)
+	_, err = NewMultiGroup(context.Background(), groups, WithBaseURL(srv.URL))
+	require.NoError(t, err)
+	a.Equal(int32(5), peak.Load(), "all the groups at once by default")
+}
@@ -0,0 +1,150 @@
+package boc
+
+import (
+	"errors"
+	"math"
+)
+
+// NelsonSiegel holds the parameters of a Nelson-Siegel yield curve, with
+// yields in percent and Tau in years
+type NelsonSiegel struct {
+	Beta0 float64
+	Beta1 float64
+	Beta2 float64
+	Tau   float64
+	// RMSE is the root mean square error of the fit, in percent
+	RMSE float64
+}
+
+// Yield returns the fitted yield in percent for a maturity in years
+func (ns NelsonSiegel) Yield(maturity float64) float64 {
+	f1, f2 := nelsonSiegelLoadings(maturity, ns.Tau)
+	return ns.Beta0 + ns.Beta1*f1 + ns.Beta2*f2
+}
+
+func nelsonSiegelLoadings(maturity, tau float64) (float64, float64) {
+	if maturity <= 0 {
+		return 1, 0
+	}
+	x := maturity / tau
+	e := math.Exp(-x)
+	f1 := (1 - e) / x
+	return f1, f1 - e
+}
+
+// FitNelsonSiegel fits a Nelson-Siegel curve to the tenors of the curve. It
+// needs at least three tenors. For each Tau the betas are solved by least
+// squares, and Tau is searched on a grid then refined.
+func (c *Curve) FitNelsonSiegel() (NelsonSiegel, error) {
+	if len(c.Tenors) < 3 {
+		return NelsonSiegel{}, errors.New("at least three tenors are needed to fit a Nelson-Siegel curve")
+	}
+
+	// grid search on a log scale between 0.1 and 30 years
+	best := NelsonSiegel{RMSE: math.Inf(1)}
+	const steps = 100
+	for i := 0; i <= steps; i++ {
+		tau := 0.1 * math.Pow(300, float64(i)/steps)
+		if ns, ok := c.fitBetas(tau); ok && ns.RMSE < best.RMSE {
+			best = ns
+		}
+	}
+	if math.IsInf(best.RMSE, 1) {
+		return NelsonSiegel{}, errors.New("failed to fit a Nelson-Siegel curve")
+	}
+
+	// golden section search around the best grid point
+	ratio := math.Pow(300, 1.0/steps)
+	lo, hi := best.Tau/ratio, best.Tau*ratio
+	g := (math.Sqrt(5) - 1) / 2
+	for i := 0; i < 50; i++ {
+		t1, t2 := hi-g*(hi-lo), lo+g*(hi-lo)
+		ns1, ok1 := c.fitBetas(t1)
+		ns2, ok2 := c.fitBetas(t2)
+		if !ok1 || !ok2 {
+			break
+		}
+		if ns1.RMSE < ns2.RMSE {
+			hi = t2
+		} else {
+			lo = t1
+		}
+		if ns1.RMSE < best.RMSE {
+			best = ns1
+		}
+		if ns2.RMSE < best.RMSE {
+			best = ns2
+		}
+	}
+	return best, nil
+}
+
+// fitBetas solves the betas by least squares for a given tau
+func (c *Curve) fitBetas(tau float64) (NelsonSiegel, bool) {
+	var ata [3][3]float64
+	var aty [3]float64
+	for i, m := range c.Tenors {
+		f1, f2 := nelsonSiegelLoadings(m, tau)
+		row := [3]float64{1, f1, f2}
+		for j := 0; j < 3; j++ {
+			aty[j] += row[j] * c.Yields[i]
+			for k := 0; k < 3; k++ {
+				ata[j][k] += row[j] * row[k]
+			}
+		}
+	}
+	beta, ok := solve3(ata, aty)
+	if !ok {
+		return NelsonSiegel{}, false
+	}
+	ns := NelsonSiegel{Beta0: beta[0], Beta1: beta[1], Beta2: beta[2], Tau: tau}
+	sse := 0.0
+	for i, m := range c.Tenors {
+		d := ns.Yield(m) - c.Yields[i]
+		sse += d * d
+	}
+	ns.RMSE = math.Sqrt(sse / float64(len(c.Tenors)))
+	return ns, true
+}
+
+// solve3 solves a 3x3 linear system by Gaussian elimination with partial pivoting
+func solve3(a [3][3]float64, b [3]float64) ([3]float64, bool) {
+	for col := 0; col < 3; col++ {
+		pivot := col
+		for row := col + 1; row < 3; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return [3]float64{}, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+		for row := col + 1; row < 3; row++ {
+			f := a[row][col] / a[col][col]
+			for k := col; k < 3; k++ {
+				a[row][k] -= f * a[col][k]
+			}
+			b[row] -= f * b[col]
+		}
+	}
+	var x [3]float64
+	for row := 2; row >= 0; row-- {
+		sum := b[row]
+		for k := row + 1; k < 3; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, true
+}
+
+// FitNelsonSiegel fits a Nelson-Siegel curve to the benchmark yields of client for a date
+func FitNelsonSiegel(client ObservationReader, date string) (NelsonSiegel, error) {
+	c, err := YieldCurve(client, date)
+	if err != nil {
+		return NelsonSiegel{}, err
+	}
+	return c.FitNelsonSiegel()
+}
@@ -0,0 +1,40 @@
+package boc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitNelsonSiegel(t *testing.T) {
+	a := assert.New(t)
+	want := NelsonSiegel{Beta0: 3.5, Beta1: -1.5, Beta2: 1, Tau: 2.5}
+	c := &Curve{Tenors: []float64{0.5, 1, 2, 3, 5, 7, 10, 30}}
+	for _, m := range c.Tenors {
+		c.Yields = append(c.Yields, want.Yield(m))
+	}
+
+	ns, err := c.FitNelsonSiegel()
+	a.NoError(err)
+	a.InDelta(want.Beta0, ns.Beta0, 1e-4)
+	a.InDelta(want.Beta1, ns.Beta1, 1e-4)
+	a.InDelta(want.Beta2, ns.Beta2, 1e-4)
+	a.InDelta(want.Tau, ns.Tau, 1e-4)
+	a.Less(ns.RMSE, 1e-6)
+
+	_, err = (&Curve{Tenors: []float64{2, 5}, Yields: []float64{1, 2}}).FitNelsonSiegel()
+	a.Error(err)
+}
+
+func TestFitNelsonSiegelDate(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	ns, err := FitNelsonSiegel(b, "2022-05-24")
+	a.NoError(err)
+	a.Less(ns.RMSE, 0.02)
+	a.InDelta(2.64, ns.Yield(5), 0.03)
+
+	_, err = FitNelsonSiegel(b, "2022-05-23")
+	a.ErrorIs(err, ErrNoData)
+}
@@ -0,0 +1,152 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	texttemplate "text/template"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var (
+	defaultText = texttemplate.Must(texttemplate.New("email.txt.tmpl").Funcs(texttemplate.FuncMap{
+		"table": func(s *boc.Summary) (string, error) {
+			var sb strings.Builder
+			err := s.WriteText(&sb)
+			return sb.String(), err
+		},
+	}).ParseFS(templates, "templates/email.txt.tmpl"))
+	defaultHTML = htmltemplate.Must(htmltemplate.New("email.html.tmpl").Funcs(htmltemplate.FuncMap{
+		"change": boc.FormatChange,
+	}).ParseFS(templates, "templates/email.html.tmpl"))
+)
+
+// Email is a Notifier sending multipart emails, with a plain-text and an HTML
+// part, through an SMTP server
+type Email struct {
+	addr string
+	from string
+	to   []string
+	auth smtp.Auth
+	text *texttemplate.Template
+	html *htmltemplate.Template
+	// send is smtp.SendMail, replaced in tests
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+var _ Notifier = (*Email)(nil)
+
+// EmailOption configures an Email
+type EmailOption func(*Email)
+
+// WithAuth sets the SMTP authentication, e.g. smtp.PlainAuth
+func WithAuth(auth smtp.Auth) EmailOption {
+	return func(e *Email) {
+		e.auth = auth
+	}
+}
+
+// WithTemplates replaces the templates of the messages. Each template must
+// define a "summary" template executed with a *boc.Summary and an "alert"
+// template executed with a boc.AlertEvent.
+func WithTemplates(text *texttemplate.Template, html *htmltemplate.Template) EmailOption {
+	return func(e *Email) {
+		e.text = text
+		e.html = html
+	}
+}
+
+// NewEmail returns an Email sending from the from address to the to addresses
+// through the SMTP server at addr (host:port)
+func NewEmail(addr, from string, to []string, opts ...EmailOption) *Email {
+	e := &Email{addr: addr, from: from, to: to, text: defaultText, html: defaultHTML, send: smtp.SendMail}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// SendSummary implements Notifier
+func (e *Email) SendSummary(ctx context.Context, s *boc.Summary) error {
+	return e.sendMessage(ctx, "Bank of Canada yields for "+s.Date, "summary", s)
+}
+
+// SendAlert implements Notifier
+func (e *Email) SendAlert(ctx context.Context, event boc.AlertEvent) error {
+	return e.sendMessage(ctx, fmt.Sprintf("Alert: %s on %s", event.Name, event.Date), "alert", event)
+}
+
+// sendMessage sends an email rendering the name templates with data. net/smtp
+// does not take a context, ctx is only checked before sending.
+func (e *Email) sendMessage(ctx context.Context, subject, name string, data any) error {
+	var text, html bytes.Buffer
+	if err := e.text.ExecuteTemplate(&text, name, data); err != nil {
+		return fmt.Errorf("error rendering text email: %w", err)
+	}
+	if err := e.html.ExecuteTemplate(&html, name, data); err != nil {
+		return fmt.Errorf("error rendering html email: %w", err)
+	}
+	msg, err := e.message(subject, text.Bytes(), html.Bytes())
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := e.send(e.addr, e.auth, e.from, e.to, msg); err != nil {
+		return fmt.Errorf("error sending email: %w", err)
+	}
+	return nil
+}
+
+// message builds a multipart/alternative message with a text and an html part
+func (e *Email) message(subject string, text, html []byte) ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", html},
+	} {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qw := quotedprintable.NewWriter(pw)
+		if _, err := qw.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err := qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", e.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", mw.Boundary())
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
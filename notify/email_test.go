@@ -0,0 +1,119 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	htmltemplate "html/template"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"testing"
+	texttemplate "text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+type sentMail struct {
+	addr string
+	from string
+	to   []string
+	msg  []byte
+}
+
+func newTestEmail(t *testing.T, sent *[]sentMail, opts ...EmailOption) *Email {
+	t.Helper()
+	e := NewEmail("smtp.example.com:587", "rates@example.com", []string{"a@example.com", "b@example.com"}, opts...)
+	e.send = func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+		*sent = append(*sent, sentMail{addr, from, to, msg})
+		return nil
+	}
+	return e
+}
+
+// parts parses a sent message, returning its subject and its parts by content type
+func parts(t *testing.T, raw []byte) (string, map[string]string) {
+	t.Helper()
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+	contents := make(map[string]string)
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(p)
+		require.NoError(t, err)
+		// quoted-printable text uses CRLF line endings
+		contents[p.Header.Get("Content-Type")] = strings.ReplaceAll(string(content), "\r\n", "\n")
+	}
+	return subject, contents
+}
+
+func TestEmailSummary(t *testing.T) {
+	a := assert.New(t)
+	var sent []sentMail
+	require.NoError(t, newTestEmail(t, &sent).SendSummary(context.Background(), testSummary()))
+	require.Len(t, sent, 1)
+	a.Equal("smtp.example.com:587", sent[0].addr)
+	a.Equal("rates@example.com", sent[0].from)
+	a.Equal([]string{"a@example.com", "b@example.com"}, sent[0].to)
+
+	subject, contents := parts(t, sent[0].msg)
+	a.Equal("Bank of Canada yields for 2022-06-01", subject)
+	a.Equal("Bank of Canada yields for 2022-06-01\n\n"+
+		"10 year benchmark yield    2.97%  +7 bps\n\nAlerts:\n- 10y above 2.9% on 2022-06-01\n\n"+
+		"Source: Bank of Canada. https://www.bankofcanada.ca/terms/\n", contents["text/plain; charset=utf-8"])
+	html := contents["text/html; charset=utf-8"]
+	a.Contains(html, `<tr><td>10 year benchmark yield</td><td align="right">2.97%</td><td align="right">&#43;7 bps</td></tr>`)
+	a.Contains(html, "<li>10y above 2.9% on 2022-06-01</li>")
+	a.Contains(html, `<p><small>Source: Bank of Canada. <a href="https://www.bankofcanada.ca/terms/">https://www.bankofcanada.ca/terms/</a></small></p>`)
+}
+
+func TestEmailAlert(t *testing.T) {
+	a := assert.New(t)
+	var sent []sentMail
+	require.NoError(t, newTestEmail(t, &sent).SendAlert(context.Background(), boc.AlertEvent{Name: "2y <b>moved</b>", Date: "2022-06-01"}))
+	require.Len(t, sent, 1)
+	subject, contents := parts(t, sent[0].msg)
+	a.Equal("Alert: 2y <b>moved</b> on 2022-06-01", subject)
+	a.Equal("The alert 2y <b>moved</b> was triggered by the observation of 2022-06-01.\n", contents["text/plain; charset=utf-8"])
+	a.Contains(contents["text/html; charset=utf-8"], "<strong>2y &lt;b&gt;moved&lt;/b&gt;</strong>")
+}
+
+func TestEmailTemplates(t *testing.T) {
+	var sent []sentMail
+	text := texttemplate.Must(texttemplate.New("").Parse(`{{define "summary"}}{{.Date}}{{end}}`))
+	html := htmltemplate.Must(htmltemplate.New("").Parse(`{{define "summary"}}<p>{{.Date}}</p>{{end}}`))
+	e := newTestEmail(t, &sent, WithTemplates(text, html))
+	require.NoError(t, e.SendSummary(context.Background(), testSummary()))
+	_, contents := parts(t, sent[0].msg)
+	assert.Equal(t, "2022-06-01", contents["text/plain; charset=utf-8"])
+	assert.Equal(t, "<p>2022-06-01</p>", contents["text/html; charset=utf-8"])
+
+	assert.ErrorContains(t, e.SendAlert(context.Background(), boc.AlertEvent{}), "error rendering text email")
+}
+
+func TestEmailError(t *testing.T) {
+	var sent []sentMail
+	e := newTestEmail(t, &sent)
+	errSMTP := errors.New("535 authentication failed")
+	e.send = func(string, smtp.Auth, string, []string, []byte) error { return errSMTP }
+	assert.ErrorIs(t, e.SendSummary(context.Background(), testSummary()), errSMTP)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, e.SendSummary(ctx, testSummary()), context.Canceled)
+}
@@ -0,0 +1,54 @@
+// Package notify posts the daily summary and the alert events of a client to
+// chat webhooks (Slack, Discord) and by email.
+package notify
+
+import (
+	"context"
+	"sync"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// Notifier delivers summaries and alert events
+type Notifier interface {
+	// SendSummary delivers a daily summary
+	SendSummary(ctx context.Context, s *boc.Summary) error
+	// SendAlert delivers an alert event
+	SendAlert(ctx context.Context, event boc.AlertEvent) error
+}
+
+// AlertHandler returns a handler for boc.WithAlert delivering the events with
+// notifier. The alert handlers have no context nor error, so the events are
+// delivered with ctx and the errors are passed to onError, which can be nil.
+func AlertHandler(ctx context.Context, notifier Notifier, onError func(boc.AlertEvent, error)) func(boc.AlertEvent) {
+	return func(event boc.AlertEvent) {
+		if err := notifier.SendAlert(ctx, event); err != nil && onError != nil {
+			onError(event, err)
+		}
+	}
+}
+
+// Collector keeps the alert events until the next summary, to report them with
+// it instead of one message per event
+type Collector struct {
+	mu     sync.Mutex
+	events []boc.AlertEvent
+}
+
+// Handler returns a handler for boc.WithAlert collecting the events
+func (c *Collector) Handler() func(boc.AlertEvent) {
+	return func(event boc.AlertEvent) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.events = append(c.events, event)
+	}
+}
+
+// Drain returns the events collected since the last call
+func (c *Collector) Drain() []boc.AlertEvent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	events := c.events
+	c.events = nil
+	return events
+}
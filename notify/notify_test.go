@@ -0,0 +1,48 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+type fakeNotifier struct {
+	alerts []boc.AlertEvent
+	err    error
+}
+
+func (n *fakeNotifier) SendSummary(context.Context, *boc.Summary) error { return n.err }
+
+func (n *fakeNotifier) SendAlert(_ context.Context, event boc.AlertEvent) error {
+	n.alerts = append(n.alerts, event)
+	return n.err
+}
+
+func TestAlertHandler(t *testing.T) {
+	a := assert.New(t)
+	n := new(fakeNotifier)
+	event := boc.AlertEvent{Name: "10y above 3%", Date: "2022-06-01"}
+	AlertHandler(context.Background(), n, nil)(event)
+	a.Equal([]boc.AlertEvent{event}, n.alerts)
+
+	n.err = errors.New("unavailable")
+	var failed []error
+	AlertHandler(context.Background(), n, func(_ boc.AlertEvent, err error) { failed = append(failed, err) })(event)
+	a.Equal([]error{n.err}, failed)
+}
+
+func TestCollector(t *testing.T) {
+	a := assert.New(t)
+	var c Collector
+	handler := c.Handler()
+	handler(boc.AlertEvent{Name: "a"})
+	handler(boc.AlertEvent{Name: "b"})
+	events := c.Drain()
+	a.Len(events, 2)
+	a.Equal("b", events[1].Name)
+	a.Empty(c.Drain())
+}
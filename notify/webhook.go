@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// Webhook is a Notifier posting messages to a Slack or Discord incoming webhook
+type Webhook struct {
+	url        string
+	httpClient *http.Client
+	// field is the JSON field of the message text
+	field string
+	// bold wraps a title in the bold markup of the service
+	bold string
+}
+
+var _ Notifier = (*Webhook)(nil)
+
+// WebhookOption configures a Webhook
+type WebhookOption func(*Webhook)
+
+// WithHTTPClient sets the HTTP client used to post the messages, http.DefaultClient by default
+func WithHTTPClient(client *http.Client) WebhookOption {
+	return func(w *Webhook) {
+		w.httpClient = client
+	}
+}
+
+// Slack returns a Webhook posting to a Slack incoming webhook URL
+func Slack(webhookURL string, opts ...WebhookOption) *Webhook {
+	return newWebhook(webhookURL, "text", "*", opts)
+}
+
+// Discord returns a Webhook posting to a Discord webhook URL
+func Discord(webhookURL string, opts ...WebhookOption) *Webhook {
+	return newWebhook(webhookURL, "content", "**", opts)
+}
+
+func newWebhook(webhookURL, field, bold string, opts []WebhookOption) *Webhook {
+	w := &Webhook{url: webhookURL, httpClient: http.DefaultClient, field: field, bold: bold}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// SendSummary implements Notifier, posting the summary as a monospace table
+func (w *Webhook) SendSummary(ctx context.Context, s *boc.Summary) error {
+	var table strings.Builder
+	if err := s.WriteText(&table); err != nil {
+		return err
+	}
+	return w.post(ctx, fmt.Sprintf("%sBank of Canada yields for %s%s\n```\n%s```", w.bold, s.Date, w.bold, table.String()))
+}
+
+// SendAlert implements Notifier
+func (w *Webhook) SendAlert(ctx context.Context, event boc.AlertEvent) error {
+	return w.post(ctx, fmt.Sprintf("%sAlert: %s%s triggered on %s", w.bold, event.Name, w.bold, event.Date))
+}
+
+func (w *Webhook) post(ctx context.Context, text string) error {
+	body, err := json.Marshal(map[string]string{w.field: text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("error posting to webhook: %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	return nil
+}
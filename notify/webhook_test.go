@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+func newWebhookServer(t *testing.T, status int) (*httptest.Server, *[]map[string]string) {
+	t.Helper()
+	var messages []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var msg map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		messages = append(messages, msg)
+		w.WriteHeader(status)
+		w.Write([]byte("invalid_token"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &messages
+}
+
+func testSummary() *boc.Summary {
+	return &boc.Summary{
+		Date:        "2022-06-01",
+		Rows:        []boc.SummaryRow{{Series: boc.SeriesYield10Year, Label: "10 year benchmark yield", Value: 2.97, Change: 7}},
+		Alerts:      []boc.AlertEvent{{Name: "10y above 2.9%", Date: "2022-06-01"}},
+		Attribution: boc.Attribution{Text: "Source: Bank of Canada.", TermsURL: "https://www.bankofcanada.ca/terms/"},
+	}
+}
+
+func TestSlack(t *testing.T) {
+	a := assert.New(t)
+	srv, messages := newWebhookServer(t, http.StatusOK)
+	w := Slack(srv.URL)
+
+	require.NoError(t, w.SendSummary(context.Background(), testSummary()))
+	require.NoError(t, w.SendAlert(context.Background(), boc.AlertEvent{Name: "10y above 2.9%", Date: "2022-06-01"}))
+	require.Len(t, *messages, 2)
+	a.Equal("*Bank of Canada yields for 2022-06-01*\n```\n"+
+		"10 year benchmark yield    2.97%  +7 bps\n\nAlerts:\n- 10y above 2.9% on 2022-06-01\n\n"+
+		"Source: Bank of Canada. https://www.bankofcanada.ca/terms/\n```", (*messages)[0]["text"])
+	a.Equal("*Alert: 10y above 2.9%* triggered on 2022-06-01", (*messages)[1]["text"])
+}
+
+func TestDiscord(t *testing.T) {
+	srv, messages := newWebhookServer(t, http.StatusNoContent)
+	require.NoError(t, Discord(srv.URL).SendAlert(context.Background(), boc.AlertEvent{Name: "2y moved", Date: "2022-06-01"}))
+	require.Len(t, *messages, 1)
+	assert.Equal(t, "**Alert: 2y moved** triggered on 2022-06-01", (*messages)[0]["content"])
+}
+
+func TestWebhookError(t *testing.T) {
+	srv, _ := newWebhookServer(t, http.StatusForbidden)
+	err := Slack(srv.URL).SendSummary(context.Background(), testSummary())
+	assert.ErrorContains(t, err, "403: invalid_token")
+}
@@ -0,0 +1,339 @@
+package boc
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/clauderoy790/bank-of-canada-interests-rates/cache"
+)
+
+// Option configures the client returned by NewBOCInterests
+type Option func(*bocInterests)
+
+// WithLogger makes the client emit structured logs to logger.
+// By default the client does not log anything.
+func WithLogger(logger *slog.Logger) Option {
+	return func(b *bocInterests) {
+		if logger != nil {
+			b.logger = logger
+		}
+	}
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// WithTracerProvider makes the client create OpenTelemetry spans around
+// HTTP fetches, JSON decoding, cache reads and writes, and indexing of the
+// observations. By default no spans are recorded.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(b *bocInterests) {
+		if tp != nil {
+			b.tracer = tp.Tracer(tracerName)
+		}
+	}
+}
+
+// WithHooks registers hooks called around each request made to the Valet API
+func WithHooks(hooks Hooks) Option {
+	return func(b *bocInterests) {
+		b.hooks = hooks
+	}
+}
+
+// WithRetry retries failed fetches up to maxRetries times. The wait before
+// each retry starts at backoff and doubles after every attempt, or is the
+// Retry-After of a 429 or 503 response if longer, see WithMaxRetryAfter.
+// Network errors, 429 and 5xx responses are retried. By default fetches are not retried.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(b *bocInterests) {
+		b.retries = maxRetries
+		b.backoff = backoff
+	}
+}
+
+// defaultMaxRetryAfter is the longest Retry-After waited for unless WithMaxRetryAfter is used
+const defaultMaxRetryAfter = time.Minute
+
+// WithMaxRetryAfter sets the longest wait requested by the Retry-After header
+// of a 429 or 503 response that WithRetry honors. A response asking for a
+// longer wait fails the fetch right away, with DataError.RetryAfter set.
+// The default is one minute.
+func WithMaxRetryAfter(limit time.Duration) Option {
+	return func(b *bocInterests) {
+		b.retryAfterLimit = limit
+	}
+}
+
+// defaultTimeout is the time limit of a request unless WithTimeout is used
+const defaultTimeout = 30 * time.Second
+
+// WithTimeout limits the time of each request to the Valet API, including
+// reading the response. A request timing out is retried like a network error
+// with WithRetry. The default is 30 seconds, 0 disables the limit.
+func WithTimeout(timeout time.Duration) Option {
+	return func(b *bocInterests) {
+		b.timeout = timeout
+	}
+}
+
+// WithHedging sends a second request when the first has not answered after
+// delay, and uses the first successful response, to cut the tail latency of
+// latency-sensitive paths such as startup. The slower request is cancelled.
+// By default a single request is sent.
+func WithHedging(delay time.Duration) Option {
+	return func(b *bocInterests) {
+		b.hedgeDelay = delay
+	}
+}
+
+// WithHTTPClient makes the client send its requests with client instead of
+// http.DefaultClient, e.g. to use a proxy. The timeout of WithTimeout still applies.
+// A nil client is ignored.
+func WithHTTPClient(client *http.Client) Option {
+	return func(b *bocInterests) {
+		if client != nil {
+			b.httpClient = client
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent of the requests to the Valet API, e.g. the
+// name of the application and a contact. The default is DefaultUserAgent.
+func WithUserAgent(userAgent string) Option {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithHeader sets a header on every request to the Valet API, e.g. the
+// identification required by a corporate gateway. It can be used several times.
+func WithHeader(key, value string) Option {
+	return func(b *bocInterests) {
+		if b.headers == nil {
+			b.headers = make(http.Header)
+		}
+		b.headers.Set(key, value)
+	}
+}
+
+// WithCircuitBreaker stops contacting the Valet API after threshold consecutive
+// failed fetches. For cooldown, fetches fail with ErrCircuitOpen and the client
+// keeps serving the data it has; then one fetch is tried to close the circuit.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(b *bocInterests) {
+		b.breaker = newBreaker(max(threshold, 1), cooldown)
+	}
+}
+
+// WithStaleIfError makes Refresh keep serving the data in use instead of
+// returning an error when the fetch fails. Staleness reports the failure.
+func WithStaleIfError() Option {
+	return func(b *bocInterests) {
+		b.staleIfError = true
+	}
+}
+
+// WithMaxStaleness makes the lookups refresh the data first when it is older
+// than maxStaleness, by fetch time or, if unknown, by latest observation date.
+// A lookup fails with a StaleDataError if the refresh fails, unless
+// WithStaleIfError is used, and the data is not refreshed again for a minute
+// or maxStaleness if shorter. The refresh uses the context of the Context query
+// option if given. All the lookups that return an error are checked; All,
+// Observations, Records, the metadata and Save read the data in use as is.
+// See also WithStaleDataErrors.
+func WithMaxStaleness(maxStaleness time.Duration) Option {
+	return func(b *bocInterests) {
+		b.maxStaleness = maxStaleness
+	}
+}
+
+// WithStaleDataErrors makes the lookups fail with a StaleDataError instead of
+// refreshing data older than the limit of WithMaxStaleness, for callers that
+// schedule the refreshes themselves
+func WithStaleDataErrors() Option {
+	return func(b *bocInterests) {
+		b.staleErrors = true
+	}
+}
+
+// WithTransport makes the client send its requests through transport, e.g. a
+// TransportFunc calling the browser fetch API from a js/wasm build, or a stub in
+// tests. Under GOOS=js the default transport of net/http already uses fetch.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(b *bocInterests) {
+		b.httpClient = &http.Client{Transport: transport}
+	}
+}
+
+// WithTransportOptions makes the client send its requests through a transport
+// tuned with opts, e.g. a larger connection pool for a service making many
+// requests. It replaces the client of WithHTTPClient and WithTransport.
+func WithTransportOptions(opts TransportOptions) Option {
+	return func(b *bocInterests) {
+		b.httpClient = &http.Client{Transport: opts.transport()}
+	}
+}
+
+// WithLanguage selects the English (bankofcanada.ca) or French (banqueducanada.ca)
+// Valet endpoint. Labels and descriptions are returned in the selected language.
+// The default is French.
+func WithLanguage(lang Language) Option {
+	return func(b *bocInterests) {
+		b.language = lang
+		b.baseURL = lang.baseURL()
+	}
+}
+
+// WithBaseURL overrides the Valet endpoint, e.g. "https://www.bankofcanada.ca/valet".
+// It is useful behind a proxy or for tests.
+func WithBaseURL(baseURL string) Option {
+	return func(b *bocInterests) {
+		b.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithSeries fetches only the given series of the group, in a single request,
+// instead of the whole group. The other series have no value. The keys must be
+// series of the group, e.g. SeriesYield2Year.
+func WithSeries(keys ...string) Option {
+	return func(b *bocInterests) {
+		b.series = append(b.series, keys...)
+	}
+}
+
+// WithDiffHandler registers a handler called after each Refresh that changed the
+// data, with the dates added or removed and the values revised by the Bank of Canada.
+// It can be used several times to register several handlers.
+func WithDiffHandler(handler func(Diff)) Option {
+	return func(b *bocInterests) {
+		b.diffHandlers = append(b.diffHandlers, handler)
+	}
+}
+
+// WithAlert registers a handler called for each date added or revised by a
+// Refresh whose observation satisfies condition. See Above, Below, MovedBy,
+// SpreadAbove and SpreadBelow for the usual conditions.
+func WithAlert(name string, condition Condition, handler func(AlertEvent)) Option {
+	return func(b *bocInterests) {
+		b.alerts = append(b.alerts, alert{name: name, condition: condition, handler: handler})
+	}
+}
+
+// WithUnknownSeries registers a handler called, while decoding, with each value
+// of a series that Observations has no field for, e.g. a series added to the
+// group by the Bank of Canada. Without handler such values are dropped.
+// It can be used several times to register several handlers.
+func WithUnknownSeries(handler func(date, seriesKey string, value Val)) Option {
+	return func(b *bocInterests) {
+		b.unknown = append(b.unknown, handler)
+	}
+}
+
+// WithProgress makes the client call handler while it fetches data, with the
+// bytes downloaded then the observations decoded, e.g. to show a progress bar
+// during the several seconds a full history takes
+func WithProgress(handler func(Progress)) Option {
+	return func(b *bocInterests) {
+		b.progress = handler
+	}
+}
+
+// WithSchemaDriftHandler registers a handler called when a fetched payload
+// lacks expected series, has unexpected ones or has values that are not
+// numbers. The drift is logged as a
+// warning either way. It can be used several times to register several handlers.
+func WithSchemaDriftHandler(handler func(SchemaDrift)) Option {
+	return func(b *bocInterests) {
+		b.driftHandlers = append(b.driftHandlers, handler)
+	}
+}
+
+// WithLegacySeries makes LongRun extend the history of the daily series
+// seriesKey with the monthly series monthlyKey instead of the one of LegacySeries.
+// It can be used several times.
+func WithLegacySeries(seriesKey, monthlyKey string) Option {
+	return func(b *bocInterests) {
+		if b.legacy == nil {
+			b.legacy = make(map[string]string)
+		}
+		b.legacy[seriesKey] = monthlyKey
+	}
+}
+
+// WithCache makes the client read the Valet payload from store before
+// downloading it, and cache what it downloads for ttl, forever if ttl is 0.
+// Clients sharing a store share one copy of the payload, see the rediscache package.
+// Refresh does not read the cache, it downloads the data and caches it.
+func WithCache(store cache.Store, ttl time.Duration) Option {
+	return func(b *bocInterests) {
+		b.cache = store
+		b.cacheTTL = ttl
+	}
+}
+
+// WithStorage makes the client start from the data saved in storage instead
+// of fetching it, and save the data of every fetch and refresh to it.
+func WithStorage(storage Storage) Option {
+	return func(b *bocInterests) {
+		b.storage = storage
+	}
+}
+
+// WithFullRefresh makes Refresh download the whole history instead of the
+// observations since the latest known date, so that the revisions of older
+// dates and the removed dates are picked up and reported to the diff handlers.
+func WithFullRefresh() Option {
+	return func(b *bocInterests) {
+		b.fullRefresh = true
+	}
+}
+
+// WithChunkedFetch makes the client download the full history as one request
+// per year, from startYear to the current year, with up to workers requests at
+// a time. The responses are smaller and fetched concurrently. The fetches fail
+// while startYear is after the current year.
+func WithChunkedFetch(startYear, workers int) Option {
+	return func(b *bocInterests) {
+		b.chunkStart = startYear
+		b.chunkWorkers = max(workers, 1)
+	}
+}
+
+// WithGroupWorkers limits the number of groups a MultiGroup fetches at a time.
+// By default all the groups are fetched at once.
+func WithGroupWorkers(workers int) Option {
+	return func(b *bocInterests) {
+		b.groupWorkers = workers
+	}
+}
+
+// WithSink makes each Refresh that changed the data write the observations
+// of the dates added or revised to sink. It can be used several times to
+// register several sinks. See Backfill to write the history first.
+func WithSink(sink Sink) Option {
+	return func(b *bocInterests) {
+		b.sinks = append(b.sinks, sink)
+	}
+}
+
+// WithDateLayout makes the client read the numeric dates it is given according
+// to layout, e.g. "DD-MM-YYYY", instead of guessing the day and the month.
+// See FormatDateLayout.
+func WithDateLayout(layout string) Option {
+	return func(b *bocInterests) {
+		b.dateLayout = layout
+	}
+}
+
+// WithStrictDates makes the client reject the dates whose day and month cannot
+// be told apart instead of guessing. See FormatDateStrict.
+func WithStrictDates() Option {
+	return func(b *bocInterests) {
+		b.strictDates = true
+	}
+}
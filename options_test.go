@@ -0,0 +1,59 @@
+package boc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLogger(t *testing.T) {
+	a := assert.New(t)
+	srv := newTestServer(t, http.StatusOK)
+	buf := new(bytes.Buffer)
+	b := newBOCInterests(WithLogger(slog.New(slog.NewTextHandler(buf, nil))), WithBaseURL(srv.URL))
+
+	a.NoError(b.load(context.Background()))
+	a.Contains(buf.String(), "fetch start")
+	a.Contains(buf.String(), "fetch finished")
+	a.Contains(buf.String(), "observations=8")
+}
+
+func TestWithLoggerNil(t *testing.T) {
+	b := newBOCInterests(WithLogger(nil))
+	assert.NotNil(t, b.logger)
+}
+
+func TestWithSeries(t *testing.T) {
+	a := assert.New(t)
+	fixture := readFixture(t)
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		data := &BOCData{SeriesDetail: fixture.SeriesDetail}
+		for _, obs := range fixture.Observations {
+			data.Observations = append(data.Observations, Observations{D: obs.D, Yield2Year: obs.Yield2Year, Yield10Year: obs.Yield10Year})
+		}
+		json.NewEncoder(w).Encode(data)
+	}))
+	t.Cleanup(srv.Close)
+
+	b, err := NewBOCInterests(WithBaseURL(srv.URL), WithSeries(SeriesYield2Year, SeriesYield10Year))
+	require.NoError(t, err)
+	a.Equal([]string{"/observations/" + SeriesYield2Year + "," + SeriesYield10Year + "/json"}, paths)
+	v, err := b.SeriesValue("2022-06-01", SeriesYield10Year)
+	a.NoError(err)
+	a.Equal(2.97, v)
+	_, err = b.SeriesValue("2022-06-01", SeriesYield5Year)
+	a.ErrorIs(err, ErrNoValue)
+
+	_, err = NewBOCInterests(WithBaseURL(srv.URL), WithSeries(SeriesBreakevenInflation))
+	a.ErrorIs(err, ErrUnknownSeries)
+	a.Len(paths, 1, "unknown series are rejected before fetching")
+}
@@ -0,0 +1,41 @@
+package boc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GetObservationsForPeriod implements BOCInterests
+func (b *bocInterests) GetObservationsForPeriod(period string) ([]Observations, error) {
+	start, end, err := parsePeriod(period)
+	if err != nil {
+		return nil, &DataError{Date: period, Err: fmt.Errorf("%w: %w", ErrInvalidDate, err)}
+	}
+	ds, err := b.fresh(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	from, to := ds.bounds(start.Format(time.DateOnly), end.Format(time.DateOnly))
+	observations := make([]Observations, 0, to-from)
+	for i := from; i < to; i++ {
+		observations = append(observations, *ds.observation(i))
+	}
+	return observations, nil
+}
+
+// parsePeriod returns the first and the last day of a year like "2023" or of a
+// month like "2023-05" or "2023/05"
+func parsePeriod(period string) (time.Time, time.Time, error) {
+	period = strings.TrimSpace(period)
+	if t, err := time.Parse("2006", period); err == nil {
+		return t, t.AddDate(1, 0, -1), nil
+	}
+	for _, layout := range []string{"2006-01", "2006/01"} {
+		if t, err := time.Parse(layout, period); err == nil {
+			return t, t.AddDate(0, 1, -1), nil
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("period %q is not a year like 2023 or a month like 2023-05", period)
+}
@@ -0,0 +1,44 @@
+package boc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetObservationsForPeriod(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	tests := []struct {
+		period string
+		want   []string
+	}{
+		{period: "2022", want: []string{"2022-05-20", "2022-05-24", "2022-05-25", "2022-05-26", "2022-05-27", "2022-05-30", "2022-05-31", "2022-06-01"}},
+		{period: "2022-05", want: []string{"2022-05-20", "2022-05-24", "2022-05-25", "2022-05-26", "2022-05-27", "2022-05-30", "2022-05-31"}},
+		{period: " 2022/06 ", want: []string{"2022-06-01"}},
+		{period: "2022-04", want: []string{}},
+		{period: "2021", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			observations, err := b.GetObservationsForPeriod(tt.period)
+			require.NoError(t, err)
+			dates := []string{}
+			for _, obs := range observations {
+				dates = append(dates, obs.D)
+			}
+			assert.Equal(t, tt.want, dates)
+		})
+	}
+
+	observations, err := b.GetObservationsForPeriod("2022-06")
+	require.NoError(t, err)
+	a.Equal("2.73", observations[0].Yield2Year.V)
+
+	for _, invalid := range []string{"", "22", "2022-13", "2022-05-01", "May"} {
+		_, err := b.GetObservationsForPeriod(invalid)
+		a.ErrorIs(err, ErrInvalidDate, invalid)
+	}
+}
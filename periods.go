@@ -0,0 +1,134 @@
+package boc
+
+import (
+	"fmt"
+	"time"
+)
+
+// ByMonth returns the observations of client from start to end grouped by month,
+// e.g. "2022-05", each in chronological order. An empty start or end leaves that side open.
+func ByMonth(client ObservationReader, start, end string) (map[string][]Observations, error) {
+	return groupBy(client, start, end, len("2006-01"))
+}
+
+// ByYear returns the observations of client from start to end grouped by year, e.g. "2022"
+func ByYear(client ObservationReader, start, end string) (map[string][]Observations, error) {
+	return groupBy(client, start, end, len("2006"))
+}
+
+// groupBy groups the observations from start to end by the prefix of their date of length n
+func groupBy(client ObservationReader, start, end string, n int) (map[string][]Observations, error) {
+	seq, err := client.Between(start, end)
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[string][]Observations)
+	for date, obs := range seq {
+		groups[date[:n]] = append(groups[date[:n]], *obs)
+	}
+	return groups, nil
+}
+
+// AnnualAverage returns the average of the daily values of a series over a
+// year, rounded to the decimals of the values as the Bank of Canada publishes it
+func AnnualAverage(client ObservationReader, seriesKey string, year int) (float64, error) {
+	return average(client, seriesKey, fmt.Sprintf("%04d-01-01", year), fmt.Sprintf("%04d-12-31", year))
+}
+
+// QuarterlyAverage returns the average of the daily values of a series over a
+// quarter of a year, from 1 to 4, rounded like AnnualAverage
+func QuarterlyAverage(client ObservationReader, seriesKey string, year, quarter int) (float64, error) {
+	if quarter < 1 || quarter > 4 {
+		return 0, fmt.Errorf("invalid quarter %d, expected 1 to 4", quarter)
+	}
+	first := date(year, time.Month(3*quarter-2), 1)
+	last := first.AddDate(0, 3, -1)
+	return average(client, seriesKey, first.Format("2006-01-02"), last.Format("2006-01-02"))
+}
+
+// average returns the mean of the values of a series from the formatted start
+// to end, rounded to the decimals the values are published with
+func average(client ObservationReader, seriesKey, start, end string) (float64, error) {
+	if err := checkSeries(seriesKey); err != nil {
+		return 0, err
+	}
+	seq, err := between(client, start, end)
+	if err != nil {
+		return 0, err
+	}
+	sum, n, places := 0.0, 0, -1
+	for _, obs := range seq {
+		v, ok := obs.Value(seriesKey)
+		if !ok {
+			continue
+		}
+		sum += v
+		n++
+		if published, ok := obs.val(seriesKey); ok {
+			places = max(places, decimals(published.V))
+		}
+	}
+	if n == 0 {
+		return 0, &DataError{Date: start, Series: seriesKey, Err: ErrNoValue}
+	}
+	if places < 0 {
+		places = 2
+	}
+	return round(sum/float64(n), places), nil
+}
+
+// SnapshotAt returns the last observation of each period of client from start
+// to end, e.g. the quarter-end or year-end values, skipping the period in progress
+func SnapshotAt(client ObservationReader, freq Frequency, start, end string) ([]Observations, error) {
+	seq, err := client.Between(start, end)
+	if err != nil {
+		return nil, err
+	}
+	observations := make([]Observations, 0)
+	for _, obs := range seq {
+		observations = append(observations, *obs)
+	}
+	snapshots := make([]Observations, 0)
+	for i, obs := range observations {
+		period := periodEnd(obs.D, freq)
+		next := ""
+		if i+1 < len(observations) {
+			next = observations[i+1].D
+		} else if next, err = nextDate(client, obs.D); err != nil {
+			return nil, err
+		}
+		if next != "" {
+			if periodEnd(next, freq) == period {
+				continue
+			}
+		} else if obs.D < lastBusinessDay(period) {
+			// the period is not over
+			continue
+		}
+		snapshots = append(snapshots, obs)
+	}
+	return snapshots, nil
+}
+
+// nextDate returns the first date of client after the formatted date, empty if there is none
+func nextDate(client ObservationReader, date string) (string, error) {
+	seq, err := between(client, date, "")
+	if err != nil {
+		return "", err
+	}
+	for next := range seq {
+		if next > date {
+			return next, nil
+		}
+	}
+	return "", nil
+}
+
+// lastBusinessDay returns the last business day on or before the formatted date
+func lastBusinessDay(formatted string) string {
+	t, err := time.Parse("2006-01-02", formatted)
+	if err != nil {
+		return formatted
+	}
+	return PreviousBusinessDay(t.AddDate(0, 0, 1)).Format("2006-01-02")
+}
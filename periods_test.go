@@ -0,0 +1,114 @@
+package boc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByMonth(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	months, err := ByMonth(b, "", "")
+	require.NoError(t, err)
+	require.Len(t, months, 2)
+	a.Len(months["2022-05"], 7)
+	a.Equal("2022-05-20", months["2022-05"][0].D)
+	a.Equal("2022-05-31", months["2022-05"][6].D)
+	require.Len(t, months["2022-06"], 1)
+	a.Equal("2.73", months["2022-06"][0].Yield2Year.V)
+
+	months, err = ByMonth(b, "2022-05-30", "2022-05-31")
+	require.NoError(t, err)
+	a.Len(months, 1)
+	a.Len(months["2022-05"], 2)
+
+	_, err = ByMonth(b, "foo", "")
+	a.ErrorIs(err, ErrInvalidDate)
+}
+
+func TestByYear(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	years, err := ByYear(b, "", "")
+	require.NoError(t, err)
+	a.Len(years, 1)
+	a.Len(years["2022"], 8)
+
+	years, err = ByYear(b, "2023-01-01", "")
+	require.NoError(t, err)
+	a.Empty(years)
+}
+
+func TestAverages(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	v, err := AnnualAverage(b, SeriesYield2Year, 2022)
+	require.NoError(t, err)
+	a.Equal(2.61, v, "2.61375 rounded to the published decimals")
+
+	v, err = QuarterlyAverage(b, SeriesYieldRRB, 2022, 2)
+	require.NoError(t, err)
+	a.Equal(0.62, v, "the date without value is skipped")
+
+	v, err = QuarterlyAverage(b, SeriesBreakevenInflation, 2022, 2)
+	require.NoError(t, err)
+	a.InDelta(2.28, v, 0.1)
+
+	_, err = QuarterlyAverage(b, SeriesYield2Year, 2022, 1)
+	a.ErrorIs(err, ErrNoValue)
+	_, err = QuarterlyAverage(b, SeriesYield2Year, 2022, 5)
+	a.Error(err)
+	_, err = AnnualAverage(b, "foo", 2022)
+	a.ErrorIs(err, ErrUnknownSeries)
+}
+
+func TestSnapshotAt(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	dates := func(observations []Observations) []string {
+		dates := make([]string, 0, len(observations))
+		for _, obs := range observations {
+			dates = append(dates, obs.D)
+		}
+		return dates
+	}
+
+	snapshots, err := SnapshotAt(b, Monthly, "", "")
+	require.NoError(t, err)
+	a.Equal([]string{"2022-05-31"}, dates(snapshots), "June is not over")
+	a.Equal("2.68", snapshots[0].Yield2Year.V)
+
+	snapshots, err = SnapshotAt(b, Weekly, "", "")
+	require.NoError(t, err)
+	a.Equal([]string{"2022-05-20", "2022-05-27"}, dates(snapshots))
+
+	snapshots, err = SnapshotAt(b, Weekly, "2022-05-24", "")
+	require.NoError(t, err)
+	a.Equal([]string{"2022-05-27"}, dates(snapshots))
+
+	srv := newDataServer(t, func() *BOCData {
+		data := new(BOCData)
+		for _, date := range []string{"2021-12-30", "2021-12-31", "2022-03-30", "2022-03-31", "2022-06-29", "2022-07-04"} {
+			data.Observations = append(data.Observations, Observations{D: date, Yield2Year: Val{V: "1.00"}})
+		}
+		return data
+	})
+	b = newBOCInterests(WithBaseURL(srv.URL))
+	require.NoError(t, b.load(context.Background()))
+
+	snapshots, err = SnapshotAt(b, Quarterly, "", "")
+	require.NoError(t, err)
+	a.Equal([]string{"2021-12-31", "2022-03-31", "2022-06-29"}, dates(snapshots))
+	snapshots, err = SnapshotAt(b, Yearly, "", "2022-12-31")
+	require.NoError(t, err)
+	a.Equal([]string{"2021-12-31"}, dates(snapshots))
+
+	_, err = SnapshotAt(b, Yearly, "foo", "")
+	a.ErrorIs(err, ErrInvalidDate)
+}
@@ -0,0 +1,33 @@
+package boc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Ping implements BOCInterests
+func (b *bocInterests) Ping(ctx context.Context) error {
+	url := b.baseURL + "/groups/" + b.group + "/json"
+	resp, body, err := b.doRequest(ctx, url)
+	if err != nil {
+		b.logger.Warn("ping failed", "url", url, "error", err)
+		return &DataError{Err: fmt.Errorf("error pinging the Valet API: %w", err)}
+	}
+	if resp.StatusCode != http.StatusOK {
+		err := statusError(resp, body)
+		b.logger.Warn("ping failed", "url", url, "status", resp.StatusCode)
+		return err
+	}
+	var payload struct {
+		GroupDetails *json.RawMessage `json:"groupDetails"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return &DataError{StatusCode: resp.StatusCode, Snippet: snippet(body), Err: fmt.Errorf("failed to parse ping response: %w", err)}
+	}
+	if payload.GroupDetails == nil {
+		return &DataError{StatusCode: resp.StatusCode, Snippet: snippet(body), Err: fmt.Errorf("ping response has no group details")}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package boc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPing(t *testing.T) {
+	a := assert.New(t)
+	var path string
+	body := `{"groupDetails": {"name": "bond_yields_all", "label": "Bond yields"}, "groupSeries": {}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	b := newBOCInterests(WithBaseURL(srv.URL))
+
+	a.NoError(b.Ping(context.Background()))
+	a.Equal("/groups/bond_yields_all/json", path)
+
+	body = `{"observations": []}`
+	a.ErrorContains(b.Ping(context.Background()), "ping response has no group details")
+	body = `<html>`
+	a.ErrorContains(b.Ping(context.Background()), "failed to parse ping response")
+
+	b = newBOCInterests(WithBaseURL(newTestServer(t, http.StatusServiceUnavailable).URL))
+	a.ErrorIs(b.Ping(context.Background()), ErrBadStatus)
+	srv.Close()
+	b = newBOCInterests(WithBaseURL(srv.URL))
+	a.ErrorContains(b.Ping(context.Background()), "error pinging the Valet API")
+}
@@ -0,0 +1,62 @@
+package boc
+
+import (
+	"context"
+	"sort"
+)
+
+// SeriesPolicyRate is the key of the target for the overnight rate, the policy
+// interest rate of the Bank of Canada. It is not part of the bond yields group,
+// see FetchSeries.
+const SeriesPolicyRate = "V39079"
+
+// ChangeEvent is a change of a rate that moves by steps, e.g. the policy rate
+type ChangeEvent struct {
+	// Date is the first date with the new value
+	Date string
+	Old  float64
+	New  float64
+	// Change is New minus Old
+	Change Bps
+}
+
+// FetchSeries fetches the values of any series of the Valet API by key, e.g.
+// SeriesPolicyRate, in chronological order, skipping the dates without value.
+// The options configure the request as for NewBOCInterests.
+func FetchSeries(ctx context.Context, seriesKey string, opts ...Option) ([]Point, error) {
+	b := newBOCInterests(opts...)
+	data, err := b.fetchValues(ctx, b.baseURL+"/observations/"+seriesKey+"/json")
+	if err != nil {
+		return nil, err
+	}
+	points := make([]Point, 0, len(data.values))
+	for date, values := range data.values {
+		if v, ok := values[seriesKey]; ok {
+			points = append(points, Point{Date: date, Value: v})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Date < points[j].Date })
+	return points, nil
+}
+
+// ChangeEvents returns every change of the value of chronological points, e.g.
+// the hikes and the cuts of the policy rate
+func ChangeEvents(points []Point) []ChangeEvent {
+	events := make([]ChangeEvent, 0)
+	for i := 1; i < len(points); i++ {
+		if old, v := points[i-1].Value, points[i].Value; v != old {
+			events = append(events, ChangeEvent{Date: points[i].Date, Old: old, New: v, Change: ToBps(v - old)})
+		}
+	}
+	return events
+}
+
+// PolicyRateChanges fetches the policy rate and returns its changes, the
+// history of the decisions of the Bank of Canada that moved it
+func PolicyRateChanges(ctx context.Context, opts ...Option) ([]ChangeEvent, error) {
+	points, err := FetchSeries(ctx, SeriesPolicyRate, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ChangeEvents(points), nil
+}
@@ -0,0 +1,49 @@
+package boc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeEvents(t *testing.T) {
+	a := assert.New(t)
+	a.Empty(ChangeEvents(nil))
+	a.Equal([]ChangeEvent{
+		{Date: "2022-03-03", Old: 0.25, New: 0.5, Change: 25},
+		{Date: "2022-04-14", Old: 0.5, New: 1, Change: 50},
+	}, ChangeEvents([]Point{
+		{Date: "2022-03-01", Value: 0.25},
+		{Date: "2022-03-03", Value: 0.5},
+		{Date: "2022-03-04", Value: 0.5},
+		{Date: "2022-04-14", Value: 1},
+	}))
+}
+
+func TestPolicyRateChanges(t *testing.T) {
+	a := assert.New(t)
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"seriesDetail": {"V39079": {"label": "V39079"}}, "observations": [
+			{"d": "2022-06-02", "V39079": {"v": "1.50"}},
+			{"d": "2022-06-01", "V39079": {"v": "1.50"}},
+			{"d": "2022-05-31", "V39079": {"v": "1.00"}},
+			{"d": "2022-05-30", "V39079": {"v": ""}},
+			{"d": "2022-05-27", "V39079": {"v": "1.00"}}
+		]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	events, err := PolicyRateChanges(context.Background(), WithBaseURL(srv.URL))
+	require.NoError(t, err)
+	a.Equal("/observations/V39079/json", path)
+	a.Equal([]ChangeEvent{{Date: "2022-06-01", Old: 1, New: 1.5, Change: 50}}, events)
+
+	_, err = PolicyRateChanges(context.Background(), WithBaseURL(newTestServer(t, http.StatusServiceUnavailable).URL))
+	a.ErrorIs(err, ErrBadStatus)
+}
@@ -0,0 +1,49 @@
+package boc
+
+import "io"
+
+// progressInterval is the number of bytes read between two progress reports
+const progressInterval = 32 << 10
+
+// Progress reports how far a fetch of the Valet API is, see WithProgress
+type Progress struct {
+	URL string
+	// BytesRead is the number of bytes of the response read so far, as sent on the wire
+	BytesRead int64
+	// TotalBytes is the length of the response, -1 while downloading if the server did not send it
+	TotalBytes int64
+	// Observations is the number of observations decoded so far, 0 while downloading
+	Observations int
+	// Done is set on the last report of the fetch, once all the observations are decoded
+	Done bool
+}
+
+// progressReader reports the bytes read from r every progressInterval bytes and at the end
+type progressReader struct {
+	r        io.Reader
+	progress Progress
+	reported int64
+	report   func(Progress)
+}
+
+// Read implements io.Reader
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	p.progress.BytesRead += int64(n)
+	if p.progress.BytesRead-p.reported >= progressInterval || err == io.EOF && p.progress.BytesRead > p.reported {
+		p.reported = p.progress.BytesRead
+		p.report(p.progress)
+	}
+	return n, err
+}
+
+// decodeProgress returns the function reporting the observations decoded from
+// the response of url, nil without WithProgress
+func (b *bocInterests) decodeProgress(url string, size int) func(observations int) {
+	if b.progress == nil {
+		return nil
+	}
+	return func(observations int) {
+		b.progress(Progress{URL: url, BytesRead: int64(size), TotalBytes: int64(size), Observations: observations})
+	}
+}
@@ -0,0 +1,58 @@
+package boc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithProgress(t *testing.T) {
+	a := assert.New(t)
+	var sb strings.Builder
+	sb.WriteString(`{"observations":[`)
+	for i := 0; i < 3*decodeCheckInterval; i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(&sb, `{"d":"%d-05-20","BD.CDN.2YR.DQ.YLD":{"v":"2.59"},"BD.CDN.10YR.DQ.YLD":{"v":"2.90"},"BD.CDN.LONG.DQ.YLD":{"v":"3.01"}}`, 1300+i)
+	}
+	sb.WriteString(`]}`)
+	payload := sb.String()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", fmt.Sprint(len(payload)))
+		w.Write([]byte(payload))
+	}))
+	t.Cleanup(srv.Close)
+
+	var reports []Progress
+	b := newBOCInterests(WithBaseURL(srv.URL), WithProgress(func(p Progress) { reports = append(reports, p) }))
+	require.NoError(t, b.load(context.Background()))
+
+	size := int64(len(payload))
+	var downloads, decodes int
+	var downloaded int64
+	for i, p := range reports {
+		a.Equal(b.dataURL(), p.URL)
+		if p.Observations == 0 {
+			downloads++
+			downloaded = p.BytesRead
+			a.Equal(size, p.TotalBytes)
+			if i > 0 {
+				a.Greater(p.BytesRead, reports[i-1].BytesRead)
+			}
+		} else {
+			decodes++
+		}
+	}
+	a.GreaterOrEqual(downloads, 2, "the download is reported as it goes")
+	a.Equal(size, downloaded)
+	a.Equal(3, decodes)
+	a.Equal(Progress{URL: b.dataURL(), BytesRead: size, TotalBytes: size, Observations: 3 * decodeCheckInterval, Done: true}, reports[len(reports)-1])
+	a.Equal(2*decodeCheckInterval, reports[len(reports)-2].Observations)
+}
@@ -0,0 +1,214 @@
+package boc
+
+import (
+	"context"
+	"iter"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// QueryOption configures a single lookup
+type QueryOption func(*query)
+
+type query struct {
+	forwardFill bool
+	interpolate bool
+	ctx         context.Context
+}
+
+func newQuery(opts []QueryOption) *query {
+	q := new(query)
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// context returns the context of the lookup, see Context
+func (q *query) context() context.Context {
+	if q.ctx == nil {
+		return context.Background()
+	}
+	return q.ctx
+}
+
+// Context sets the context of the refresh a lookup makes when the data is
+// older than the limit of WithMaxStaleness, e.g. to bound it by a request deadline
+func Context(ctx context.Context) QueryOption {
+	return func(q *query) {
+		q.ctx = ctx
+	}
+}
+
+// ForwardFill fills the dates without observation (weekends, holidays) and the
+// missing series values with the last known prior value. With Between, every
+// calendar day of the range is returned once a prior value is known.
+func ForwardFill() QueryOption {
+	return func(q *query) {
+		q.forwardFill = true
+	}
+}
+
+// Interpolate fills the dates without observation and the missing series values
+// by linear interpolation, by calendar day, between the surrounding known values.
+// Values before the first or after the last known value of a series stay missing.
+// With Between, every calendar day of the range is returned. It takes precedence
+// over ForwardFill.
+func Interpolate() QueryOption {
+	return func(q *query) {
+		q.interpolate = true
+	}
+}
+
+// filledObservation returns the observation for date with its missing values
+// forward filled, or nil if there is no data on or before date
+func (d *dataset) filledObservation(date string) *Observations {
+	i := sort.Search(len(d.dates), func(i int) bool { return d.dates[i] > date }) - 1
+	if i < 0 {
+		return nil
+	}
+	obs := &Observations{D: date}
+	for c, key := range allSeries {
+		for j := i; j >= 0; j-- {
+			if !math.IsNaN(d.columns[c][j]) {
+				obs.field(key).V = d.format(c, j)
+				break
+			}
+		}
+	}
+	return obs
+}
+
+// filledSeq returns every calendar day from the formatted start to end, forward filling the
+// missing values. The range starts on the first date with data if start is
+// before it, and ends on the last date with data if end is empty.
+func (d *dataset) filledSeq(start, end string) iter.Seq2[string, *Observations] {
+	dates := d.dates
+	if len(dates) == 0 {
+		return func(yield func(string, *Observations) bool) {}
+	}
+	first, last := start, end
+	if first < dates[0] {
+		first = dates[0]
+	}
+	if last == "" {
+		last = dates[len(dates)-1]
+	}
+	return func(yield func(string, *Observations) bool) {
+		if first > last {
+			return
+		}
+		day, _ := time.Parse("2006-01-02", first)
+		current := d.filledObservation(first)
+		i := sort.SearchStrings(dates, first)
+		for date := first; date <= last; date = day.Format("2006-01-02") {
+			if i < len(dates) && dates[i] == date {
+				for c, key := range allSeries {
+					if v := d.format(c, i); v != "" {
+						current.field(key).V = v
+					}
+				}
+				i++
+			}
+			obs := *current
+			obs.D = date
+			if !yield(date, &obs) {
+				return
+			}
+			day = day.AddDate(0, 0, 1)
+		}
+	}
+}
+
+// interpolatedObservation returns the observation for the formatted date with its
+// missing values interpolated, or nil if no value can be interpolated
+func (d *dataset) interpolatedObservation(date string) *Observations {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil
+	}
+	// i is the index of the first date after date
+	i := sort.Search(len(d.dates), func(i int) bool { return d.dates[i] > date })
+	obs := &Observations{D: date}
+	found := false
+	for c, key := range allSeries {
+		if v, ok := d.interpolate(c, i, day); ok {
+			obs.field(key).V = v
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return obs
+}
+
+// interpolate returns the formatted value of column c at day, i being the index of
+// the first date after day. The value is as published if known, else interpolated
+// between the last known value on or before day and the first known value after
+// it, and rounded to the larger number of decimals of the two.
+func (d *dataset) interpolate(c, i int, day time.Time) (string, bool) {
+	column := d.columns[c]
+	prev := i - 1
+	for prev >= 0 && math.IsNaN(column[prev]) {
+		prev--
+	}
+	if prev < 0 {
+		return "", false
+	}
+	if d.dates[prev] == day.Format("2006-01-02") {
+		return d.format(c, prev), true
+	}
+	next := i
+	for next < len(column) && math.IsNaN(column[next]) {
+		next++
+	}
+	if next == len(column) {
+		return "", false
+	}
+	start, _ := time.Parse("2006-01-02", d.dates[prev])
+	end, _ := time.Parse("2006-01-02", d.dates[next])
+	ratio := day.Sub(start).Hours() / end.Sub(start).Hours()
+	// interpolated in units of the last decimal so that the float noise cannot
+	// change the rounding, e.g. of 2.575 to 2.58
+	decimals := int(max(d.decimals[c][prev], d.decimals[c][next]))
+	p := math.Pow10(decimals)
+	from, to := math.Round(column[prev]*p), math.Round(column[next]*p)
+	v := math.Round(from+ratio*(to-from)) / p
+	return strconv.FormatFloat(v, 'f', decimals, 64), true
+}
+
+// interpolatedSeq returns every calendar day from the formatted start to end with
+// the missing values interpolated. The range starts on the first date with data if
+// start is before it, and ends on the last date with data if end is empty.
+func (d *dataset) interpolatedSeq(start, end string) iter.Seq2[string, *Observations] {
+	dates := d.dates
+	if len(dates) == 0 {
+		return func(yield func(string, *Observations) bool) {}
+	}
+	first, last := start, end
+	if first < dates[0] {
+		first = dates[0]
+	}
+	if last == "" {
+		last = dates[len(dates)-1]
+	}
+	return func(yield func(string, *Observations) bool) {
+		if first > last {
+			return
+		}
+		day, _ := time.Parse("2006-01-02", first)
+		for date := first; date <= last; date = day.Format("2006-01-02") {
+			obs := d.interpolatedObservation(date)
+			if obs == nil {
+				obs = &Observations{D: date}
+			}
+			if !yield(date, obs) {
+				return
+			}
+			day = day.AddDate(0, 0, 1)
+		}
+	}
+}
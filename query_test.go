@@ -0,0 +1,165 @@
+package boc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForwardFillObservation(t *testing.T) {
+	b := newTestBOC(t)
+	tests := []struct {
+		name    string
+		date    string
+		year2   string
+		rrb     string
+		wantErr bool
+	}{
+		{
+			name:  "holiday uses previous business day",
+			date:  "2022-05-23",
+			year2: "2.59",
+			rrb:   "0.61",
+		},
+		{
+			name:  "missing series value",
+			date:  "2022-05-27",
+			year2: "2.61",
+			rrb:   "0.57",
+		},
+		{
+			name:  "weekend after missing value",
+			date:  "2022-05-29",
+			year2: "2.61",
+			rrb:   "0.57",
+		},
+		{
+			name:    "before first date",
+			date:    "2022-05-19",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			obs, err := b.GetObservationForDate(tt.date, ForwardFill())
+			if tt.wantErr {
+				a.ErrorIs(err, ErrNoData)
+				return
+			}
+			a.NoError(err)
+			a.Equal(tt.date, obs.D)
+			a.Equal(tt.year2, obs.Yield2Year.V)
+			a.Equal(tt.rrb, obs.YieldRRB.V)
+		})
+	}
+
+	obs, err := b.GetObservationForDate("2022-05-27")
+	assert.NoError(t, err)
+	assert.Equal(t, "", obs.YieldRRB.V)
+
+	v, err := b.SeriesValue("2022-05-28", SeriesYield10Year, ForwardFill())
+	assert.NoError(t, err)
+	assert.Equal(t, 2.80, v)
+}
+
+func TestForwardFillBetween(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	seq, err := b.Between("2022-05-21", "2022-05-30", ForwardFill())
+	a.NoError(err)
+	dates := make([]string, 0)
+	rrb := make([]string, 0)
+	for date, obs := range seq {
+		a.Equal(date, obs.D)
+		dates = append(dates, date)
+		rrb = append(rrb, obs.YieldRRB.V)
+	}
+	a.Equal([]string{
+		"2022-05-21", "2022-05-22", "2022-05-23", "2022-05-24", "2022-05-25",
+		"2022-05-26", "2022-05-27", "2022-05-28", "2022-05-29", "2022-05-30",
+	}, dates)
+	a.Equal([]string{"0.61", "0.61", "0.61", "0.58", "0.55", "0.57", "0.57", "0.57", "0.57", "0.63"}, rrb)
+
+	seq, err = b.Between("2022-01-01", "", ForwardFill())
+	a.NoError(err)
+	count := 0
+	first := ""
+	for date := range seq {
+		if first == "" {
+			first = date
+		}
+		count++
+	}
+	a.Equal("2022-05-20", first)
+	a.Equal(13, count)
+}
+
+func TestInterpolate(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	v, err := b.SeriesValue("2022-05-23", SeriesYield2Year, Interpolate())
+	require.NoError(t, err)
+	a.Equal(2.58, v, "three quarters of the way from 2.59 on Friday to 2.57 on Tuesday, rounded")
+
+	v, err = b.SeriesValue("2022-05-27", SeriesYieldRRB, Interpolate())
+	require.NoError(t, err)
+	a.Equal(0.59, v)
+
+	v, err = b.SeriesValue("2022-05-24", SeriesYield10Year, Interpolate())
+	require.NoError(t, err)
+	a.Equal(2.78, v, "published values are kept")
+
+	_, err = b.GetObservationForDate("2022-06-02", Interpolate())
+	a.ErrorIs(err, ErrNoData, "nothing to interpolate after the last date")
+	_, err = b.GetObservationForDate("2022-06-02", Interpolate(), ForwardFill())
+	a.ErrorIs(err, ErrNoData, "Interpolate takes precedence")
+
+	times, values, err := b.TimeSeries(SeriesYield2Year, "2022-05-19", "2022-05-24", Interpolate())
+	require.NoError(t, err)
+	a.Len(times, 5, "every calendar day from the first date with data")
+	a.Equal(2.59, values[0])
+	a.Equal(2.58, values[2])
+	a.Equal(2.57, values[4])
+
+	seq, err := b.Between("2022-05-31", "2022-06-02", Interpolate())
+	require.NoError(t, err)
+	dates := make([]string, 0)
+	for date, obs := range seq {
+		dates = append(dates, date)
+		if date == "2022-06-02" {
+			a.Empty(obs.Yield2Year.V)
+		}
+	}
+	a.Equal([]string{"2022-05-31", "2022-06-01", "2022-06-02"}, dates)
+}
+
+func TestInterpolateFormat(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	obs, err := b.GetObservationForDate("2022-05-23", Interpolate())
+	require.NoError(t, err)
+	a.Equal("2.58", obs.Yield2Year.V)
+	a.Equal("2.79", obs.Yield10Year.V)
+	obs, err = b.GetObservationForDate("2022-05-27", Interpolate())
+	require.NoError(t, err)
+	a.Equal("0.59", obs.YieldRRB.V)
+	a.Equal("2.80", obs.Yield10Year.V, "published values are kept as published")
+
+	b = newBOCInterests()
+	b.ds.Store(b.newDataset(context.Background(), &BOCData{Observations: []Observations{
+		{D: "2022-05-24", Yield2Year: Val{V: "2.6"}},
+		{D: "2022-05-27", Yield2Year: Val{V: "2.575"}},
+	}}))
+	obs, err = b.GetObservationForDate("2022-05-25", Interpolate())
+	require.NoError(t, err)
+	a.Equal("2.592", obs.Yield2Year.V, "rounded to the larger number of decimals")
+	obs, err = b.GetObservationForDate("2022-05-24", Interpolate())
+	require.NoError(t, err)
+	a.Equal("2.6", obs.Yield2Year.V)
+}
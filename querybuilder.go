@@ -0,0 +1,291 @@
+package boc
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Query builds a selection of series over a range of dates, optionally
+// resampled. It is returned by BOCInterests.Query and run with Run.
+type Query struct {
+	client    *bocInterests
+	series    []string
+	from, to  string
+	freq      Frequency
+	resampled bool
+	policy    ResamplePolicy
+	opts      []QueryOption
+}
+
+// Query implements BOCInterests
+func (b *bocInterests) Query() *Query {
+	return &Query{client: b}
+}
+
+// Series selects the series of the result, all the series of the group by default
+func (q *Query) Series(keys ...string) *Query {
+	q.series = append(q.series, keys...)
+	return q
+}
+
+// From starts the result on date, inclusively
+func (q *Query) From(date string) *Query {
+	q.from = date
+	return q
+}
+
+// To ends the result on date, inclusively
+func (q *Query) To(date string) *Query {
+	q.to = date
+	return q
+}
+
+// Weekly resamples the result to weeks ending on Friday
+func (q *Query) Weekly() *Query {
+	q.freq, q.resampled = Weekly, true
+	return q
+}
+
+// Monthly resamples the result to calendar months
+func (q *Query) Monthly() *Query {
+	q.freq, q.resampled = Monthly, true
+	return q
+}
+
+// Policy sets the value representing a resampled period, Last by default
+func (q *Query) Policy(policy ResamplePolicy) *Query {
+	q.policy = policy
+	return q
+}
+
+// With applies per-call options such as ForwardFill to the lookup
+func (q *Query) With(opts ...QueryOption) *Query {
+	q.opts = append(q.opts, opts...)
+	return q
+}
+
+// Run runs the query
+func (q *Query) Run() (*ResultSet, error) {
+	series := q.series
+	if len(series) == 0 {
+		series = AllSeries()
+	}
+	for _, key := range series {
+		if err := checkSeries(key); err != nil {
+			return nil, err
+		}
+	}
+	rs := &ResultSet{
+		Series:      slices.Clone(series),
+		Units:       make([]string, len(series)),
+		LatestDate:  q.client.LatestDate(),
+		LastUpdated: q.client.LastUpdated(),
+		Attribution: q.client.Attribution(),
+	}
+	for i, key := range series {
+		rs.Units[i], _ = SeriesUnit(key)
+	}
+	if q.resampled {
+		return rs, q.runResampled(rs)
+	}
+	seq, err := q.client.Between(q.from, q.to, q.opts...)
+	if err != nil {
+		return nil, err
+	}
+	for date, obs := range seq {
+		row := Row{Date: date, Values: make([]float64, len(series))}
+		for i, key := range series {
+			if v, ok := obs.Value(key); ok {
+				row.Values[i] = v
+			} else {
+				row.Values[i] = math.NaN()
+			}
+		}
+		rs.Rows = append(rs.Rows, row)
+	}
+	return rs, nil
+}
+
+// runResampled resamples each series on its own and joins them by period
+func (q *Query) runResampled(rs *ResultSet) error {
+	rows := make(map[string][]float64)
+	for i, key := range rs.Series {
+		points, err := seriesBetween(q.client, key, q.from, q.to, q.opts...)
+		if err != nil {
+			return err
+		}
+		for _, p := range resample(points, q.freq, q.policy) {
+			if rows[p.Date] == nil {
+				rows[p.Date] = make([]float64, len(rs.Series))
+				for j := range rows[p.Date] {
+					rows[p.Date][j] = math.NaN()
+				}
+			}
+			rows[p.Date][i] = p.Value
+		}
+	}
+	for date, values := range rows {
+		rs.Rows = append(rs.Rows, Row{Date: date, Values: values})
+	}
+	slices.SortFunc(rs.Rows, func(a, b Row) int { return cmp.Compare(a.Date, b.Date) })
+	return nil
+}
+
+// ResultSet is the result of a Query, a table of dates by series
+type ResultSet struct {
+	// Series are the keys of the columns
+	Series []string
+	// Units are the units of the columns, see SeriesUnit
+	Units []string
+	// Rows are in chronological order
+	Rows []Row
+	// LatestDate is the date of the latest observation of the client and
+	// LastUpdated when the Bank of Canada last updated its data, telling how fresh the result is
+	LatestDate  string
+	LastUpdated time.Time
+	// Attribution is the source citation written with the exports
+	Attribution Attribution
+}
+
+// ColumnLabel returns the label of column i for exports and charts, e.g.
+// "10 year benchmark yield (% per annum)"
+func (rs *ResultSet) ColumnLabel(i int) string {
+	label, ok := SeriesLabel(rs.Series[i])
+	if !ok {
+		label = rs.Series[i]
+	}
+	if i < len(rs.Units) && rs.Units[i] != "" {
+		label += " (" + rs.Units[i] + ")"
+	}
+	return label
+}
+
+// Row holds the values of a date, in the order of ResultSet.Series. Missing values are NaN.
+type Row struct {
+	Date   string
+	Values []float64
+}
+
+// WriteCSV writes the result with a date column then one column per series,
+// leaving the missing values empty. The columns are named by ColumnLabel, with
+// their units. The header is preceded by comment lines starting with "#" with
+// the LatestDate, LastUpdated and Attribution of the result, when known.
+func (rs *ResultSet) WriteCSV(w io.Writer) error {
+	for _, line := range rs.metadata() {
+		if _, err := fmt.Fprintf(w, "# %s: %s\n", line[0], line[1]); err != nil {
+			return err
+		}
+	}
+	cw := csv.NewWriter(w)
+	header := []string{"date"}
+	for i := range rs.Series {
+		header = append(header, rs.ColumnLabel(i))
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	record := make([]string, len(rs.Series)+1)
+	for _, row := range rs.Rows {
+		record[0] = row.Date
+		for i, v := range row.Values {
+			record[i+1] = ""
+			if !math.IsNaN(v) {
+				record[i+1] = strconv.FormatFloat(v, 'f', -1, 64)
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// metadata returns the names and the values of the known metadata of the
+// result, in the order they are exported
+func (rs *ResultSet) metadata() [][2]string {
+	var lines [][2]string
+	if rs.LatestDate != "" {
+		lines = append(lines, [2]string{"latest_date", rs.LatestDate})
+	}
+	if !rs.LastUpdated.IsZero() {
+		lines = append(lines, [2]string{"last_updated", rs.LastUpdated.UTC().Format(time.RFC3339)})
+	}
+	if rs.Attribution.Text != "" {
+		lines = append(lines, [2]string{"attribution", rs.Attribution.String()})
+	}
+	return lines
+}
+
+// WriteJSON writes the result as an object with the "latest_date",
+// "last_updated" and "attribution" of the result, when known, its "columns" with the key, the
+// label and the unit of each series, and its "rows": an array of objects with a
+// "date" key and one key per series, the missing values being null
+func (rs *ResultSet) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(rs)
+}
+
+// resultJSON is the JSON encoding of a ResultSet
+type resultJSON struct {
+	LatestDate  string           `json:"latest_date,omitempty"`
+	LastUpdated string           `json:"last_updated,omitempty"`
+	Attribution *attributionJSON `json:"attribution,omitempty"`
+	Columns     []columnJSON     `json:"columns"`
+	Rows        []map[string]any `json:"rows"`
+}
+
+// attributionJSON is the JSON encoding of an Attribution
+type attributionJSON struct {
+	Text     string `json:"text"`
+	TermsURL string `json:"terms_url,omitempty"`
+}
+
+// columnJSON describes a column of a ResultSet in JSON
+type columnJSON struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	Unit  string `json:"unit,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, see WriteJSON
+func (rs *ResultSet) MarshalJSON() ([]byte, error) {
+	out := resultJSON{LatestDate: rs.LatestDate, Columns: make([]columnJSON, 0, len(rs.Series))}
+	for i, key := range rs.Series {
+		column := columnJSON{Key: key, Label: key}
+		if label, ok := SeriesLabel(key); ok {
+			column.Label = label
+		}
+		if i < len(rs.Units) {
+			column.Unit = rs.Units[i]
+		}
+		out.Columns = append(out.Columns, column)
+	}
+	if !rs.LastUpdated.IsZero() {
+		out.LastUpdated = rs.LastUpdated.UTC().Format(time.RFC3339)
+	}
+	if rs.Attribution.Text != "" {
+		out.Attribution = &attributionJSON{Text: rs.Attribution.Text, TermsURL: rs.Attribution.TermsURL}
+	}
+	rows := make([]map[string]any, 0, len(rs.Rows))
+	for _, row := range rs.Rows {
+		m := make(map[string]any, len(row.Values)+1)
+		m["date"] = row.Date
+		for i, v := range row.Values {
+			if math.IsNaN(v) {
+				m[rs.Series[i]] = nil
+			} else {
+				m[rs.Series[i]] = v
+			}
+		}
+		rows = append(rows, m)
+	}
+	out.Rows = rows
+	return json.Marshal(out)
+}
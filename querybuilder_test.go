@@ -0,0 +1,121 @@
+package boc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryRun(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	rs, err := b.Query().Series(SeriesYield10Year, SeriesYieldRRB).From("2022-05-26").To("2022-05-30").Run()
+	require.NoError(t, err)
+	a.Equal([]string{SeriesYield10Year, SeriesYieldRRB}, rs.Series)
+	a.Equal([]string{UnitPercent, UnitPercent}, rs.Units)
+	a.Equal("10 year benchmark yield (% per annum)", rs.ColumnLabel(0))
+	a.Equal(b.Attribution(), rs.Attribution)
+	a.Len(rs.Rows, 3)
+	a.Equal("2022-05-26", rs.Rows[0].Date)
+	a.Equal("2022-05-27", rs.Rows[1].Date)
+	a.True(math.IsNaN(rs.Rows[1].Values[1]))
+
+	rs, err = b.Query().Run()
+	require.NoError(t, err)
+	a.Equal(AllSeries(), rs.Series)
+	a.Len(rs.Rows, 8)
+
+	rs, err = b.Query().Series(SeriesYield2Year).From("2022-05-27").To("2022-05-30").With(ForwardFill()).Run()
+	require.NoError(t, err)
+	a.Len(rs.Rows, 4, "every calendar day")
+
+	_, err = b.Query().Series("foo").Run()
+	a.ErrorIs(err, ErrUnknownSeries)
+	_, err = b.Query().From("foo").Run()
+	a.ErrorIs(err, ErrInvalidDate)
+}
+
+func TestQueryResampled(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	rs, err := b.Query().Series(SeriesYield2Year, SeriesYieldRRB).Weekly().Run()
+	require.NoError(t, err)
+	a.Equal([]string{"2022-05-20", "2022-05-27", "2022-06-03"}, []string{rs.Rows[0].Date, rs.Rows[1].Date, rs.Rows[2].Date})
+	want, err := Resample(b, SeriesYieldRRB, Weekly, Last)
+	require.NoError(t, err)
+	a.Equal(want[1].Value, rs.Rows[1].Values[1], "the last RRB value of the week")
+
+	rs, err = b.Query().Series(SeriesYield2Year).Monthly().Policy(Mean).Run()
+	require.NoError(t, err)
+	a.Len(rs.Rows, 2)
+	a.Equal("2022-05-31", rs.Rows[0].Date)
+}
+
+func TestResultSetExport(t *testing.T) {
+	a := assert.New(t)
+	rs := &ResultSet{
+		Series: []string{SeriesYield2Year, SeriesYieldRRB},
+		Units:  []string{UnitPercent, UnitPercent},
+		Rows: []Row{
+			{Date: "2022-05-26", Values: []float64{2.55, 0.6}},
+			{Date: "2022-05-27", Values: []float64{2.6, math.NaN()}},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, rs.WriteCSV(buf))
+	a.Equal("date,2 year benchmark yield (% per annum),Real return bond yield (% per annum)\n2022-05-26,2.55,0.6\n2022-05-27,2.6,\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, rs.WriteJSON(buf))
+	a.JSONEq(`{"columns": [
+		{"key": "BD.CDN.2YR.DQ.YLD", "label": "2 year benchmark yield", "unit": "% per annum"},
+		{"key": "BD.CDN.RRB.DQ.YLD", "label": "Real return bond yield", "unit": "% per annum"}
+	], "rows": [
+		{"date": "2022-05-26", "BD.CDN.2YR.DQ.YLD": 2.55, "BD.CDN.RRB.DQ.YLD": 0.6},
+		{"date": "2022-05-27", "BD.CDN.2YR.DQ.YLD": 2.6, "BD.CDN.RRB.DQ.YLD": null}
+	]}`, buf.String())
+
+	rs.LatestDate = "2022-06-01"
+	rs.LastUpdated = time.Date(2022, 6, 1, 20, 30, 0, 0, time.UTC)
+	rs.Attribution = Attribution{Text: "Source: Bank of Canada.", TermsURL: defaultTermsURL}
+	buf.Reset()
+	require.NoError(t, rs.WriteCSV(buf))
+	a.True(strings.HasPrefix(buf.String(), "# latest_date: 2022-06-01\n# last_updated: 2022-06-01T20:30:00Z\n# attribution: Source: Bank of Canada. "+defaultTermsURL+"\ndate,"), buf.String())
+	b := newBOCInterests()
+	b.ds.Store(b.newDataset(context.Background(), &BOCData{}))
+	n, err := b.ImportCSV(buf, "")
+	require.NoError(t, err, "the comments are skipped on import")
+	a.Equal(2, n)
+	obs, err := b.GetObservationForDate("2022-05-26")
+	require.NoError(t, err)
+	a.Equal("2.55", obs.Yield2Year.V, "the columns are read by label")
+	a.Equal("0.6", obs.YieldRRB.V)
+
+	buf.Reset()
+	require.NoError(t, rs.WriteJSON(buf))
+	var exported struct {
+		LatestDate  string    `json:"latest_date"`
+		LastUpdated time.Time `json:"last_updated"`
+		Attribution struct {
+			Text     string `json:"text"`
+			TermsURL string `json:"terms_url"`
+		} `json:"attribution"`
+		Rows []map[string]any `json:"rows"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &exported))
+	a.Equal("2022-06-01", exported.LatestDate)
+	a.True(rs.LastUpdated.Equal(exported.LastUpdated))
+	a.Len(exported.Rows, 2)
+	a.Equal("Source: Bank of Canada.", exported.Attribution.Text)
+	a.Equal(defaultTermsURL, exported.Attribution.TermsURL)
+}
@@ -0,0 +1,98 @@
+package boc
+
+import "math"
+
+// Compounding is the number of times a year a rate is compounded. The zero
+// value is semi-annual compounding, the Canadian bond and mortgage convention,
+// so that a Compounding left unset does not silently mean another convention.
+type Compounding int
+
+const (
+	// CompoundContinuous is continuous compounding
+	CompoundContinuous Compounding = -1
+	// CompoundAnnual is annual compounding
+	CompoundAnnual Compounding = 1
+	// CompoundSemiAnnual is semi-annual compounding, the Canadian bond and mortgage convention
+	CompoundSemiAnnual Compounding = 2
+	// CompoundQuarterly is quarterly compounding
+	CompoundQuarterly Compounding = 4
+	// CompoundMonthly is monthly compounding
+	CompoundMonthly Compounding = 12
+)
+
+// periods returns the number of compounding periods a year, 0 for continuous
+// compounding, which any negative value is
+func (c Compounding) periods() int {
+	switch {
+	case c == 0:
+		return int(CompoundSemiAnnual)
+	case c < 0:
+		return 0
+	}
+	return int(c)
+}
+
+// ConvertRate converts a rate in percent between compounding conventions,
+// keeping the same growth over a year
+func ConvertRate(rate float64, from, to Compounding) float64 {
+	if from.periods() == to.periods() {
+		return rate
+	}
+	return fromContinuous(toContinuous(rate, from), to)
+}
+
+// toContinuous returns the continuously compounded rate, as a decimal, of a rate in percent
+func toContinuous(rate float64, c Compounding) float64 {
+	if c.periods() == 0 {
+		return PercentToDecimal(rate)
+	}
+	n := float64(c.periods())
+	return n * math.Log1p(PercentToDecimal(rate)/n)
+}
+
+// fromContinuous returns the rate in percent of a continuously compounded rate as a decimal
+func fromContinuous(rate float64, c Compounding) float64 {
+	if c.periods() == 0 {
+		return DecimalToPercent(rate)
+	}
+	n := float64(c.periods())
+	return DecimalToPercent(n * math.Expm1(rate/n))
+}
+
+// PercentToDecimal converts a rate in percent, as published by the Bank of Canada, to a decimal
+func PercentToDecimal(percent float64) float64 {
+	return percent / 100
+}
+
+// DecimalToPercent converts a decimal rate to percent
+func DecimalToPercent(decimal float64) float64 {
+	return decimal * 100
+}
+
+// PercentToBps converts a rate in percent to basis points.
+//
+// Deprecated: use ToBps, which drops the floating point noise and returns a Bps.
+func PercentToBps(percent float64) float64 {
+	return float64(ToBps(percent))
+}
+
+// BpsToPercent converts basis points to percent.
+//
+// Deprecated: use FromBps or Bps.Percent.
+func BpsToPercent(bps float64) float64 {
+	return FromBps(Bps(bps))
+}
+
+// DecimalToBps converts a decimal rate to basis points.
+//
+// Deprecated: use ToBps of DecimalToPercent.
+func DecimalToBps(decimal float64) float64 {
+	return float64(ToBps(DecimalToPercent(decimal)))
+}
+
+// BpsToDecimal converts basis points to a decimal rate.
+//
+// Deprecated: use PercentToDecimal of FromBps.
+func BpsToDecimal(bps float64) float64 {
+	return PercentToDecimal(FromBps(Bps(bps)))
+}
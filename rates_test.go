@@ -0,0 +1,46 @@
+package boc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertRate(t *testing.T) {
+	tests := []struct {
+		name string
+		rate float64
+		from Compounding
+		to   Compounding
+		want float64
+	}{
+		{name: "same", rate: 5, from: CompoundSemiAnnual, to: CompoundSemiAnnual, want: 5},
+		{name: "semi-annual to annual", rate: 5, from: CompoundSemiAnnual, to: CompoundAnnual, want: 5.0625},
+		{name: "annual to semi-annual", rate: 5.0625, from: CompoundAnnual, to: CompoundSemiAnnual, want: 5},
+		{name: "semi-annual to continuous", rate: 5, from: CompoundSemiAnnual, to: CompoundContinuous, want: 200 * math.Log(1.025)},
+		{name: "continuous to annual", rate: 5, from: CompoundContinuous, to: CompoundAnnual, want: (math.Exp(0.05) - 1) * 100},
+		{name: "semi-annual to monthly", rate: 5, from: CompoundSemiAnnual, to: CompoundMonthly, want: 12 * (math.Pow(1.025, 1.0/6) - 1) * 100},
+		{name: "zero", rate: 0, from: CompoundQuarterly, to: CompoundContinuous, want: 0},
+		{name: "unset is semi-annual", rate: 5, from: 0, to: CompoundAnnual, want: 5.0625},
+		{name: "to unset", rate: 5.0625, from: CompoundAnnual, to: 0, want: 5},
+		{name: "unset and semi-annual", rate: 5, from: 0, to: CompoundSemiAnnual, want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, ConvertRate(tt.rate, tt.from, tt.to), 1e-12)
+		})
+	}
+}
+
+func TestUnitConversions(t *testing.T) {
+	a := assert.New(t)
+	a.InDelta(0.0257, PercentToDecimal(2.57), 1e-15)
+	a.InDelta(2.57, DecimalToPercent(0.0257), 1e-15)
+	a.InDelta(257, PercentToBps(2.57), 1e-12)
+	a.InDelta(2.57, BpsToPercent(257), 1e-15)
+	a.InDelta(25, DecimalToBps(0.0025), 1e-12)
+	a.InDelta(0.0025, BpsToDecimal(25), 1e-15)
+	a.Equal(float64(ToBps(2.97-2.90)), PercentToBps(2.97-2.90), "the same conversion as ToBps")
+	a.Equal(7.0, PercentToBps(2.97-2.90))
+}
@@ -0,0 +1,89 @@
+package boc
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// RawJSON implements BOCInterests
+func (b *bocInterests) RawJSON() []byte {
+	raw := b.raw.Load()
+	if raw == nil {
+		return nil
+	}
+	return bytes.Clone(*raw)
+}
+
+// RawMap implements BOCInterests
+func (b *bocInterests) RawMap() (map[string]any, error) {
+	raw := b.raw.Load()
+	if raw == nil {
+		return nil, &DataError{Err: ErrNoData}
+	}
+	var m map[string]any
+	if err := json.Unmarshal(*raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// mergeRawJSON merges the payload of an incremental refresh into the payload
+// kept for RawJSON, which stays nil if it was, e.g. when the data was read from storage
+func (b *bocInterests) mergeRawJSON(update []byte) {
+	raw := b.raw.Load()
+	if raw == nil {
+		return
+	}
+	merged, err := mergeRaw(*raw, update)
+	if err != nil {
+		b.logger.Warn("raw payload not merged", "error", err)
+		return
+	}
+	b.raw.Store(&merged)
+}
+
+// mergeRaw returns the payload update with its observations replacing or adding
+// to the observations of the payload data by date, as mergeData does, keeping
+// the fields the types do not model
+func mergeRaw(data, update []byte) ([]byte, error) {
+	var old, merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &old); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(update, &merged); err != nil {
+		return nil, err
+	}
+	var oldObs, newObs []map[string]json.RawMessage
+	if err := json.Unmarshal(old["observations"], &oldObs); len(old["observations"]) > 0 && err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(merged["observations"], &newObs); len(merged["observations"]) > 0 && err != nil {
+		return nil, err
+	}
+	date := func(obs map[string]json.RawMessage) string {
+		var d string
+		json.Unmarshal(obs["d"], &d)
+		return d
+	}
+	updated := make(map[string]bool, len(newObs))
+	for _, obs := range newObs {
+		updated[date(obs)] = true
+	}
+	observations := make([]map[string]json.RawMessage, 0, len(oldObs)+len(newObs))
+	for _, obs := range oldObs {
+		if !updated[date(obs)] {
+			observations = append(observations, obs)
+		}
+	}
+	observations = append(observations, newObs...)
+	sort.SliceStable(observations, func(i, j int) bool {
+		return date(observations[i]) < date(observations[j])
+	})
+	raw, err := json.Marshal(observations)
+	if err != nil {
+		return nil, err
+	}
+	merged["observations"] = raw
+	return json.Marshal(merged)
+}
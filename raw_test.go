@@ -0,0 +1,44 @@
+package boc
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawJSON(t *testing.T) {
+	a := assert.New(t)
+	a.Nil(newBOCInterests().RawJSON())
+	_, err := newBOCInterests().RawMap()
+	a.ErrorIs(err, ErrNoData)
+
+	b := newTestBOC(t)
+	fixture, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	raw := b.RawJSON()
+	a.Equal(fixture, raw)
+	raw[0] = 'x'
+	a.Equal(fixture, b.RawJSON(), "a copy is returned")
+
+	m, err := b.RawMap()
+	require.NoError(t, err)
+	terms, ok := m["terms"].(map[string]any)
+	require.True(t, ok)
+	a.Equal("https://www.bankofcanada.ca/terms/", terms["url"])
+	a.Len(m["observations"], 8)
+}
+
+func TestMergeRaw(t *testing.T) {
+	a := assert.New(t)
+	merged, err := mergeRaw(
+		[]byte(`{"terms":{"url":"old"},"observations":[{"d":"2022-05-26","x":{"v":"1"}},{"d":"2022-05-27","x":{"v":"2"}}]}`),
+		[]byte(`{"terms":{"url":"new"},"extra":true,"observations":[{"d":"2022-05-30","x":{"v":"4"}},{"d":"2022-05-27","x":{"v":"3"}}]}`))
+	require.NoError(t, err)
+	a.JSONEq(`{"terms":{"url":"new"},"extra":true,"observations":[
+		{"d":"2022-05-26","x":{"v":"1"}},{"d":"2022-05-27","x":{"v":"3"}},{"d":"2022-05-30","x":{"v":"4"}}]}`, string(merged))
+
+	_, err = mergeRaw([]byte(`{`), []byte(`{}`))
+	a.Error(err)
+}
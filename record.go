@@ -0,0 +1,66 @@
+package boc
+
+import (
+	"maps"
+	"slices"
+)
+
+// Record is an observation as a date and its values by series key, including
+// the series Observations has no field for, so that it keeps working when the
+// Bank of Canada adds, renames or retires series of the group
+type Record struct {
+	Date string `json:"date"`
+	// Values are the published values by series key, the series without value are left out
+	Values map[string]Val `json:"values"`
+}
+
+// Record returns the observation as a Record
+func (o *Observations) Record() Record {
+	r := Record{Date: o.D, Values: make(map[string]Val, len(allSeries)+len(o.Extra))}
+	for _, key := range allSeries {
+		if v := o.field(key); v.Valid() {
+			r.Values[key] = *v
+		}
+	}
+	for key, v := range o.Extra {
+		if v.Valid() {
+			r.Values[key] = v
+		}
+	}
+	return r
+}
+
+// Keys returns the keys of the series with a value, sorted
+func (r Record) Keys() []string {
+	return slices.Sorted(maps.Keys(r.Values))
+}
+
+// Value returns the value of a series as a float, and false if it has no value
+func (r Record) Value(seriesKey string) (float64, bool) {
+	return r.Values[seriesKey].Float()
+}
+
+// Has reports whether the record has a value for the series key
+func (r Record) Has(seriesKey string) bool {
+	return r.Values[seriesKey].Valid()
+}
+
+// RecordFor implements BOCInterests
+func (b *bocInterests) RecordFor(date string) (Record, bool) {
+	ds := b.current()
+	i, ok := ds.index(date)
+	if !ok {
+		return Record{}, false
+	}
+	return ds.observation(i).Record(), true
+}
+
+// Records implements BOCInterests
+func (b *bocInterests) Records() []Record {
+	ds := b.current()
+	records := make([]Record, 0, len(ds.dates))
+	for i := range ds.dates {
+		records = append(records, ds.observation(i).Record())
+	}
+	return records
+}
@@ -0,0 +1,62 @@
+package boc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecord(t *testing.T) {
+	a := assert.New(t)
+	obs := &Observations{D: "2022-05-24", Yield2Year: Val{V: "2.57"}, Extra: map[string]Val{"A.NEW": {V: "1.5"}, "A.EMPTY": {}}}
+	r := obs.Record()
+	a.Equal("2022-05-24", r.Date)
+	a.Equal([]string{"A.NEW", SeriesYield2Year}, r.Keys())
+	v, ok := r.Value("A.NEW")
+	a.True(ok)
+	a.Equal(1.5, v)
+	_, ok = r.Value(SeriesYield10Year)
+	a.False(ok)
+
+	v, ok = obs.Value("A.NEW")
+	a.True(ok, "Value reads the extra series")
+	a.Equal(1.5, v)
+}
+
+func TestRecords(t *testing.T) {
+	a := assert.New(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"observations": [
+			{"d": "2022-05-25", "BD.CDN.2YR.DQ.YLD": {"v": "2.53"}},
+			{"d": "2022-05-24", "BD.CDN.2YR.DQ.YLD": {"v": "2.57"}, "BD.CDN.1YR.DQ.YLD": {"v": "2.40"}}
+		]}`))
+	}))
+	t.Cleanup(srv.Close)
+	b := newBOCInterests(WithBaseURL(srv.URL))
+	require.NoError(t, b.load(context.Background()))
+
+	records := b.Records()
+	require.Len(t, records, 2)
+	a.Equal(Record{Date: "2022-05-24", Values: map[string]Val{SeriesYield2Year: {V: "2.57"}, "BD.CDN.1YR.DQ.YLD": {V: "2.40"}}}, records[0])
+	a.Equal(Record{Date: "2022-05-25", Values: map[string]Val{SeriesYield2Year: {V: "2.53"}}}, records[1])
+
+	obs, err := b.GetObservationForDate("2022-05-24")
+	require.NoError(t, err)
+	a.Equal(map[string]Val{"BD.CDN.1YR.DQ.YLD": {V: "2.40"}}, obs.Extra)
+	obs.Extra["A.NEW"] = Val{V: "1"}
+	a.Len(b.Records()[0].Values, 2, "the data in use is not modified")
+}
+
+func TestRecordFor(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	record, ok := b.RecordFor("2022-05-25")
+	a.True(ok)
+	a.Equal(b.Records()[2], record)
+	_, ok = b.RecordFor("2022-05-23")
+	a.False(ok)
+}
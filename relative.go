@@ -0,0 +1,122 @@
+package boc
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// relativeOffset matches offsets from today like "-7d", "+2w", "-1m", "-1y" or
+// "-3bd" for business days
+var relativeOffset = regexp.MustCompile(`^([+-]\d+) ?(d|w|m|y|bd)$`)
+
+// maxRelativeOffset is the largest offset of a relative date by unit, about ten
+// years, so that an expression from a request cannot make the client walk the
+// calendar for long
+var maxRelativeOffset = map[string]int{"d": 3660, "w": 522, "m": 120, "y": 10, "bd": 2610}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// RelativeDate resolves a relative date expression against the current date in
+// Toronto at now, see TorontoDay, and the business days of the Bank of Canada:
+// "today", "yesterday", "last business day", "last friday" (the latest Friday
+// before today), or an offset from today like "-7d", "-2w", "-1m", "-1y" or
+// "-3bd" in business days. Offsets are limited to about ten years. It returns
+// false if expr is not relative, and an error if its offset is too large.
+func RelativeDate(expr string, now time.Time) (time.Time, bool, error) {
+	expr = strings.ToLower(strings.Join(strings.Fields(expr), " "))
+	today := TorontoDay(now)
+	switch expr {
+	case "today":
+		return today, true, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true, nil
+	case "last business day", "previous business day":
+		return PreviousBusinessDay(today), true, nil
+	}
+	if name, ok := strings.CutPrefix(expr, "last "); ok {
+		if wd, ok := weekdays[name]; ok {
+			day := today.AddDate(0, 0, -1)
+			for day.Weekday() != wd {
+				day = day.AddDate(0, 0, -1)
+			}
+			return day, true, nil
+		}
+	}
+	m := relativeOffset.FindStringSubmatch(expr)
+	if m == nil {
+		return time.Time{}, false, nil
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil || n < -maxRelativeOffset[m[2]] || n > maxRelativeOffset[m[2]] {
+		return time.Time{}, true, fmt.Errorf("offset %s is larger than %d%s", m[1], maxRelativeOffset[m[2]], m[2])
+	}
+	switch m[2] {
+	case "d":
+		return today.AddDate(0, 0, n), true, nil
+	case "w":
+		return today.AddDate(0, 0, 7*n), true, nil
+	case "m":
+		return addMonths(today, n), true, nil
+	case "y":
+		return addMonths(today, 12*n), true, nil
+	}
+	return addBusinessDays(today, n), true, nil
+}
+
+// addBusinessDays adds n business days to a day. It jumps whole weeks first,
+// which have five weekdays less their holidays, and walks the remaining days.
+func addBusinessDays(day time.Time, n int) time.Time {
+	step, remaining := 1, n
+	if n < 0 {
+		step, remaining = -1, -n
+	}
+	// at least one day is left to walk so that the result is a business day
+	for remaining > 5 {
+		weeks := (remaining - 1) / 5
+		next := day.AddDate(0, 0, 7*weeks*step)
+		// the jumped days are after day up to next, or from next up to before day
+		from, to := day.AddDate(0, 0, 1), next.AddDate(0, 0, 1)
+		if step < 0 {
+			from, to = next, day
+		}
+		remaining -= 5*weeks - weekdayHolidays(from, to)
+		day = next
+	}
+	for ; remaining > 0; remaining-- {
+		if step < 0 {
+			day = PreviousBusinessDay(day)
+		} else {
+			day = NextBusinessDay(day)
+		}
+	}
+	return day
+}
+
+// weekdayHolidays returns the number of holidays on weekdays from the day from
+// to before the day to
+func weekdayHolidays(from, to time.Time) int {
+	n := 0
+	for year := from.Year(); year <= to.Year(); year++ {
+		for _, h := range Holidays(year) {
+			wd := h.Date.Weekday()
+			if !h.Date.Before(from) && h.Date.Before(to) && wd != time.Saturday && wd != time.Sunday {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// addMonths adds n months to a day, clamping the day to the end of the
+// month, e.g. one month before March 31 is the last day of February
+func addMonths(day time.Time, n int) time.Time {
+	first := date(day.Year(), day.Month(), 1).AddDate(0, n, 0)
+	last := first.AddDate(0, 1, -1).Day()
+	return date(first.Year(), first.Month(), min(day.Day(), last))
+}
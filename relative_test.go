@@ -0,0 +1,109 @@
+package boc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelativeDate(t *testing.T) {
+	// Wednesday June 1 2022 at 22:00 in Toronto
+	now := time.Date(2022, 6, 2, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{expr: "today", want: "2022-06-01"},
+		{expr: " Yesterday ", want: "2022-05-31"},
+		{expr: "last business day", want: "2022-05-31"},
+		{expr: "last friday", want: "2022-05-27"},
+		{expr: "last Wednesday", want: "2022-05-25"},
+		{expr: "-7d", want: "2022-05-25"},
+		{expr: "+1d", want: "2022-06-02"},
+		{expr: "-2w", want: "2022-05-18"},
+		{expr: "-1m", want: "2022-05-01"},
+		{expr: "-1y", want: "2021-06-01"},
+		{expr: "-6bd", want: "2022-05-24"},
+		{expr: "+3bd", want: "2022-06-06"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			day, ok, err := RelativeDate(tt.expr, now)
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, day.Format(time.DateOnly))
+		})
+	}
+
+	for _, expr := range []string{"2022-06-01", "last week", "-7", "7d", "-1q"} {
+		_, ok, err := RelativeDate(expr, now)
+		assert.NoError(t, err)
+		assert.False(t, ok, expr)
+	}
+
+	for _, expr := range []string{"-200000bd", "+3661d", "-523w", "-121m", "+11y", "-99999999999999999999d"} {
+		_, ok, err := RelativeDate(expr, now)
+		assert.True(t, ok, expr)
+		assert.ErrorContains(t, err, "is larger than", expr)
+	}
+	_, ok, err := RelativeDate("-2610bd", now)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	walk := func(day time.Time, n int) time.Time {
+		for ; n < 0; n++ {
+			day = PreviousBusinessDay(day)
+		}
+		for ; n > 0; n-- {
+			day = NextBusinessDay(day)
+		}
+		return day
+	}
+	// the starts include a weekend, a holiday and the days around Christmas
+	starts := []time.Time{date(2022, 6, 1), date(2022, 6, 4), date(2022, 5, 23), date(2021, 12, 24), date(2021, 12, 28)}
+	for _, start := range starts {
+		for _, n := range []int{-400, -37, -11, -6, -5, -1, 0, 1, 5, 6, 12, 64, 400} {
+			assert.Equal(t, walk(start, n), addBusinessDays(start, n), "%s %+d", start.Format(time.DateOnly), n)
+		}
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	assert.Equal(t, date(2022, 2, 28), addMonths(date(2022, 3, 31), -1))
+	assert.Equal(t, date(2024, 2, 29), addMonths(date(2024, 1, 31), 1))
+	assert.Equal(t, date(2023, 1, 15), addMonths(date(2022, 12, 15), 1))
+}
+
+func TestRelativeLookups(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	b.now = func() time.Time { return time.Date(2022, 6, 1, 21, 0, 0, 0, time.UTC) }
+
+	obs, err := b.GetObservationForDate("last friday")
+	require.NoError(t, err)
+	a.Equal("2022-05-27", obs.D)
+	v, err := b.SeriesValue("yesterday", SeriesYield2Year)
+	require.NoError(t, err)
+	a.Equal(2.68, v)
+
+	seq, err := b.Between("-2bd", "today")
+	require.NoError(t, err)
+	var dates []string
+	for date := range seq {
+		dates = append(dates, date)
+	}
+	a.Equal([]string{"2022-05-30", "2022-05-31", "2022-06-01"}, dates)
+}
+
+func TestRelativeDateTooLarge(t *testing.T) {
+	b := newTestBOC(t)
+	_, err := b.GetObservationForDate("-200000bd")
+	var dataErr *DataError
+	require.ErrorAs(t, err, &dataErr)
+	assert.ErrorIs(t, err, ErrInvalidDate)
+	assert.Equal(t, "-200000bd", dataErr.Date)
+}
@@ -0,0 +1,150 @@
+package report
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"text/template"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// Spread is the difference between the latest values of two series
+type Spread struct {
+	// Name names the spread, e.g. "10y-2y"
+	Name  string
+	Long  string
+	Short string
+	// Value is Long minus Short in basis points
+	Value float64
+	// Change is the daily change of the spread in basis points, NaN if unknown
+	Change float64
+}
+
+// DefaultSpreads are the spreads of the report data: the slopes of the curve
+// and the long-term breakeven inflation
+var DefaultSpreads = []Spread{
+	{Name: "10y-2y", Long: boc.SeriesYield10Year, Short: boc.SeriesYield2Year},
+	{Name: "5y-2y", Long: boc.SeriesYield5Year, Short: boc.SeriesYield2Year},
+	{Name: "30y-10y", Long: boc.SeriesYieldLong, Short: boc.SeriesYield10Year},
+	{Name: "breakeven", Long: boc.SeriesYieldLong, Short: boc.SeriesYieldRRB},
+}
+
+// WithSpread adds a spread of long minus short to the report data
+func WithSpread(name, long, short string) Option {
+	return func(o *options) {
+		o.spreads = append(o.spreads, Spread{Name: name, Long: long, Short: short})
+	}
+}
+
+// Data is the context the report templates are executed with
+type Data struct {
+	// Date is the date of the latest observation
+	Date string
+	// Rows are the latest value of each series, in percent, and its daily change
+	// in basis points, in the order of boc.AllSeries
+	Rows []boc.SummaryRow
+	// Curve is the benchmark yield curve of Date
+	Curve *boc.Curve
+	// Spreads are DefaultSpreads and the spreads added with WithSpread, skipping
+	// those with a series without value
+	Spreads []Spread
+	// Moves are the rows changing by MoveThreshold basis points or more
+	Moves         []boc.SummaryRow
+	MoveThreshold float64
+	// Alerts are the alerts of the summary and those added with WithAlerts
+	Alerts      []boc.AlertEvent
+	Attribution boc.Attribution
+}
+
+// Row returns the row of a series, nil if the series has no value
+func (d *Data) Row(key string) *boc.SummaryRow {
+	for i := range d.Rows {
+		if d.Rows[i].Series == key {
+			return &d.Rows[i]
+		}
+	}
+	return nil
+}
+
+// Value returns the latest value of a series in percent, NaN if it has no value
+func (d *Data) Value(key string) float64 {
+	if row := d.Row(key); row != nil {
+		return row.Value
+	}
+	return math.NaN()
+}
+
+// NewData returns the context of the report templates for the latest observation of src
+func NewData(src Source, opts ...Option) (*Data, error) {
+	o := newOptions(opts)
+	summary, err := boc.Summarize(src)
+	if err != nil {
+		return nil, err
+	}
+	curve, err := boc.YieldCurve(src, summary.Date)
+	if err != nil {
+		return nil, err
+	}
+	d := &Data{
+		Date:          summary.Date,
+		Rows:          summary.Rows,
+		Curve:         curve,
+		Moves:         notableMoves(summary.Rows, o.moveThreshold),
+		MoveThreshold: o.moveThreshold,
+		Alerts:        append(append([]boc.AlertEvent(nil), summary.Alerts...), o.alerts...),
+		Attribution:   summary.Attribution,
+	}
+	for _, spread := range append(append([]Spread(nil), DefaultSpreads...), o.spreads...) {
+		long, short := d.Row(spread.Long), d.Row(spread.Short)
+		if long == nil || short == nil {
+			continue
+		}
+		spread.Value = float64(boc.ToBps(long.Value - short.Value).Round(2))
+		spread.Change = long.Change - short.Change
+		d.Spreads = append(d.Spreads, spread)
+	}
+	return d, nil
+}
+
+// Funcs are the functions available to the report templates:
+//   - percent formats a value in percent with two decimals, e.g. "2.97%"
+//   - change formats a change in basis points, e.g. "+7 bps", see boc.FormatChange
+//   - label returns the label of a series key, see boc.SeriesLabel
+//   - tenor formats a maturity in years, e.g. "10Y"
+var Funcs = map[string]any{
+	"percent": func(v float64) string { return fmt.Sprintf("%.2f%%", v) },
+	"change":  boc.FormatChange,
+	"label": func(key string) string {
+		label, _ := boc.SeriesLabel(key)
+		return label
+	},
+	"tenor": func(years float64) string { return fmt.Sprintf("%gY", years) },
+}
+
+// Text executes a text/template with the Data of src and Funcs
+func Text(w io.Writer, src Source, tmpl string, opts ...Option) error {
+	t, err := template.New("report").Funcs(Funcs).Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("error parsing the report template: %w", err)
+	}
+	d, err := NewData(src, opts...)
+	if err != nil {
+		return err
+	}
+	return t.Execute(w, d)
+}
+
+// HTML executes an html/template with the Data of src and Funcs
+func HTML(w io.Writer, src Source, tmpl string, opts ...Option) error {
+	t, err := htmltemplate.New("report").Funcs(Funcs).Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("error parsing the report template: %w", err)
+	}
+	d, err := NewData(src, opts...)
+	if err != nil {
+		return err
+	}
+	return t.Execute(w, d)
+}
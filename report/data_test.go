@@ -0,0 +1,58 @@
+package report
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+	"github.com/clauderoy790/bank-of-canada-interests-rates/internal/testutil"
+)
+
+func TestNewData(t *testing.T) {
+	a := assert.New(t)
+	d, err := NewData(testutil.NewClient(t), WithSpread("10y-5y", boc.SeriesYield10Year, boc.SeriesYield5Year),
+		WithSpread("foo", "foo", boc.SeriesYield2Year))
+	require.NoError(t, err)
+
+	a.Equal("2022-06-01", d.Date)
+	a.Equal(2.97, d.Value(boc.SeriesYield10Year))
+	a.True(math.IsNaN(d.Value("foo")))
+	a.Equal(7.0, d.Row(boc.SeriesYield10Year).Change)
+	a.Nil(d.Row("foo"))
+	a.Len(d.Curve.Tenors, 6)
+	a.Len(d.Moves, 11)
+
+	require.Len(t, d.Spreads, 5, "the spread of an unknown series is skipped")
+	a.Equal(Spread{Name: "10y-2y", Long: boc.SeriesYield10Year, Short: boc.SeriesYield2Year, Value: 24, Change: 2}, d.Spreads[0])
+	a.Equal("breakeven", d.Spreads[3].Name)
+	a.Equal(231.0, d.Spreads[3].Value)
+	a.Equal("10y-5y", d.Spreads[4].Name)
+	a.Equal(12.0, d.Spreads[4].Value)
+}
+
+func TestText(t *testing.T) {
+	a := assert.New(t)
+	var sb strings.Builder
+	require.NoError(t, Text(&sb, testutil.NewClient(t),
+		`{{.Date}}: {{label "BD.CDN.10YR.DQ.YLD"}} {{percent (.Value "BD.CDN.10YR.DQ.YLD")}}`+
+			`{{range .Spreads}} {{.Name}}={{.Value}}{{end}}{{with index .Curve.Tenors 0}} {{tenor .}}{{end}}`+
+			` {{change (.Row "BD.CDN.2YR.DQ.YLD").Change}}`))
+	a.Equal("2022-06-01: 10 year benchmark yield 2.97% 10y-2y=24 5y-2y=12 30y-10y=6 breakeven=231 2Y +5 bps", sb.String())
+
+	a.ErrorContains(Text(&sb, testutil.NewClient(t), "{{.Foo"), "parsing")
+	a.Error(Text(&sb, testutil.NewClient(t), "{{.Foo}}"))
+}
+
+func TestHTML(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, HTML(&sb, testutil.NewClient(t), `<p title="{{.Date}}">{{.Attribution.Text}} <a href="{{.Attribution.TermsURL}}">terms</a></p>`))
+	assert.Equal(t, `<p title="2022-06-01">Source : Banque du Canada. <a href="https://www.bankofcanada.ca/terms/">terms</a></p>`, sb.String())
+
+	sb.Reset()
+	require.NoError(t, HTML(&sb, testutil.NewClient(t), `{{range .Alerts}}{{.Name}}{{end}}`, WithAlerts(boc.AlertEvent{Name: "<b>"})))
+	assert.Equal(t, "&lt;b&gt;", sb.String())
+}
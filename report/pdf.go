@@ -0,0 +1,227 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/template"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// DefaultPDFTitle is the template of the title of the PDF report, executed with the *Data
+const DefaultPDFTitle = "Government of Canada bond yields on {{.Date}}"
+
+// DefaultPDFFooter is the template of the footer of the PDF report, executed with the *Data
+const DefaultPDFFooter = "{{.Attribution}}"
+
+// page size of the PDF report, US Letter in points
+const (
+	pageWidth  = 612
+	pageHeight = 792
+	margin     = 54
+)
+
+// WithPDFTitle sets the text/template of the title of the PDF report, executed with the *Data
+func WithPDFTitle(tmpl string) Option {
+	return func(o *options) {
+		o.pdfTitle = tmpl
+	}
+}
+
+// WithPDFFooter sets the text/template of the footer of the PDF report, executed with the *Data
+func WithPDFFooter(tmpl string) Option {
+	return func(o *options) {
+		o.pdfFooter = tmpl
+	}
+}
+
+// PDF writes a one page PDF report of the latest observation: the title, a
+// table of the yields and their daily changes, a chart of the benchmark curve
+// and the footer. The page only uses the standard Helvetica fonts, so that no
+// font is embedded.
+func PDF(w io.Writer, src Source, opts ...Option) error {
+	o := newOptions(opts)
+	d, err := NewData(src, opts...)
+	if err != nil {
+		return err
+	}
+	title, err := execute("title", o.pdfTitle, d)
+	if err != nil {
+		return err
+	}
+	footer, err := execute("footer", o.pdfFooter, d)
+	if err != nil {
+		return err
+	}
+
+	var c content
+	y := float64(pageHeight - margin - 18)
+	c.text("F2", 18, margin, y, title)
+
+	y -= 36
+	c.text("F2", 10, margin, y, "Series")
+	c.textRight("F2", 10, 400, y, "Yield")
+	c.textRight("F2", 10, pageWidth-margin, y, "Daily change")
+	c.line(margin, y-4, pageWidth-margin, y-4)
+	for _, row := range d.Rows {
+		y -= 16
+		c.text("F1", 10, margin, y, row.Label)
+		c.textRight("F1", 10, 400, y, fmt.Sprintf("%.2f%%", row.Value))
+		c.textRight("F1", 10, pageWidth-margin, y, boc.FormatChange(row.Change))
+	}
+
+	y -= 40
+	c.text("F2", 12, margin, y, "Benchmark curve")
+	c.curve(d.Curve, margin+30, 90, pageWidth-margin, y-20)
+
+	c.text("F1", 8, margin, margin-18, footer)
+	return writePDF(w, c.Bytes())
+}
+
+// execute executes a text/template with data and Funcs
+func execute(name, text string, data any) (string, error) {
+	tmpl, err := template.New(name).Funcs(Funcs).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("error parsing the %s template: %w", name, err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("error executing the %s template: %w", name, err)
+	}
+	return sb.String(), nil
+}
+
+// content is a PDF content stream
+type content struct {
+	bytes.Buffer
+}
+
+// text writes s with its baseline starting at x, y
+func (c *content) text(font string, size, x, y float64, s string) {
+	fmt.Fprintf(c, "BT /%s %g Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escapePDF(s))
+}
+
+// textRight writes s with its baseline ending at x, y
+func (c *content) textRight(font string, size, x, y float64, s string) {
+	c.text(font, size, x-textWidth(font, size, s), y, s)
+}
+
+func (c *content) line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(c, "%.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+// curve draws the yields of a curve by tenor in the box from left, bottom to right, top
+func (c *content) curve(curve *boc.Curve, left, bottom, right, top float64) {
+	if len(curve.Tenors) == 0 {
+		return
+	}
+	low, high := curve.Yields[0], curve.Yields[0]
+	for _, y := range curve.Yields {
+		low, high = math.Min(low, y), math.Max(high, y)
+	}
+	// a quarter of a percent of padding, rounded to the quarter
+	low, high = math.Floor(low*4)/4-0.25, math.Ceil(high*4)/4+0.25
+	maxTenor := curve.Tenors[len(curve.Tenors)-1]
+	px := func(years float64) float64 { return left + years/maxTenor*(right-left) }
+	py := func(yield float64) float64 { return bottom + (yield-low)/(high-low)*(top-bottom) }
+
+	c.WriteString("0.5 w\n")
+	c.line(left, bottom, right, bottom)
+	c.line(left, bottom, left, top)
+	for _, yield := range []float64{low, (low + high) / 2, high} {
+		c.textRight("F1", 8, left-4, py(yield)-3, fmt.Sprintf("%.2f%%", yield))
+	}
+	for _, years := range curve.Tenors {
+		label := fmt.Sprintf("%gY", years)
+		c.text("F1", 8, px(years)-textWidth("F1", 8, label)/2, bottom-12, label)
+	}
+	c.WriteString("1.5 w 0 0.3 0.6 RG 0 0.3 0.6 rg\n")
+	for i, years := range curve.Tenors {
+		op := "l"
+		if i == 0 {
+			op = "m"
+		}
+		fmt.Fprintf(c, "%.2f %.2f %s ", px(years), py(curve.Yields[i]), op)
+	}
+	c.WriteString("S\n")
+	for i, years := range curve.Tenors {
+		fmt.Fprintf(c, "%.2f %.2f 4 4 re f\n", px(years)-2, py(curve.Yields[i])-2)
+	}
+	c.WriteString("0 0 0 RG 0 0 0 rg 1 w\n")
+}
+
+// textWidth approximates the width in points of s in a Helvetica font
+func textWidth(font string, size float64, s string) float64 {
+	em := 0.0
+	for _, r := range s {
+		switch {
+		case r == ' ' || r == '.' || r == ',' || r == ':' || r == 'i' || r == 'l':
+			em += 0.278
+		case r >= '0' && r <= '9', r == '+', r == '-':
+			em += 0.556
+		case r == '%':
+			em += 0.889
+		case r >= 'A' && r <= 'Z':
+			em += 0.667
+		default:
+			em += 0.556
+		}
+	}
+	if font == "F2" {
+		em *= 1.05
+	}
+	return em * size
+}
+
+// escapePDF returns s as the content of a PDF string encoded in WinAnsiEncoding,
+// replacing the characters it cannot encode
+func escapePDF(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r < 0x20:
+			sb.WriteByte(' ')
+		case r < 0x80:
+			sb.WriteRune(r)
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&sb, "\\%03o", r)
+		default:
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
+
+// writePDF writes a PDF document of one page with the content stream
+func writePDF(w io.Writer, stream []byte) error {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>", pageWidth, pageHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(stream), stream),
+	}
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
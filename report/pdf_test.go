@@ -0,0 +1,54 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clauderoy790/bank-of-canada-interests-rates/internal/testutil"
+)
+
+func TestPDF(t *testing.T) {
+	a := assert.New(t)
+	var buf bytes.Buffer
+	require.NoError(t, PDF(&buf, testutil.NewClient(t)))
+	doc := buf.Bytes()
+
+	a.True(bytes.HasPrefix(doc, []byte("%PDF-1.4\n")))
+	a.True(bytes.HasSuffix(doc, []byte("%%EOF\n")))
+	a.Contains(buf.String(), "(Government of Canada bond yields on 2022-06-01) Tj")
+	a.Contains(buf.String(), "(10 year benchmark yield) Tj")
+	a.Contains(buf.String(), "(+7 bps) Tj")
+	a.Contains(buf.String(), "(30Y) Tj")
+	a.Contains(buf.String(), "(Source : Banque du Canada. https://www.bankofcanada.ca/terms/) Tj")
+
+	// the cross-reference table points to the objects
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(doc)
+	require.NotNil(t, m)
+	xref, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	a.True(bytes.HasPrefix(doc[xref:], []byte("xref\n0 7\n")))
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(doc[xref:], -1)
+	require.Len(t, offsets, 6)
+	for i, offset := range offsets {
+		n, err := strconv.Atoi(string(offset[1]))
+		require.NoError(t, err)
+		a.True(bytes.HasPrefix(doc[n:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+}
+
+func TestPDFTemplates(t *testing.T) {
+	a := assert.New(t)
+	var buf bytes.Buffer
+	require.NoError(t, PDF(&buf, testutil.NewClient(t), WithPDFTitle("Rates (daily) {{.Date}}"), WithPDFFooter("Désk")))
+	a.Contains(buf.String(), `(Rates \(daily\) 2022-06-01) Tj`)
+	a.Contains(buf.String(), `(D\351sk) Tj`)
+
+	a.ErrorContains(PDF(&buf, testutil.NewClient(t), WithPDFTitle("{{.Foo")), "title template")
+	a.ErrorContains(PDF(&buf, testutil.NewClient(t), WithPDFFooter("{{.Foo}}")), "footer template")
+}
@@ -0,0 +1,116 @@
+// Package report renders reports of the latest bond yields, e.g. a Markdown
+// summary to paste into wikis, issues or chat, or a PDF daily rates sheet.
+package report
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// DefaultMoveThreshold is the smallest daily change, in basis points, reported as a notable move
+const DefaultMoveThreshold = 5
+
+// Source is the part of the client read by the reports
+type Source interface {
+	boc.ObservationReader
+	boc.MetadataReader
+}
+
+// Option configures a report
+type Option func(*options)
+
+type options struct {
+	moveThreshold float64
+	alerts        []boc.AlertEvent
+	pdfTitle      string
+	pdfFooter     string
+	spreads       []Spread
+}
+
+// WithMoveThreshold sets the smallest daily change, in basis points, reported as a notable move
+func WithMoveThreshold(bps float64) Option {
+	return func(o *options) {
+		o.moveThreshold = bps
+	}
+}
+
+// WithAlerts adds alerts to the report, e.g. drained from a notify.Collector
+func WithAlerts(events ...boc.AlertEvent) Option {
+	return func(o *options) {
+		o.alerts = append(o.alerts, events...)
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{moveThreshold: DefaultMoveThreshold, pdfTitle: DefaultPDFTitle, pdfFooter: DefaultPDFFooter}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Markdown writes a Markdown report of the latest observation: a table of the
+// yields and their daily changes, the benchmark curve, the notable moves, the
+// alerts and the attribution.
+func Markdown(w io.Writer, src Source, opts ...Option) error {
+	d, err := NewData(src, opts...)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Bond yields on %s\n\n", d.Date)
+	sb.WriteString("| Series | Yield | Daily change |\n|---|---:|---:|\n")
+	for _, row := range d.Rows {
+		fmt.Fprintf(&sb, "| %s | %.2f%% | %s |\n", escape(row.Label), row.Value, boc.FormatChange(row.Change))
+	}
+
+	sb.WriteString("\n## Curve\n\n| Tenor | Yield |\n|---|---:|\n")
+	for i, years := range d.Curve.Tenors {
+		fmt.Fprintf(&sb, "| %gY | %.2f%% |\n", years, d.Curve.Yields[i])
+	}
+
+	sb.WriteString("\n## Notable moves\n\n")
+	if len(d.Moves) == 0 {
+		fmt.Fprintf(&sb, "No move of %g bps or more.\n", d.MoveThreshold)
+	}
+	for _, row := range d.Moves {
+		direction := "up"
+		if row.Change < 0 {
+			direction = "down"
+		}
+		fmt.Fprintf(&sb, "- %s %s %g bps to %.2f%%\n", escape(row.Label), direction, math.Abs(row.Change), row.Value)
+	}
+
+	if len(d.Alerts) > 0 {
+		sb.WriteString("\n## Alerts\n\n")
+		for _, event := range d.Alerts {
+			fmt.Fprintf(&sb, "- %s on %s\n", escape(event.Name), event.Date)
+		}
+	}
+	if a := d.Attribution; a.Text != "" {
+		fmt.Fprintf(&sb, "\n_%s_ <%s>\n", a.Text, a.TermsURL)
+	}
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+// notableMoves returns the rows changing by threshold basis points or more
+func notableMoves(rows []boc.SummaryRow, threshold float64) []boc.SummaryRow {
+	moves := make([]boc.SummaryRow, 0)
+	for _, row := range rows {
+		if !math.IsNaN(row.Change) && math.Abs(row.Change) >= threshold {
+			moves = append(moves, row)
+		}
+	}
+	return moves
+}
+
+// escape escapes the characters of s with a meaning in a Markdown table
+func escape(s string) string {
+	return strings.NewReplacer(`|`, `\|`, `*`, `\*`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,33 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+	"github.com/clauderoy790/bank-of-canada-interests-rates/internal/testutil"
+)
+
+func TestMarkdown(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, Markdown(&sb, testutil.NewClient(t), WithMoveThreshold(7),
+		WithAlerts(boc.AlertEvent{Name: "10y_above_2.9", Date: "2022-06-01"})))
+	md := sb.String()
+	a := assert.New(t)
+	a.True(strings.HasPrefix(md, "# Bond yields on 2022-06-01\n\n| Series | Yield | Daily change |\n|---|---:|---:|\n"))
+	a.Contains(md, "| 10 year benchmark yield | 2.97% | +7 bps |\n")
+	a.Contains(md, "## Curve\n\n| Tenor | Yield |\n|---|---:|\n| 2Y | 2.73% |\n")
+	a.Contains(md, "| 30Y | 3.03% |\n")
+	a.Contains(md, "## Notable moves\n\n- 5 to 10 year average yield up 7 bps to 2.93%\n")
+	a.NotContains(md, "2 year benchmark yield up", "below the threshold")
+	a.Contains(md, "## Alerts\n\n- 10y\\_above\\_2.9 on 2022-06-01\n")
+	a.True(strings.HasSuffix(md, "\n_Source : Banque du Canada._ <https://www.bankofcanada.ca/terms/>\n"))
+
+	sb.Reset()
+	require.NoError(t, Markdown(&sb, testutil.NewClient(t), WithMoveThreshold(10)))
+	a.Contains(sb.String(), "## Notable moves\n\nNo move of 10 bps or more.\n")
+	a.NotContains(sb.String(), "## Alerts")
+}
@@ -0,0 +1,97 @@
+package boc
+
+import "time"
+
+// Frequency is the period used by Resample and SnapshotAt
+type Frequency int
+
+const (
+	// Weekly groups the observations by weeks ending on Friday
+	Weekly Frequency = iota
+	// Monthly groups the observations by calendar month
+	Monthly
+	// Quarterly groups the observations by calendar quarter
+	Quarterly
+	// Yearly groups the observations by calendar year
+	Yearly
+)
+
+// ResamplePolicy picks the value representing a period
+type ResamplePolicy int
+
+const (
+	// Last keeps the last value of the period, e.g. the Friday close
+	Last ResamplePolicy = iota
+	// First keeps the first value of the period
+	First
+	// Mean averages the values of the period
+	Mean
+)
+
+// Resample returns a series of client resampled to a weekly, monthly, quarterly or yearly frequency
+func Resample(client ObservationReader, seriesKey string, freq Frequency, policy ResamplePolicy) ([]Point, error) {
+	points, err := seriesBetween(client, seriesKey, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return resample(points, freq, policy), nil
+}
+
+// resample groups chronological points by period. Each resulting point is dated
+// with the end of its period: the Friday of the week or the last day of the month,
+// of the quarter or of the year.
+func resample(points []Point, freq Frequency, policy ResamplePolicy) []Point {
+	resampled := make([]Point, 0)
+	var values []float64
+	period := ""
+	flush := func() {
+		if len(values) > 0 {
+			resampled = append(resampled, Point{Date: period, Value: pick(values, policy)})
+		}
+		values = values[:0]
+	}
+	for _, p := range points {
+		end := periodEnd(p.Date, freq)
+		if end != period {
+			flush()
+			period = end
+		}
+		values = append(values, p.Value)
+	}
+	flush()
+	return resampled
+}
+
+// periodEnd returns the last day of the period containing date
+func periodEnd(date string, freq Frequency) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	switch freq {
+	case Monthly:
+		t = time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	case Quarterly:
+		t = time.Date(t.Year(), (t.Month()-1)/3*3+4, 0, 0, 0, 0, 0, time.UTC)
+	case Yearly:
+		t = time.Date(t.Year(), time.December, 31, 0, 0, 0, 0, time.UTC)
+	default:
+		t = t.AddDate(0, 0, (int(time.Friday)-int(t.Weekday())+7)%7)
+	}
+	return t.Format("2006-01-02")
+}
+
+func pick(values []float64, policy ResamplePolicy) float64 {
+	switch policy {
+	case First:
+		return values[0]
+	case Mean:
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	default:
+		return values[len(values)-1]
+	}
+}
@@ -0,0 +1,107 @@
+package boc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResample(t *testing.T) {
+	b := newTestBOC(t)
+	tests := []struct {
+		name   string
+		series string
+		freq   Frequency
+		policy ResamplePolicy
+		want   []Point
+	}{
+		{
+			name:   "weekly close",
+			series: SeriesYield2Year,
+			freq:   Weekly,
+			policy: Last,
+			want: []Point{
+				{Date: "2022-05-20", Value: 2.59},
+				{Date: "2022-05-27", Value: 2.61},
+				{Date: "2022-06-03", Value: 2.73},
+			},
+		},
+		{
+			name:   "weekly first",
+			series: SeriesYield2Year,
+			freq:   Weekly,
+			policy: First,
+			want: []Point{
+				{Date: "2022-05-20", Value: 2.59},
+				{Date: "2022-05-27", Value: 2.57},
+				{Date: "2022-06-03", Value: 2.65},
+			},
+		},
+		{
+			name:   "weekly close skips missing values",
+			series: SeriesYieldRRB,
+			freq:   Weekly,
+			policy: Last,
+			want: []Point{
+				{Date: "2022-05-20", Value: 0.61},
+				{Date: "2022-05-27", Value: 0.57},
+				{Date: "2022-06-03", Value: 0.72},
+			},
+		},
+		{
+			name:   "monthly close",
+			series: SeriesYield10Year,
+			freq:   Monthly,
+			policy: Last,
+			want: []Point{
+				{Date: "2022-05-31", Value: 2.90},
+				{Date: "2022-06-30", Value: 2.97},
+			},
+		},
+		{
+			name:   "monthly mean",
+			series: SeriesYield2Year,
+			freq:   Monthly,
+			policy: Mean,
+			want: []Point{
+				{Date: "2022-05-31", Value: (2.59 + 2.57 + 2.53 + 2.55 + 2.61 + 2.65 + 2.68) / 7},
+				{Date: "2022-06-30", Value: 2.73},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resample(b, tt.series, tt.freq, tt.policy)
+			assert.NoError(t, err)
+			assert.Equal(t, len(tt.want), len(got))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].Date, got[i].Date)
+				assert.InDelta(t, tt.want[i].Value, got[i].Value, 1e-9)
+			}
+		})
+	}
+
+	_, err := Resample(b, "unknown", Weekly, Last)
+	assert.ErrorIs(t, err, ErrUnknownSeries)
+}
+
+func TestPeriodEnd(t *testing.T) {
+	tests := []struct {
+		date string
+		freq Frequency
+		want string
+	}{
+		{date: "2022-05-20", freq: Weekly, want: "2022-05-20"},
+		{date: "2022-05-16", freq: Weekly, want: "2022-05-20"},
+		{date: "2022-05-21", freq: Weekly, want: "2022-05-27"},
+		{date: "2024-02-05", freq: Monthly, want: "2024-02-29"},
+		{date: "2022-12-31", freq: Monthly, want: "2022-12-31"},
+		{date: "2022-05-20", freq: Quarterly, want: "2022-06-30"},
+		{date: "2022-10-01", freq: Quarterly, want: "2022-12-31"},
+		{date: "2022-03-31", freq: Quarterly, want: "2022-03-31"},
+		{date: "2022-05-20", freq: Yearly, want: "2022-12-31"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, periodEnd(tt.date, tt.freq), tt.date)
+	}
+}
@@ -0,0 +1,99 @@
+package boc
+
+import (
+	"fmt"
+	"time"
+)
+
+// indexRatioDecimals is the precision of the index ratio of Real Return Bonds, as published
+const indexRatioDecimals = 5
+
+// ReferenceCPI returns the Reference CPI of a Real Return Bond for a day: the
+// CPI of three months before the month of day, linearly interpolated towards
+// the CPI of two months before it by the day of the month. cpi holds the
+// monthly not seasonally adjusted CPI keyed by month, e.g. "2022-02".
+func ReferenceCPI(day time.Time, cpi map[string]float64) (float64, error) {
+	first := date(day.Year(), day.Month(), 1)
+	from, to := first.AddDate(0, -3, 0).Format("2006-01"), first.AddDate(0, -2, 0).Format("2006-01")
+	cpiFrom, ok := cpi[from]
+	if !ok {
+		return 0, fmt.Errorf("no CPI for %s", from)
+	}
+	cpiTo, ok := cpi[to]
+	if !ok {
+		return 0, fmt.Errorf("no CPI for %s", to)
+	}
+	days := float64(first.AddDate(0, 1, -1).Day())
+	return cpiFrom + float64(day.Day()-1)/days*(cpiTo-cpiFrom), nil
+}
+
+// IndexRatio returns the index ratio of a Real Return Bond, the Reference CPI
+// of a day divided by the Reference CPI of the issue date, rounded to five decimals
+func IndexRatio(referenceCPI, baseCPI float64) float64 {
+	return round(referenceCPI/baseCPI, indexRatioDecimals)
+}
+
+// IndexedPrice returns the price per 100 of face value of a Real Return Bond
+// from its real clean price, the price of its real cash flows, and its index ratio
+func IndexedPrice(realPrice, indexRatio float64) float64 {
+	return round(realPrice*indexRatio, priceDecimals)
+}
+
+// IndexedCoupon returns the coupon paid per 100 of face value by a semi-annual
+// Real Return Bond, with couponRate its real coupon in percent
+func IndexedCoupon(couponRate, indexRatio float64) float64 {
+	return couponRate / 2 * indexRatio
+}
+
+// RRBPrice returns the indexed clean price per 100 of face value of a semi-annual
+// Real Return Bond from its real yield in percent, e.g. the RRB yield series
+func RRBPrice(realYield, couponRate, yearsToMaturity, indexRatio float64) float64 {
+	return IndexedPrice(CleanPrice(realYield, couponRate, yearsToMaturity), indexRatio)
+}
+
+// FisherBreakeven returns the inflation rate in percent, semi-annually compounded,
+// that equates a nominal and a real semi-annual yield in percent:
+// (1 + nominal/2) = (1 + real/2) (1 + inflation/2).
+// The simple difference nominal - real is SeriesBreakevenInflation.
+func FisherBreakeven(nominal, real float64) float64 {
+	return ((1+nominal/200)/(1+real/200) - 1) * 200
+}
+
+// RealNominal compares the long-term nominal benchmark yield to the real return bond yield of a date
+type RealNominal struct {
+	Date string
+	// Nominal is the long-term benchmark yield in percent
+	Nominal float64
+	// Real is the real return bond yield in percent
+	Real float64
+	// Breakeven is Nominal minus Real, in percent
+	Breakeven float64
+	// FisherBreakeven is the breakeven inflation compounded with Real, see FisherBreakeven
+	FisherBreakeven float64
+}
+
+// CompareRealNominal compares the long-term benchmark yield of client to the
+// real return bond yield for a date
+func CompareRealNominal(client ObservationReader, date string, opts ...QueryOption) (RealNominal, error) {
+	obs, err := client.GetObservationForDate(date, opts...)
+	if err != nil {
+		return RealNominal{}, err
+	}
+	nominal, ok := obs.Value(SeriesYieldLong)
+	if !ok {
+		return RealNominal{}, &DataError{Date: obs.D, Series: SeriesYieldLong, Err: ErrNoValue}
+	}
+	real, ok := obs.Value(SeriesYieldRRB)
+	if !ok {
+		return RealNominal{}, &DataError{Date: obs.D, Series: SeriesYieldRRB, Err: ErrNoValue}
+	}
+	// rounded as SeriesBreakevenInflation so that both give the same breakeven
+	breakeven, _ := obs.breakeven()
+	return RealNominal{
+		Date:            obs.D,
+		Nominal:         nominal,
+		Real:            real,
+		Breakeven:       breakeven,
+		FisherBreakeven: FisherBreakeven(nominal, real),
+	}, nil
+}
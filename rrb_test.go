@@ -0,0 +1,81 @@
+package boc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferenceCPI(t *testing.T) {
+	a := assert.New(t)
+	cpi := map[string]float64{"2022-03": 145.8, "2022-04": 146.8}
+
+	ref, err := ReferenceCPI(time.Date(2022, time.June, 15, 0, 0, 0, 0, time.UTC), cpi)
+	require.NoError(t, err)
+	a.InDelta(146.26667, ref, 1e-5, "14 of the 30 days of June towards April")
+
+	ref, err = ReferenceCPI(time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC), cpi)
+	require.NoError(t, err)
+	a.Equal(145.8, ref, "the CPI of March on the first of June")
+
+	_, err = ReferenceCPI(time.Date(2022, time.July, 1, 0, 0, 0, 0, time.UTC), cpi)
+	a.ErrorContains(err, "no CPI for 2022-05")
+}
+
+func TestIndexRatio(t *testing.T) {
+	a := assert.New(t)
+	ratio := IndexRatio(146.26667, 109.81)
+	a.Equal(1.332, ratio)
+	a.Equal(133.2, IndexedPrice(100, ratio))
+	a.InDelta(2.4975, IndexedCoupon(3.75, ratio), 1e-9)
+	a.Equal(IndexedPrice(CleanPrice(0.72, 4.25, 4), ratio), RRBPrice(0.72, 4.25, 4, ratio))
+}
+
+func TestFisherBreakeven(t *testing.T) {
+	assert.InDelta(t, 2.30171, FisherBreakeven(3.03, 0.72), 1e-5)
+	assert.Equal(t, 0.0, FisherBreakeven(2, 2))
+}
+
+func TestRealNominal(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	rn, err := CompareRealNominal(b, "2022-06-01")
+	require.NoError(t, err)
+	a.Equal("2022-06-01", rn.Date)
+	a.Equal(3.03, rn.Nominal)
+	a.Equal(0.72, rn.Real)
+	a.Equal(2.31, rn.Breakeven)
+	a.InDelta(2.30171, rn.FisherBreakeven, 1e-5)
+
+	_, err = CompareRealNominal(b, "2022-05-27")
+	a.ErrorIs(err, ErrNoValue)
+	_, err = CompareRealNominal(b, "2022-05-23")
+	a.ErrorIs(err, ErrNoData)
+}
+
+func TestRealNominalBreakevenSeries(t *testing.T) {
+	a := assert.New(t)
+	fixture := newTestBOC(t)
+	precise := newTestBOC(t)
+	precise.ds.Store(precise.newDataset(context.Background(), &BOCData{Observations: []Observations{
+		{D: "2022-05-24", YieldLong: Val{V: "3.0125"}, YieldRRB: Val{V: "0.701"}},
+	}}))
+
+	for _, b := range []*bocInterests{fixture, precise} {
+		points, err := Breakeven(b, "", "")
+		require.NoError(t, err)
+		require.NotEmpty(t, points)
+		for _, p := range points {
+			rn, err := CompareRealNominal(b, p.Date)
+			require.NoError(t, err)
+			a.Equal(p.Value, rn.Breakeven, "RealNominal and SeriesBreakevenInflation agree on %s", p.Date)
+		}
+	}
+	rn, err := CompareRealNominal(precise, "2022-05-24")
+	require.NoError(t, err)
+	a.Equal(2.3115, rn.Breakeven)
+}
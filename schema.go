@@ -0,0 +1,89 @@
+package boc
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// SchemaDrift reports the differences between the series of a fetched payload
+// and the series the package knows, e.g. after the Bank of Canada changed the group
+type SchemaDrift struct {
+	// Missing are the expected series neither described nor observed, in the order of AllSeries
+	Missing []string
+	// Unexpected are the series described or observed that are not part of AllSeries, sorted
+	Unexpected []string
+	// Invalid are the published values that are not numbers, which are read as missing
+	Invalid []InvalidValue
+}
+
+// InvalidValue is a published value that is not a number
+type InvalidValue struct {
+	Date   string
+	Series string
+	Value  string
+}
+
+// Empty reports whether the payload has the expected series
+func (s SchemaDrift) Empty() bool {
+	return len(s.Missing) == 0 && len(s.Unexpected) == 0 && len(s.Invalid) == 0
+}
+
+// schemaDrift compares the series of data to the expected series keys
+func schemaDrift(data *BOCData, expected []string) SchemaDrift {
+	var drift SchemaDrift
+	seen := make(map[string]bool, len(allSeries))
+	for key := range data.SeriesDetails {
+		seen[key] = true
+	}
+	for i := range data.Observations {
+		obs := &data.Observations[i]
+		for _, key := range allSeries {
+			v := obs.field(key)
+			if v.Valid() {
+				seen[key] = true
+			} else if strings.TrimSpace(v.V) != "" {
+				drift.Invalid = append(drift.Invalid, InvalidValue{Date: obs.D, Series: key, Value: v.V})
+			}
+		}
+		for key := range obs.Extra {
+			seen[key] = true
+		}
+	}
+	for _, key := range expected {
+		if !seen[key] {
+			drift.Missing = append(drift.Missing, key)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(seen)) {
+		if _, ok := seriesColumns[key]; !ok {
+			drift.Unexpected = append(drift.Unexpected, key)
+		}
+	}
+	return drift
+}
+
+// checkSchema logs and reports the schema drift of a fetched payload
+func (b *bocInterests) checkSchema(data *BOCData) {
+	if len(data.SeriesDetails) == 0 && len(data.Observations) == 0 {
+		// nothing to compare, e.g. an empty incremental refresh
+		return
+	}
+	expected := allSeries
+	if len(b.series) > 0 {
+		expected = b.series
+	}
+	drift := schemaDrift(data, expected)
+	if drift.Empty() {
+		return
+	}
+	if len(drift.Missing) > 0 || len(drift.Unexpected) > 0 {
+		b.logger.Warn("series differ from the expected ones", "missing", drift.Missing, "unexpected", drift.Unexpected)
+	}
+	for _, v := range drift.Invalid {
+		b.logger.Warn("value is not a number, reading it as missing", "date", v.Date, "series", v.Series, "value", v.Value)
+	}
+	for _, handler := range b.driftHandlers {
+		handler(drift)
+	}
+}
@@ -0,0 +1,57 @@
+package boc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaDrift(t *testing.T) {
+	a := assert.New(t)
+	a.True(schemaDrift(readFixture(t), allSeries).Empty())
+
+	data := &BOCData{
+		SeriesDetails: map[string]Detail{SeriesYield2Year: {}, "BD.CDN.1YR.DQ.YLD": {}},
+		Observations: []Observations{
+			{D: "2022-05-24", Yield10Year: Val{V: "2.78"}, Extra: map[string]Val{"A.NEW": {V: "1"}}},
+			{D: "2022-05-25", Yield10Year: Val{V: "n/a"}, Yield5Year: Val{V: " "}},
+		},
+	}
+	drift := schemaDrift(data, []string{SeriesYield2Year, SeriesYield10Year, SeriesYieldRRB})
+	a.Equal([]string{SeriesYieldRRB}, drift.Missing)
+	a.Equal([]string{"A.NEW", "BD.CDN.1YR.DQ.YLD"}, drift.Unexpected)
+	a.Equal([]InvalidValue{{Date: "2022-05-25", Series: SeriesYield10Year, Value: "n/a"}}, drift.Invalid)
+	a.False(drift.Empty())
+}
+
+func TestSchemaDriftHandler(t *testing.T) {
+	a := assert.New(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"seriesDetail": {"BD.CDN.2YR.DQ.YLD": {"label": "2 year"}, "BD.CDN.1YR.DQ.YLD": {"label": "1 year"}},
+			"observations": [{"d": "2022-05-24", "BD.CDN.2YR.DQ.YLD": {"v": "2.57"}, "BD.CDN.1YR.DQ.YLD": {"v": "2.40"}}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	var drifts []SchemaDrift
+	handler := func(drift SchemaDrift) { drifts = append(drifts, drift) }
+	b := newBOCInterests(WithBaseURL(srv.URL), WithSchemaDriftHandler(handler))
+	require.NoError(t, b.load(context.Background()))
+	require.Len(t, drifts, 1)
+	a.Len(drifts[0].Missing, len(allSeries)-1)
+	a.Equal([]string{"BD.CDN.1YR.DQ.YLD"}, drifts[0].Unexpected)
+
+	drifts = nil
+	b = newBOCInterests(WithBaseURL(srv.URL), WithSeries(SeriesYield2Year), WithSchemaDriftHandler(handler))
+	require.NoError(t, b.load(context.Background()))
+	require.Len(t, drifts, 1)
+	a.Empty(drifts[0].Missing, "only the series of WithSeries are expected")
+
+	drifts = nil
+	b = newBOCInterests(WithBaseURL(newTestServer(t, http.StatusOK).URL), WithSchemaDriftHandler(handler))
+	require.NoError(t, b.load(context.Background()))
+	a.Empty(drifts)
+}
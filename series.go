@@ -0,0 +1,273 @@
+package boc
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Keys of the series of the bond_yields_all group, as used by the Valet API
+const (
+	SeriesAverage1To3Year   = "CDN.AVG.1YTO3Y.AVG"
+	SeriesAverage3To5Year   = "CDN.AVG.3YTO5Y.AVG"
+	SeriesAverage5To10Year  = "CDN.AVG.5YTO10Y.AVG"
+	SeriesAverageOver10Year = "CDN.AVG.OVER.10.AVG"
+	SeriesYield2Year        = "BD.CDN.2YR.DQ.YLD"
+	SeriesYield3Year        = "BD.CDN.3YR.DQ.YLD"
+	SeriesYield5Year        = "BD.CDN.5YR.DQ.YLD"
+	SeriesYield7Year        = "BD.CDN.7YR.DQ.YLD"
+	SeriesYield10Year       = "BD.CDN.10YR.DQ.YLD"
+	SeriesYieldLong         = "BD.CDN.LONG.DQ.YLD"
+	SeriesYieldRRB          = "BD.CDN.RRB.DQ.YLD"
+)
+
+var allSeries = []string{
+	SeriesAverage1To3Year,
+	SeriesAverage3To5Year,
+	SeriesAverage5To10Year,
+	SeriesAverageOver10Year,
+	SeriesYield2Year,
+	SeriesYield3Year,
+	SeriesYield5Year,
+	SeriesYield7Year,
+	SeriesYield10Year,
+	SeriesYieldLong,
+	SeriesYieldRRB,
+}
+
+var seriesLabels = map[string]string{
+	SeriesAverage1To3Year:   "1 to 3 year average yield",
+	SeriesAverage3To5Year:   "3 to 5 year average yield",
+	SeriesAverage5To10Year:  "5 to 10 year average yield",
+	SeriesAverageOver10Year: "Over 10 years average yield",
+	SeriesYield2Year:        "2 year benchmark yield",
+	SeriesYield3Year:        "3 year benchmark yield",
+	SeriesYield5Year:        "5 year benchmark yield",
+	SeriesYield7Year:        "7 year benchmark yield",
+	SeriesYield10Year:       "10 year benchmark yield",
+	SeriesYieldLong:         "Long-term benchmark yield",
+	SeriesYieldRRB:          "Real return bond yield",
+
+	SeriesBreakevenInflation: "Long-term breakeven inflation",
+}
+
+// AllSeries returns the keys of all the series of the bond_yields_all group
+func AllSeries() []string {
+	series := make([]string, len(allSeries))
+	copy(series, allSeries)
+	return series
+}
+
+// UnitPercent is the unit of the yields, published in percent per annum
+const UnitPercent = "% per annum"
+
+// SeriesUnit returns the unit of the values of a series key, and false if the key is unknown.
+// All the series of the bond_yields_all group and the derived series are yields.
+func SeriesUnit(key string) (string, bool) {
+	if _, ok := seriesLabels[key]; !ok {
+		return "", false
+	}
+	return UnitPercent, true
+}
+
+// SeriesLabel returns a short English label for a series key, and false if the key is unknown
+func SeriesLabel(key string) (string, bool) {
+	label, ok := seriesLabels[key]
+	return label, ok
+}
+
+// field returns a pointer to the value of the series key, and nil if the key is unknown
+func (o *Observations) field(key string) *Val {
+	switch key {
+	case SeriesAverage1To3Year:
+		return &o.Average1To3Year
+	case SeriesAverage3To5Year:
+		return &o.Average3To5Year
+	case SeriesAverage5To10Year:
+		return &o.Average5To10Year
+	case SeriesAverageOver10Year:
+		return &o.AverageOver10Year
+	case SeriesYield2Year:
+		return &o.Yield2Year
+	case SeriesYield3Year:
+		return &o.Yield3Year
+	case SeriesYield5Year:
+		return &o.Yield5Year
+	case SeriesYield7Year:
+		return &o.Yield7Year
+	case SeriesYield10Year:
+		return &o.Yield10Year
+	case SeriesYieldLong:
+		return &o.YieldLong
+	case SeriesYieldRRB:
+		return &o.YieldRRB
+	}
+	return nil
+}
+
+// field returns a pointer to the details of the series key, and nil if the key is unknown
+func (d *SeriesDetail) field(key string) *Detail {
+	switch key {
+	case SeriesAverage1To3Year:
+		return &d.Average1To3Year
+	case SeriesAverage3To5Year:
+		return &d.Average3To5Year
+	case SeriesAverage5To10Year:
+		return &d.Average5To10Year
+	case SeriesAverageOver10Year:
+		return &d.AverageOver10Year
+	case SeriesYield2Year:
+		return &d.Yield2Year
+	case SeriesYield3Year:
+		return &d.Yield3Year
+	case SeriesYield5Year:
+		return &d.Yield5Year
+	case SeriesYield7Year:
+		return &d.Yield7Year
+	case SeriesYield10Year:
+		return &d.Yield10Year
+	case SeriesYieldLong:
+		return &d.YieldLong
+	case SeriesYieldRRB:
+		return &d.YieldRRB
+	}
+	return nil
+}
+
+// val returns the value of the series key, and false if the key is unknown
+func (o *Observations) val(key string) (Val, bool) {
+	if v := o.field(key); v != nil {
+		return *v, true
+	}
+	v, ok := o.Extra[key]
+	return v, ok
+}
+
+// Value returns the value of the series key as a float, and false if the key
+// is unknown or has no value for this observation
+func (o *Observations) Value(seriesKey string) (float64, bool) {
+	if seriesKey == SeriesBreakevenInflation {
+		return o.breakeven()
+	}
+	v, ok := o.val(seriesKey)
+	if !ok {
+		return 0, false
+	}
+	return v.Float()
+}
+
+// Has reports whether the observation has a value for the series key
+func (o *Observations) Has(seriesKey string) bool {
+	_, ok := o.Value(seriesKey)
+	return ok
+}
+
+// ValuePtr returns the value of the series key, nil if the key is unknown or
+// has no value for this observation, e.g. to encode missing values as null
+func (o *Observations) ValuePtr(seriesKey string) *float64 {
+	if v, ok := o.Value(seriesKey); ok {
+		return &v
+	}
+	return nil
+}
+
+// Float returns the value as a float, and false if it is not published, the
+// Valet API leaving it empty, or is not a number
+func (v Val) Float() (float64, bool) {
+	if v.V == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(v.V, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// Valid reports whether the value is published
+func (v Val) Valid() bool {
+	_, ok := v.Float()
+	return ok
+}
+
+// Ptr returns the value, nil if it is not published
+func (v Val) Ptr() *float64 {
+	if f, ok := v.Float(); ok {
+		return &f
+	}
+	return nil
+}
+
+// SeriesValue implements BOCInterests
+func (b *bocInterests) SeriesValue(date, seriesKey string, opts ...QueryOption) (float64, error) {
+	if err := checkSeries(seriesKey); err != nil {
+		return 0, err
+	}
+	obs, err := b.GetObservationForDate(date, opts...)
+	if err != nil {
+		return 0, err
+	}
+	v, ok := obs.Value(seriesKey)
+	if !ok {
+		return 0, &DataError{Date: obs.D, Series: seriesKey, Err: ErrNoValue}
+	}
+	return v, nil
+}
+
+// PreviousValue implements BOCInterests
+func (b *bocInterests) PreviousValue(seriesKey, date string) (Point, error) {
+	if err := checkSeries(seriesKey); err != nil {
+		return Point{}, err
+	}
+	formatted, err := b.formatDate(date)
+	if err != nil {
+		return Point{}, err
+	}
+	ds, err := b.fresh(context.Background())
+	if err != nil {
+		return Point{}, err
+	}
+	for i := sort.SearchStrings(ds.dates, formatted) - 1; i >= 0; i-- {
+		if v, ok := ds.observation(i).Value(seriesKey); ok {
+			return Point{Date: ds.dates[i], Value: v}, nil
+		}
+	}
+	return Point{}, &DataError{Date: formatted, Series: seriesKey, Err: ErrNoValue}
+}
+
+// Point is the value of a series at a date
+type Point struct {
+	Date  string
+	Value float64
+}
+
+// points returns the values of the series key for the dates d.dates[from:to],
+// skipping the dates without value
+func (d *dataset) points(key string, from, to int) []Point {
+	points := make([]Point, 0, to-from)
+	c, ok := seriesColumns[key]
+	if !ok {
+		// derived series are computed from the observations
+		for i := from; i < to; i++ {
+			if v, ok := d.observation(i).Value(key); ok {
+				points = append(points, Point{Date: d.dates[i], Value: v})
+			}
+		}
+		return points
+	}
+	for i, v := range d.columns[c][from:to] {
+		if !math.IsNaN(v) {
+			points = append(points, Point{Date: d.dates[from+i], Value: v})
+		}
+	}
+	return points
+}
+
+// checkSeries returns an error if key is not a known series
+func checkSeries(key string) error {
+	if _, ok := seriesLabels[key]; !ok {
+		return &DataError{Series: key, Err: ErrUnknownSeries}
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package boc
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllSeries(t *testing.T) {
+	a := assert.New(t)
+	data, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	var payload struct {
+		SeriesDetail map[string]json.RawMessage `json:"seriesDetail"`
+	}
+	require.NoError(t, json.Unmarshal(data, &payload))
+
+	series := AllSeries()
+	a.Len(series, len(payload.SeriesDetail))
+	a.NotContains(series, SeriesBreakevenInflation)
+	for _, key := range series {
+		a.Contains(payload.SeriesDetail, key)
+		label, ok := SeriesLabel(key)
+		a.True(ok)
+		a.NotEmpty(label)
+	}
+
+	series[0] = "changed"
+	a.Equal(SeriesAverage1To3Year, AllSeries()[0])
+}
+
+func TestSeriesLabel(t *testing.T) {
+	a := assert.New(t)
+	label, ok := SeriesLabel(SeriesYield10Year)
+	a.True(ok)
+	a.Equal("10 year benchmark yield", label)
+
+	_, ok = SeriesLabel("unknown")
+	a.False(ok)
+}
+
+func TestValue(t *testing.T) {
+	a := assert.New(t)
+	obs := &Observations{
+		D:          "2022-05-27",
+		Yield2Year: Val{V: "2.61"},
+		YieldRRB:   Val{V: ""},
+		YieldLong:  Val{V: "n/a"},
+	}
+
+	v, ok := obs.Value(SeriesYield2Year)
+	a.True(ok)
+	a.Equal(2.61, v)
+
+	for _, key := range []string{SeriesYieldRRB, SeriesYieldLong, "unknown"} {
+		_, ok = obs.Value(key)
+		a.False(ok, key)
+	}
+
+	for _, key := range AllSeries() {
+		_, ok := obs.val(key)
+		a.True(ok, key)
+	}
+}
+
+func TestNullAwareValues(t *testing.T) {
+	a := assert.New(t)
+	obs := &Observations{D: "2022-05-27", Yield2Year: Val{V: "0.00"}, YieldRRB: Val{V: ""}}
+
+	a.True(obs.Has(SeriesYield2Year), "zero is a published value")
+	a.False(obs.Has(SeriesYieldRRB))
+	a.False(obs.Has("unknown"))
+	require.NotNil(t, obs.ValuePtr(SeriesYield2Year))
+	a.Equal(0.0, *obs.ValuePtr(SeriesYield2Year))
+	a.Nil(obs.ValuePtr(SeriesYieldRRB))
+
+	f, ok := obs.Yield2Year.Float()
+	a.True(ok)
+	a.Equal(0.0, f)
+	_, ok = obs.YieldRRB.Float()
+	a.False(ok)
+	a.True(obs.Yield2Year.Valid())
+	a.False(Val{V: "n/a"}.Valid())
+	a.Nil(obs.YieldRRB.Ptr())
+	a.Equal(0.0, *obs.Yield2Year.Ptr())
+
+	r := obs.Record()
+	a.True(r.Has(SeriesYield2Year))
+	a.False(r.Has(SeriesYieldRRB))
+}
+
+func TestSeriesValue(t *testing.T) {
+	b := newTestBOC(t)
+	tests := []struct {
+		name    string
+		date    string
+		series  string
+		want    float64
+		wantErr error
+	}{
+		{
+			name:   "success",
+			date:   "2022-05-25",
+			series: SeriesYield10Year,
+			want:   2.74,
+		},
+		{
+			name:    "missing value",
+			date:    "2022-05-27",
+			series:  SeriesYieldRRB,
+			wantErr: ErrNoValue,
+		},
+		{
+			name:    "unknown series",
+			date:    "2022-05-25",
+			series:  "unknown",
+			wantErr: ErrUnknownSeries,
+		},
+		{
+			name:    "no data",
+			date:    "2022-05-23",
+			series:  SeriesYield10Year,
+			wantErr: ErrNoData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := b.SeriesValue(tt.date, tt.series)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPreviousValue(t *testing.T) {
+	b := newTestBOC(t)
+	tests := []struct {
+		name    string
+		series  string
+		date    string
+		want    Point
+		wantErr error
+	}{
+		{name: "previous business day", series: SeriesYield2Year, date: "2022-05-24", want: Point{Date: "2022-05-20", Value: 2.59}},
+		{name: "weekend", series: SeriesYield10Year, date: "2022-05-29", want: Point{Date: "2022-05-27", Value: 2.8}},
+		{name: "missing value", series: SeriesYieldRRB, date: "2022-05-30", want: Point{Date: "2022-05-26", Value: 0.57}},
+		{name: "first date", series: SeriesYield2Year, date: "2022-05-20", wantErr: ErrNoValue},
+		{name: "unknown series", series: "foo", date: "2022-05-24", wantErr: ErrUnknownSeries},
+		{name: "invalid date", series: SeriesYield2Year, date: "foo", wantErr: ErrInvalidDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := b.PreviousValue(tt.series, tt.date)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, p)
+		})
+	}
+}
+
+func TestSeriesInfo(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	detail, err := b.SeriesInfo(SeriesYield10Year)
+	a.NoError(err)
+	a.Equal("10 year", detail.Label)
+	a.Equal(Dimension{Key: "d", Name: "Date"}, detail.Dimension)
+	_, err = b.SeriesInfo("V39079")
+	a.ErrorIs(err, ErrUnknownSeries)
+
+	details := b.SeriesDetails()
+	a.Len(details, 11)
+	delete(details, SeriesYield10Year)
+	_, err = b.SeriesInfo(SeriesYield10Year)
+	a.NoError(err, "SeriesDetails returns a copy")
+
+	data := readFixture(t)
+	data.SeriesDetails = map[string]Detail{"V39079": {Label: "Target for the overnight rate"}}
+	b.ds.Store(b.newDataset(context.Background(), data))
+	detail, err = b.SeriesInfo("V39079")
+	a.NoError(err)
+	a.Equal("Target for the overnight rate", detail.Label)
+	_, err = b.SeriesInfo(SeriesYield10Year)
+	a.NoError(err, "the fixed fields are indexed too")
+}
+
+func TestSeriesUnit(t *testing.T) {
+	unit, ok := SeriesUnit(SeriesYieldRRB)
+	assert.True(t, ok)
+	assert.Equal(t, UnitPercent, unit)
+	unit, ok = SeriesUnit(SeriesBreakevenInflation)
+	assert.True(t, ok)
+	assert.Equal(t, UnitPercent, unit)
+	_, ok = SeriesUnit("V39079")
+	assert.False(t, ok)
+
+	detail, err := newTestBOC(t).SeriesInfo(SeriesYield2Year)
+	assert.NoError(t, err)
+	assert.Equal(t, UnitPercent, detail.Unit)
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/clauderoy790/bank-of-canada-interests-rates/feed"
+)
+
+// WithAlertLog adds the alert events recorded by log to the feeds
+func WithAlertLog(log *feed.AlertLog) Option {
+	return func(s *Server) {
+		s.alerts = log
+	}
+}
+
+// newFeed returns the feed of the latest observations, and of the alerts if
+// an alert log is set, linking to the requested URL
+func (s *Server) newFeed(r *http.Request) *feed.Feed {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	entries := feed.Observations(s.client, feed.DefaultEntries)
+	if s.alerts != nil {
+		entries = feed.Merge(entries, s.alerts.Entries())
+	}
+	return &feed.Feed{
+		Title:   "Bank of Canada bond yields",
+		Link:    scheme + "://" + r.Host + r.URL.Path,
+		Rights:  s.client.Attribution().String(),
+		Entries: entries,
+	}
+}
+
+func (s *Server) handleAtom(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	s.newFeed(r).WriteAtom(w)
+}
+
+func (s *Server) handleRSS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	s.newFeed(r).WriteRSS(w)
+}
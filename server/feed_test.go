@@ -0,0 +1,47 @@
+package server
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+	"github.com/clauderoy790/bank-of-canada-interests-rates/feed"
+)
+
+func TestFeeds(t *testing.T) {
+	a := assert.New(t)
+	log := feed.NewAlertLog(10)
+	log.Handler()(boc.AlertEvent{Name: "10y above 2.9%", Date: "2022-06-01"})
+	srv := newTestServer(t, WithAlertLog(log))
+
+	resp, err := http.Get(srv.URL + "/feed.atom")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	a.Equal("application/atom+xml; charset=utf-8", resp.Header.Get("Content-Type"))
+	var atom struct {
+		ID      string `xml:"id"`
+		Rights  string `xml:"rights"`
+		Entries []struct {
+			ID string `xml:"id"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.NewDecoder(resp.Body).Decode(&atom))
+	a.Equal(srv.URL+"/feed.atom", atom.ID)
+	a.Equal("Source : Banque du Canada. https://www.bankofcanada.ca/terms/", atom.Rights)
+	require.Len(t, atom.Entries, 9)
+	a.Equal("urn:boc:alert:10y%20above%202.9%25:2022-06-01", atom.Entries[0].ID)
+	a.Equal("urn:boc:observation:2022-06-01", atom.Entries[1].ID)
+
+	resp, err = http.Get(srv.URL + "/feed.rss")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	a.Equal("application/rss+xml; charset=utf-8", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	a.Contains(string(body), "<guid isPermaLink=\"false\">urn:boc:observation:2022-05-20</guid>")
+}
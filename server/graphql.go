@@ -0,0 +1,217 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// graphQLRequest is the body of a GraphQL POST request
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// seriesValue is the value of a series in an observation, nil when missing
+type seriesValue struct {
+	Series string
+	Value  *float64
+}
+
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+	switch r.Method {
+	case http.MethodGet:
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if vars := r.URL.Query().Get("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				writeJSON(w, http.StatusBadRequest, errorBody("invalid variables: "+err.Error()))
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorBody("invalid request body: "+err.Error()))
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeJSON(w, http.StatusMethodNotAllowed, errorBody("method not allowed"))
+		return
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:         s.schema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        r.Context(),
+	})
+	writeJSON(w, http.StatusOK, result)
+}
+
+// errorBody is a GraphQL response with a single error
+func errorBody(message string) map[string]any {
+	return map[string]any{"errors": []map[string]string{{"message": message}}}
+}
+
+func (s *Server) newSchema() (graphql.Schema, error) {
+	valueType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Value",
+		Fields: graphql.Fields{
+			"series": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"value":  &graphql.Field{Type: graphql.Float},
+		},
+	})
+	observationType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Observation",
+		Description: "The values of the series for a date",
+		Fields: graphql.Fields{
+			"date": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source.(*boc.Observations).D, nil },
+			},
+			"value": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "The value of a series, null when missing",
+				Args:        graphql.FieldConfigArgument{"series": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					if v, ok := p.Source.(*boc.Observations).Value(p.Args["series"].(string)); ok {
+						return v, nil
+					}
+					return nil, nil
+				},
+			},
+			"values": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(valueType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					obs := p.Source.(*boc.Observations)
+					values := make([]seriesValue, 0)
+					for _, key := range boc.AllSeries() {
+						sv := seriesValue{Series: key}
+						if v, ok := obs.Value(key); ok {
+							sv.Value = &v
+						}
+						values = append(values, sv)
+					}
+					return values, nil
+				},
+			},
+		},
+	})
+	seriesType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Series",
+		Fields: graphql.Fields{
+			"key":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"label": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"unit":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	pointType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Point",
+		Fields: graphql.Fields{
+			"date":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"value": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+	rangeArgs := graphql.FieldConfigArgument{
+		"from":        &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: ""},
+		"to":          &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: ""},
+		"forwardFill": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"observation": &graphql.Field{
+				Type:        observationType,
+				Description: "The observation of a date",
+				Args: graphql.FieldConfigArgument{
+					"date":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"forwardFill": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return s.client.GetObservationForDate(p.Args["date"].(string), queryOptions(p)...)
+				},
+			},
+			"observations": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(observationType))),
+				Description: "The observations from from to to inclusively, in chronological order",
+				Args:        rangeArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					seq, err := s.client.Between(p.Args["from"].(string), p.Args["to"].(string), queryOptions(p)...)
+					if err != nil {
+						return nil, err
+					}
+					observations := make([]*boc.Observations, 0)
+					for _, obs := range seq {
+						observations = append(observations, obs)
+					}
+					return observations, nil
+				},
+			},
+			"series": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(seriesType))),
+				Description: "The series of the group",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					series := make([]map[string]string, 0)
+					for _, key := range boc.AllSeries() {
+						label, _ := boc.SeriesLabel(key)
+						unit, _ := boc.SeriesUnit(key)
+						series = append(series, map[string]string{"key": key, "label": label, "unit": unit})
+					}
+					return series, nil
+				},
+			},
+			"spread": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(pointType))),
+				Description: "The spread in basis points of seriesA over seriesB, on the dates both have a value",
+				Args: graphql.FieldConfigArgument{
+					"seriesA":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"seriesB":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"from":        rangeArgs["from"],
+					"to":          rangeArgs["to"],
+					"forwardFill": rangeArgs["forwardFill"],
+				},
+				Resolve: s.resolveSpread,
+			},
+		},
+	})
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+func (s *Server) resolveSpread(p graphql.ResolveParams) (any, error) {
+	seriesA, seriesB := p.Args["seriesA"].(string), p.Args["seriesB"].(string)
+	for _, key := range []string{seriesA, seriesB} {
+		if _, ok := boc.SeriesLabel(key); !ok {
+			return nil, &boc.DataError{Series: key, Err: boc.ErrUnknownSeries}
+		}
+	}
+	seq, err := s.client.Between(p.Args["from"].(string), p.Args["to"].(string), queryOptions(p)...)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]boc.Point, 0)
+	for date, obs := range seq {
+		a, okA := obs.Value(seriesA)
+		b, okB := obs.Value(seriesB)
+		if okA && okB {
+			// rounded to hide the floating point noise of the subtraction
+			points = append(points, boc.Point{Date: date, Value: float64(boc.ToBps(a - b))})
+		}
+	}
+	return points, nil
+}
+
+// queryOptions returns the lookup options of the arguments of a field
+func queryOptions(p graphql.ResolveParams) []boc.QueryOption {
+	opts := []boc.QueryOption{boc.Context(p.Context)}
+	if ff, _ := p.Args["forwardFill"].(bool); ff {
+		opts = append(opts, boc.ForwardFill())
+	}
+	return opts
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postGraphQL(t *testing.T, srvURL, query string) (int, string) {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"query": query})
+	require.NoError(t, err)
+	resp, err := http.Post(srvURL+"/graphql", "application/json", strings.NewReader(string(body)))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(raw)
+}
+
+func TestGraphQL(t *testing.T) {
+	srv := newTestServer(t)
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "observation",
+			query: `{ observation(date: "2022-05-24") { date value(series: "BD.CDN.2YR.DQ.YLD") } }`,
+			want:  `{"data": {"observation": {"date": "2022-05-24", "value": 2.57}}}`,
+		},
+		{
+			name:  "missing value",
+			query: `{ observation(date: "2022-05-27") { value(series: "BD.CDN.RRB.DQ.YLD") } }`,
+			want:  `{"data": {"observation": {"value": null}}}`,
+		},
+		{
+			name:  "forward fill",
+			query: `{ observation(date: "2022-05-23", forwardFill: true) { date value(series: "BD.CDN.2YR.DQ.YLD") } }`,
+			want:  `{"data": {"observation": {"date": "2022-05-23", "value": 2.59}}}`,
+		},
+		{
+			name:  "observations",
+			query: `{ observations(from: "2022-05-24", to: "2022-05-25") { date } }`,
+			want:  `{"data": {"observations": [{"date": "2022-05-24"}, {"date": "2022-05-25"}]}}`,
+		},
+		{
+			name:  "values",
+			query: `{ observation(date: "2022-05-24") { values { series value } } }`,
+		},
+		{
+			name:  "series",
+			query: `{ series { key label unit } }`,
+		},
+		{
+			name:  "spread",
+			query: `{ spread(seriesA: "BD.CDN.10YR.DQ.YLD", seriesB: "BD.CDN.2YR.DQ.YLD", from: "2022-05-24", to: "2022-05-24") { date value } }`,
+			want:  `{"data": {"spread": [{"date": "2022-05-24", "value": 21}]}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := postGraphQL(t, srv.URL, tt.query)
+			assert.Equal(t, http.StatusOK, status)
+			assert.NotContains(t, body, "errors")
+			if tt.want != "" {
+				assert.JSONEq(t, tt.want, body)
+			}
+		})
+	}
+}
+
+func TestGraphQLValues(t *testing.T) {
+	a := assert.New(t)
+	srv := newTestServer(t)
+	_, body := postGraphQL(t, srv.URL, `{ observation(date: "2022-05-27") { values { series value } } series { key label } }`)
+
+	var result struct {
+		Data struct {
+			Observation struct {
+				Values []struct {
+					Series string
+					Value  *float64
+				}
+			}
+			Series []struct {
+				Key   string
+				Label string
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &result))
+	values := result.Data.Observation.Values
+	a.Len(values, 11)
+	a.Equal("BD.CDN.RRB.DQ.YLD", values[10].Series)
+	a.Nil(values[10].Value)
+	a.Len(result.Data.Series, 11)
+	a.Equal("2 year benchmark yield", result.Data.Series[4].Label)
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	a := assert.New(t)
+	srv := newTestServer(t)
+
+	_, body := postGraphQL(t, srv.URL, `{ observation(date: "2022-05-23") { date } }`)
+	a.Contains(body, "no data for this date")
+	_, body = postGraphQL(t, srv.URL, `{ spread(seriesA: "foo", seriesB: "BD.CDN.2YR.DQ.YLD") { date } }`)
+	a.Contains(body, "unknown series")
+	_, body = postGraphQL(t, srv.URL, `{ unknown }`)
+	a.Contains(body, "errors")
+
+	resp, err := http.Post(srv.URL+"/graphql", "application/json", strings.NewReader("{"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	a.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodDelete, srv.URL+"/graphql", nil)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	a.Equal(http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestGraphQLGet(t *testing.T) {
+	a := assert.New(t)
+	srv := newTestServer(t)
+	q := url.Values{
+		"query":     {`query($d: String!) { observation(date: $d) { date } }`},
+		"variables": {`{"d": "2022-05-25"}`},
+	}
+	resp, err := http.Get(srv.URL + "/graphql?" + q.Encode())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	a.JSONEq(`{"data": {"observation": {"date": "2022-05-25"}}}`, string(raw))
+}
@@ -0,0 +1,237 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spec is the decoded openapi.json, walked by the contract tests
+type spec map[string]any
+
+func loadSpec(t *testing.T) spec {
+	t.Helper()
+	var doc spec
+	require.NoError(t, json.Unmarshal(openAPI, &doc))
+	return doc
+}
+
+// resolve follows the $ref of node, if any
+func (s spec) resolve(node map[string]any) map[string]any {
+	ref, ok := node["$ref"].(string)
+	if !ok {
+		return node
+	}
+	var cur any = map[string]any(s)
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		m, _ := cur.(map[string]any)
+		cur = m[part]
+	}
+	resolved, _ := cur.(map[string]any)
+	return s.resolve(resolved)
+}
+
+// operation returns the documented path and the operation matching method and path
+func (s spec) operation(method, path string) (string, map[string]any, bool) {
+	paths, _ := s["paths"].(map[string]any)
+	segments := strings.Split(path, "/")
+	for template, item := range paths {
+		parts := strings.Split(template, "/")
+		if len(parts) != len(segments) {
+			continue
+		}
+		match := true
+		for i, part := range parts {
+			if !strings.HasPrefix(part, "{") && part != segments[i] {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		op, ok := item.(map[string]any)[strings.ToLower(method)].(map[string]any)
+		return template, op, ok
+	}
+	return "", nil, false
+}
+
+// parameters returns the names of the parameters of op documented in in
+func (s spec) parameters(op map[string]any, in string) []string {
+	names := make([]string, 0)
+	params, _ := op["parameters"].([]any)
+	for _, p := range params {
+		param := s.resolve(p.(map[string]any))
+		if param["in"] == in {
+			names = append(names, param["name"].(string))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validate returns the differences between v and schema, at being the location of v
+func (s spec) validate(schema map[string]any, v any, at string) []string {
+	schema = s.resolve(schema)
+	if v == nil {
+		if nullable, _ := schema["nullable"].(bool); nullable {
+			return nil
+		}
+		return []string{at + ": unexpected null"}
+	}
+	switch schema["type"] {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: %T is not an object", at, v)}
+		}
+		var errs []string
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, ok := obj[name.(string)]; !ok {
+				errs = append(errs, fmt.Sprintf("%s: missing %s", at, name))
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		additional, _ := schema["additionalProperties"].(map[string]any)
+		for name, value := range obj {
+			if property, ok := properties[name].(map[string]any); ok {
+				errs = append(errs, s.validate(property, value, at+"."+name)...)
+			} else if additional != nil {
+				errs = append(errs, s.validate(additional, value, at+"."+name)...)
+			} else {
+				errs = append(errs, fmt.Sprintf("%s: undocumented %s", at, name))
+			}
+		}
+		return errs
+	case "array":
+		items, ok := v.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: %T is not an array", at, v)}
+		}
+		var errs []string
+		for i, item := range items {
+			errs = append(errs, s.validate(schema["items"].(map[string]any), item, at+"["+strconv.Itoa(i)+"]")...)
+		}
+		return errs
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return []string{fmt.Sprintf("%s: %T is not a string", at, v)}
+		}
+		if schema["format"] == "date" {
+			if _, err := time.Parse(time.DateOnly, str); err != nil {
+				return []string{fmt.Sprintf("%s: %q is not a date", at, str)}
+			}
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			return []string{fmt.Sprintf("%s: %T is not a number", at, v)}
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return []string{fmt.Sprintf("%s: %T is not a boolean", at, v)}
+		}
+	}
+	return nil
+}
+
+// TestOpenAPIContract checks the responses of the server against the operations
+// of openapi.json, and that every documented response is reached
+func TestOpenAPIContract(t *testing.T) {
+	doc := loadSpec(t)
+	srv := newTestServer(t)
+	requests := []string{
+		"/v1/observations?from=2022-05-30",
+		"/v1/observations?from=2022-05-26&to=2022-05-30&forwardFill=true",
+		"/v1/observations?from=foo",
+		"/v1/observations/2022-05-27",
+		"/v1/observations/2022-05-23?forwardFill=true",
+		"/v1/observations/2022-05-23",
+		"/v1/observations/foo",
+		"/v1/observations/2022-05-27?forwardFill=maybe",
+		"/v1/series",
+		"/v1/series/BD.CDN.RRB.DQ.YLD?from=2022-05-26&to=2022-05-30",
+		"/v1/series/BD.CDN.RRB.DQ.YLD?from=2022-05-26&forwardFill=true",
+		"/v1/series/foo",
+	}
+	reached := make(map[string]bool)
+	for _, path := range requests {
+		t.Run(path, func(t *testing.T) {
+			a := assert.New(t)
+			u, err := url.Parse(path)
+			require.NoError(t, err)
+			template, op, ok := doc.operation(http.MethodGet, u.Path)
+			require.True(t, ok, "undocumented operation")
+			for name := range u.Query() {
+				a.Contains(doc.parameters(op, "query"), name, "undocumented parameter")
+			}
+
+			resp, err := http.Get(srv.URL + path)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			raw, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			status := strconv.Itoa(resp.StatusCode)
+			reached["GET "+template+" "+status] = true
+			responses, _ := op["responses"].(map[string]any)
+			documented, ok := responses[status].(map[string]any)
+			require.True(t, ok, "undocumented status %s: %s", status, raw)
+			response := doc.resolve(documented)
+
+			headers, _ := response["headers"].(map[string]any)
+			for name := range headers {
+				a.NotEmpty(resp.Header.Get(name), "header %s", name)
+			}
+			content, _ := response["content"].(map[string]any)
+			media, ok := content["application/json"].(map[string]any)
+			require.True(t, ok, "no documented content")
+			a.Equal("application/json", resp.Header.Get("Content-Type"))
+			var body any
+			require.NoError(t, json.Unmarshal(raw, &body))
+			a.Empty(doc.validate(media["schema"].(map[string]any), body, "body"))
+		})
+	}
+
+	paths, _ := doc["paths"].(map[string]any)
+	for template, item := range paths {
+		for method, op := range item.(map[string]any) {
+			responses, _ := op.(map[string]any)["responses"].(map[string]any)
+			for status := range responses {
+				key := strings.ToUpper(method) + " " + template + " " + status
+				assert.True(t, reached[key], "%s is never reached", key)
+			}
+		}
+	}
+}
+
+// TestOpenAPIPathParameters checks that the path parameters of every route are documented
+func TestOpenAPIPathParameters(t *testing.T) {
+	doc := loadSpec(t)
+	for _, r := range (&Server{}).routes() {
+		template, op, ok := doc.operation(r.method, r.path)
+		if !assert.True(t, ok, "%s %s is undocumented", r.method, r.path) {
+			continue
+		}
+		assert.Equal(t, r.path, template)
+		wildcards := make([]string, 0)
+		for _, part := range strings.Split(r.path, "/") {
+			if strings.HasPrefix(part, "{") {
+				wildcards = append(wildcards, strings.Trim(part, "{}"))
+			}
+		}
+		sort.Strings(wildcards)
+		assert.Equal(t, wildcards, doc.parameters(op, "path"), template)
+	}
+}
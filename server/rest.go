@@ -0,0 +1,167 @@
+package server
+
+import (
+	_ "embed"
+	"errors"
+	"net/http"
+	"strconv"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// openAPI is the OpenAPI 3 document of the REST API, served on /openapi.json
+//
+//go:embed openapi.json
+var openAPI []byte
+
+// routes are the operations of the REST API, each described in openapi.json
+func (s *Server) routes() []route {
+	return []route{
+		{http.MethodGet, "/v1/observations", s.handleObservations},
+		{http.MethodGet, "/v1/observations/{date}", s.handleObservation},
+		{http.MethodGet, "/v1/series", s.handleSeries},
+		{http.MethodGet, "/v1/series/{key}", s.handleSeriesPoints},
+	}
+}
+
+type route struct {
+	method  string
+	path    string
+	handler http.HandlerFunc
+}
+
+// observation is the REST representation of an observation, null values being missing
+type observation struct {
+	Date   string              `json:"date"`
+	Values map[string]*float64 `json:"values"`
+}
+
+type series struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	Unit  string `json:"unit"`
+}
+
+type point struct {
+	Date  string  `json:"date"`
+	Value float64 `json:"value"`
+}
+
+func newObservation(obs *boc.Observations) observation {
+	o := observation{Date: obs.D, Values: make(map[string]*float64)}
+	for _, key := range boc.AllSeries() {
+		o.Values[key] = obs.ValuePtr(key)
+	}
+	return o
+}
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPI)
+}
+
+func (s *Server) handleObservations(w http.ResponseWriter, r *http.Request) {
+	opts, err := restOptions(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	seq, err := s.client.Between(r.URL.Query().Get("from"), r.URL.Query().Get("to"), opts...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	observations := make([]observation, 0)
+	for _, obs := range seq {
+		observations = append(observations, newObservation(obs))
+	}
+	writeJSON(w, http.StatusOK, observations)
+}
+
+func (s *Server) handleObservation(w http.ResponseWriter, r *http.Request) {
+	opts, err := restOptions(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	obs, err := s.client.GetObservationForDate(r.PathValue("date"), opts...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newObservation(obs))
+}
+
+func (s *Server) handleSeries(w http.ResponseWriter, r *http.Request) {
+	list := make([]series, 0)
+	for _, key := range boc.AllSeries() {
+		label, _ := boc.SeriesLabel(key)
+		unit, _ := boc.SeriesUnit(key)
+		list = append(list, series{Key: key, Label: label, Unit: unit})
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleSeriesPoints(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	if _, ok := boc.SeriesLabel(key); !ok {
+		writeError(w, &boc.DataError{Series: key, Err: boc.ErrUnknownSeries})
+		return
+	}
+	opts, err := restOptions(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	seq, err := s.client.Between(r.URL.Query().Get("from"), r.URL.Query().Get("to"), opts...)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	points := make([]point, 0)
+	for date, obs := range seq {
+		if v, ok := obs.Value(key); ok {
+			points = append(points, point{Date: date, Value: v})
+		}
+	}
+	writeJSON(w, http.StatusOK, points)
+}
+
+// restOptions returns the lookup options of the query parameters
+func restOptions(r *http.Request) ([]boc.QueryOption, error) {
+	// a refresh of stale data is bounded by the request
+	opts := []boc.QueryOption{boc.Context(r.Context())}
+	ff := r.URL.Query().Get("forwardFill")
+	if ff == "" {
+		return opts, nil
+	}
+	fill, err := strconv.ParseBool(ff)
+	if err != nil {
+		return nil, &badRequestError{"invalid forwardFill: " + ff}
+	}
+	if fill {
+		opts = append(opts, boc.ForwardFill())
+	}
+	return opts, nil
+}
+
+type badRequestError struct {
+	message string
+}
+
+func (e *badRequestError) Error() string {
+	return e.message
+}
+
+// writeError writes err with the status code matching its cause
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var badRequest *badRequestError
+	switch {
+	case errors.As(err, &badRequest), errors.Is(err, boc.ErrInvalidDate), errors.Is(err, boc.ErrAmbiguousDate), errors.Is(err, boc.ErrUnknownSeries):
+		status = http.StatusBadRequest
+	case errors.Is(err, boc.ErrNoData), errors.Is(err, boc.ErrNoValue):
+		status = http.StatusNotFound
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
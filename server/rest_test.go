@@ -0,0 +1,108 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(raw)
+}
+
+func TestREST(t *testing.T) {
+	srv := newTestServer(t)
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		want   string
+	}{
+		{
+			name:   "observation",
+			path:   "/v1/observations/2022-05-27",
+			status: http.StatusOK,
+			want: `{"date": "2022-05-27", "values": {
+				"CDN.AVG.1YTO3Y.AVG": 2.62, "CDN.AVG.3YTO5Y.AVG": 2.66, "CDN.AVG.5YTO10Y.AVG": 2.75, "CDN.AVG.OVER.10.AVG": 2.88,
+				"BD.CDN.2YR.DQ.YLD": 2.61, "BD.CDN.3YR.DQ.YLD": 2.62, "BD.CDN.5YR.DQ.YLD": 2.67, "BD.CDN.7YR.DQ.YLD": 2.73,
+				"BD.CDN.10YR.DQ.YLD": 2.8, "BD.CDN.LONG.DQ.YLD": 2.87, "BD.CDN.RRB.DQ.YLD": null}}`,
+		},
+		{name: "holiday", path: "/v1/observations/2022-05-23", status: http.StatusNotFound},
+		{name: "invalid date", path: "/v1/observations/foo", status: http.StatusBadRequest},
+		{name: "invalid forward fill", path: "/v1/observations/2022-05-23?forwardFill=maybe", status: http.StatusBadRequest},
+		{name: "forward fill", path: "/v1/observations/2022-05-23?forwardFill=true", status: http.StatusOK},
+		{name: "observations", path: "/v1/observations?from=2022-05-30", status: http.StatusOK},
+		{name: "series", path: "/v1/series", status: http.StatusOK},
+		{
+			name:   "series points",
+			path:   "/v1/series/BD.CDN.RRB.DQ.YLD?from=2022-05-26&to=2022-05-30",
+			status: http.StatusOK,
+			want:   `[{"date": "2022-05-26", "value": 0.57}, {"date": "2022-05-30", "value": 0.63}]`,
+		},
+		{name: "unknown series", path: "/v1/series/foo", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := get(t, srv.URL+tt.path)
+			assert.Equal(t, tt.status, status, body)
+			if tt.want != "" {
+				assert.JSONEq(t, tt.want, body)
+			}
+			if status != http.StatusOK {
+				assert.Contains(t, body, `"error"`)
+			}
+		})
+	}
+}
+
+// TestOpenAPI checks that the document describes exactly the routes of the server
+func TestOpenAPI(t *testing.T) {
+	a := assert.New(t)
+	srv := newTestServer(t)
+	status, body := get(t, srv.URL+"/openapi.json")
+	a.Equal(http.StatusOK, status)
+
+	var doc struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &doc))
+	a.True(strings.HasPrefix(doc.OpenAPI, "3."))
+
+	documented := make([]string, 0)
+	for path, operations := range doc.Paths {
+		for method := range operations {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+	routes := make([]string, 0)
+	for _, r := range (&Server{}).routes() {
+		routes = append(routes, r.method+" "+r.path)
+	}
+	sort.Strings(documented)
+	sort.Strings(routes)
+	a.Equal(routes, documented)
+}
+
+func TestRESTVintage(t *testing.T) {
+	a := assert.New(t)
+	srv := newTestServer(t)
+	resp, err := http.Get(srv.URL + "/v1/series")
+	require.NoError(t, err)
+	resp.Body.Close()
+	a.Equal("2022-06-01", resp.Header.Get("X-Latest-Date"))
+	_, err = http.ParseTime(resp.Header.Get("Last-Modified"))
+	a.NoError(err, "the Date of the Valet response")
+}
@@ -0,0 +1,73 @@
+// Package server exposes the data of a client over HTTP, to be embedded in a
+// service or run on its own.
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+	"github.com/clauderoy790/bank-of-canada-interests-rates/feed"
+)
+
+// Server is an http.Handler serving the data of a client
+type Server struct {
+	client boc.BOCInterests
+	mux    *http.ServeMux
+	schema graphql.Schema
+	alerts *feed.AlertLog
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// New returns a Server serving the data of client. The REST API is mounted on
+// /v1 and described by /openapi.json, the GraphQL endpoint is mounted on /graphql
+// and the feeds of the latest observations on /feed.atom and /feed.rss.
+func New(client boc.BOCInterests, opts ...Option) (*Server, error) {
+	s := &Server{client: client, mux: http.NewServeMux()}
+	schema, err := s.newSchema()
+	if err != nil {
+		return nil, err
+	}
+	s.schema = schema
+	s.mux.HandleFunc("/graphql", s.handleGraphQL)
+	s.mux.HandleFunc("GET /openapi.json", s.handleOpenAPI)
+	s.mux.HandleFunc("GET /feed.atom", s.handleAtom)
+	s.mux.HandleFunc("GET /feed.rss", s.handleRSS)
+	for _, r := range s.routes() {
+		s.mux.HandleFunc(r.method+" "+r.path, s.withVintage(r.handler))
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// withVintage sets the Last-Modified header of the responses of handler to when
+// the Bank of Canada last updated the data, and X-Latest-Date to the date of
+// the latest observation, so that clients know how fresh the data is
+func (s *Server) withVintage(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if updated := s.client.LastUpdated(); !updated.IsZero() {
+			w.Header().Set("Last-Modified", updated.UTC().Format(http.TimeFormat))
+		}
+		if latest := s.client.LatestDate(); latest != "" {
+			w.Header().Set("X-Latest-Date", latest)
+		}
+		handler(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,20 @@
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clauderoy790/bank-of-canada-interests-rates/internal/testutil"
+)
+
+// newTestServer returns a Server serving a client loaded from the testdata fixture of the boc package
+func newTestServer(t *testing.T, opts ...Option) *httptest.Server {
+	t.Helper()
+	s, err := New(testutil.NewClient(t), opts...)
+	require.NoError(t, err)
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	return srv
+}
@@ -0,0 +1,80 @@
+package boc
+
+import (
+	"context"
+	"fmt"
+)
+
+// backfillBatchSize is the number of observations written at a time by Backfill
+const backfillBatchSize = 1000
+
+// Sink receives observations to write to an external system, such as a time
+// series database or a message broker
+type Sink interface {
+	// Write writes observations, in chronological order
+	Write(ctx context.Context, observations []Observations) error
+}
+
+// RevisionSink is a Sink that is also told about the values revised by a
+// refresh, in addition to receiving the observations of the revised dates
+type RevisionSink interface {
+	Sink
+	// WriteRevisions writes the values revised for already known dates
+	WriteRevisions(ctx context.Context, revisions []Revision) error
+}
+
+// writeSinks writes the observations added or revised by a refresh to the sinks.
+// Failures are logged since the refresh itself succeeded.
+func (b *bocInterests) writeSinks(ctx context.Context, ds *dataset, diff Diff) {
+	if len(b.sinks) == 0 {
+		return
+	}
+	dates := diff.changedDates()
+	observations := make([]Observations, 0, len(dates))
+	for _, date := range dates {
+		i, _ := ds.index(date)
+		observations = append(observations, *ds.observation(i))
+	}
+	for _, sink := range b.sinks {
+		if err := sink.Write(ctx, observations); err != nil {
+			b.logger.Error("sink write failed", "sink", fmt.Sprintf("%T", sink), "observations", len(observations), "error", err)
+		}
+		if rs, ok := sink.(RevisionSink); ok && len(diff.Revisions) > 0 {
+			if err := rs.WriteRevisions(ctx, diff.Revisions); err != nil {
+				b.logger.Error("sink write failed", "sink", fmt.Sprintf("%T", sink), "revisions", len(diff.Revisions), "error", err)
+			}
+		}
+	}
+}
+
+// Backfill writes the observations of client from start to end to sink, in
+// batches. An empty start or end leaves that side of the range open.
+func Backfill(ctx context.Context, client ObservationReader, sink Sink, start, end string) error {
+	seq, err := client.Between(start, end)
+	if err != nil {
+		return err
+	}
+	batch := make([]Observations, 0, backfillBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := sink.Write(ctx, batch); err != nil {
+			return fmt.Errorf("error backfilling from %s: %w", batch[0].D, err)
+		}
+		batch = batch[:0]
+		return nil
+	}
+	for _, obs := range seq {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch = append(batch, *obs)
+		if len(batch) == backfillBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
@@ -0,0 +1,140 @@
+// Package influx implements a boc.Sink writing the observations to InfluxDB 2
+// through its line protocol, with one measurement per series and one point
+// per date.
+package influx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// Writer is a boc.Sink writing to an InfluxDB bucket
+type Writer struct {
+	url        string
+	org        string
+	bucket     string
+	token      string
+	httpClient *http.Client
+	tags       map[string]string
+}
+
+var _ boc.Sink = (*Writer)(nil)
+
+// Option configures a Writer
+type Option func(*Writer)
+
+// WithHTTPClient sets the HTTP client used to reach InfluxDB, http.DefaultClient by default
+func WithHTTPClient(client *http.Client) Option {
+	return func(w *Writer) {
+		w.httpClient = client
+	}
+}
+
+// WithTag adds a tag to every point, source=boc is always set
+func WithTag(key, value string) Option {
+	return func(w *Writer) {
+		w.tags[key] = value
+	}
+}
+
+// New returns a Writer for the bucket of org on the InfluxDB server at serverURL,
+// authenticated with token
+func New(serverURL, org, bucket, token string, opts ...Option) *Writer {
+	w := &Writer{
+		url:        strings.TrimSuffix(serverURL, "/"),
+		org:        org,
+		bucket:     bucket,
+		token:      token,
+		httpClient: http.DefaultClient,
+		tags:       map[string]string{"source": "boc"},
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Write implements boc.Sink
+func (w *Writer) Write(ctx context.Context, observations []boc.Observations) error {
+	body, err := w.lines(observations)
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	query := url.Values{"org": {w.org}, "bucket": {w.bucket}, "precision": {"s"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url+"/api/v2/write?"+query.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating influxdb request: %w", err)
+	}
+	req.Header.Set("Authorization", "Token "+w.token)
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error writing to influxdb: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("error writing to influxdb: %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// lines encodes the observations in line protocol, one line per series value
+func (w *Writer) lines(observations []boc.Observations) ([]byte, error) {
+	tags := w.tagSet()
+	var buf bytes.Buffer
+	for _, obs := range observations {
+		day, err := time.Parse("2006-01-02", obs.D)
+		if err != nil {
+			return nil, fmt.Errorf("invalid observation date %q: %w", obs.D, err)
+		}
+		for _, key := range boc.AllSeries() {
+			value, ok := obs.Value(key)
+			if !ok {
+				continue
+			}
+			buf.WriteString(escape(key))
+			buf.WriteString(tags)
+			buf.WriteString(" value=")
+			buf.WriteString(strconv.FormatFloat(value, 'f', -1, 64))
+			buf.WriteByte(' ')
+			buf.WriteString(strconv.FormatInt(day.Unix(), 10))
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// tagSet returns the tags sorted by key, as recommended by InfluxDB
+func (w *Writer) tagSet() string {
+	keys := make([]string, 0, len(w.tags))
+	for key := range w.tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&sb, ",%s=%s", escape(key), escape(w.tags[key]))
+	}
+	return sb.String()
+}
+
+var escaper = strings.NewReplacer(",", `\,`, " ", `\ `, "=", `\=`)
+
+// escape escapes the commas, spaces and equal signs of measurement, tag keys and tag values
+func escape(s string) string {
+	return escaper.Replace(s)
+}
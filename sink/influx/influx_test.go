@@ -0,0 +1,79 @@
+package influx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+	"github.com/clauderoy790/bank-of-canada-interests-rates/internal/testutil"
+)
+
+type request struct {
+	query  string
+	header http.Header
+	lines  []string
+}
+
+func newInflux(t *testing.T, status int) (*httptest.Server, *[]request) {
+	t.Helper()
+	var requests []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/write", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, request{query: r.URL.RawQuery, header: r.Header, lines: strings.Split(strings.TrimSpace(string(body)), "\n")})
+		w.WriteHeader(status)
+		if status != http.StatusNoContent {
+			w.Write([]byte(`{"message":"unauthorized access"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestWrite(t *testing.T) {
+	a := assert.New(t)
+	srv, requests := newInflux(t, http.StatusNoContent)
+	w := New(srv.URL+"/", "my-org", "rates", "secret", WithTag("env", "prod test"))
+
+	obs := boc.Observations{D: "2022-05-24", Yield2Year: boc.Val{V: "2.57"}, Yield10Year: boc.Val{V: "2.78"}}
+	require.NoError(t, w.Write(context.Background(), []boc.Observations{obs}))
+	require.Len(t, *requests, 1)
+	req := (*requests)[0]
+	a.Equal("bucket=rates&org=my-org&precision=s", req.query)
+	a.Equal("Token secret", req.header.Get("Authorization"))
+	a.Equal([]string{
+		boc.SeriesYield2Year + `,env=prod\ test,source=boc value=2.57 1653350400`,
+		boc.SeriesYield10Year + `,env=prod\ test,source=boc value=2.78 1653350400`,
+	}, req.lines)
+
+	a.NoError(w.Write(context.Background(), nil))
+	a.Len(*requests, 1, "nothing to write")
+	a.Error(w.Write(context.Background(), []boc.Observations{{D: "foo"}}))
+}
+
+func TestWriteError(t *testing.T) {
+	srv, _ := newInflux(t, http.StatusUnauthorized)
+	w := New(srv.URL, "my-org", "rates", "wrong")
+	err := w.Write(context.Background(), []boc.Observations{{D: "2022-05-24", Yield2Year: boc.Val{V: "2.57"}}})
+	assert.ErrorContains(t, err, "401")
+	assert.ErrorContains(t, err, "unauthorized access")
+}
+
+func TestBackfill(t *testing.T) {
+	a := assert.New(t)
+	srv, requests := newInflux(t, http.StatusNoContent)
+	client := testutil.NewClient(t)
+
+	require.NoError(t, boc.Backfill(context.Background(), client, New(srv.URL, "my-org", "rates", "secret"), "2022-05-30", ""))
+	require.Len(t, *requests, 1)
+	lines := (*requests)[0].lines
+	a.Contains(lines, boc.SeriesYield10Year+",source=boc value=2.86 1653868800")
+	a.Contains(lines, boc.SeriesYieldRRB+",source=boc value=0.72 1654041600")
+}
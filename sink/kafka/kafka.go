@@ -0,0 +1,102 @@
+// Package kafka implements a boc.RevisionSink publishing the observations and
+// the revisions of each refresh as JSON events to a Kafka topic.
+//
+// The package does not depend on a Kafka client, Producer is satisfied in a
+// few lines by the writers of segmentio/kafka-go, franz-go or sarama.
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// Event types
+const (
+	// TypeObservation is the type of the events carrying the values of a date, new or revised
+	TypeObservation = "observation"
+	// TypeRevision is the type of the events carrying a value revised for an already known date
+	TypeRevision = "revision"
+)
+
+// Message is a Kafka record
+type Message struct {
+	Topic string
+	Key   []byte
+	Value []byte
+}
+
+// Producer publishes messages to Kafka
+type Producer interface {
+	Produce(ctx context.Context, messages ...Message) error
+}
+
+// Event is the JSON payload of the messages. The messages are keyed by date so
+// that the events of a date stay ordered within a partition.
+type Event struct {
+	Type string `json:"type"`
+	Date string `json:"date"`
+	// Values are the series values of an observation event, in percent
+	Values map[string]float64 `json:"values,omitempty"`
+	// Series, Old and New describe a revision event, Old or New are nil when missing
+	Series string   `json:"series,omitempty"`
+	Old    *float64 `json:"old,omitempty"`
+	New    *float64 `json:"new,omitempty"`
+}
+
+// Publisher is a boc.RevisionSink publishing to a topic
+type Publisher struct {
+	producer Producer
+	topic    string
+}
+
+var _ boc.RevisionSink = (*Publisher)(nil)
+
+// New returns a Publisher publishing to topic with producer
+func New(producer Producer, topic string) *Publisher {
+	return &Publisher{producer: producer, topic: topic}
+}
+
+// Write implements boc.Sink, publishing an observation event per date
+func (p *Publisher) Write(ctx context.Context, observations []boc.Observations) error {
+	events := make([]Event, 0, len(observations))
+	for _, obs := range observations {
+		event := Event{Type: TypeObservation, Date: obs.D, Values: make(map[string]float64)}
+		for _, key := range boc.AllSeries() {
+			if value, ok := obs.Value(key); ok {
+				event.Values[key] = value
+			}
+		}
+		events = append(events, event)
+	}
+	return p.publish(ctx, events)
+}
+
+// WriteRevisions implements boc.RevisionSink, publishing a revision event per value
+func (p *Publisher) WriteRevisions(ctx context.Context, revisions []boc.Revision) error {
+	events := make([]Event, 0, len(revisions))
+	for _, rev := range revisions {
+		events = append(events, Event{Type: TypeRevision, Date: rev.Date, Series: rev.Series, Old: boc.Val{V: rev.Old}.Ptr(), New: boc.Val{V: rev.New}.Ptr()})
+	}
+	return p.publish(ctx, events)
+}
+
+func (p *Publisher) publish(ctx context.Context, events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	messages := make([]Message, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("error encoding %s event: %w", event.Type, err)
+		}
+		messages = append(messages, Message{Topic: p.topic, Key: []byte(event.Date), Value: payload})
+	}
+	if err := p.producer.Produce(ctx, messages...); err != nil {
+		return fmt.Errorf("error publishing to %s: %w", p.topic, err)
+	}
+	return nil
+}
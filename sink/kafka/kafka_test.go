@@ -0,0 +1,52 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+type producerFunc func(ctx context.Context, messages ...Message) error
+
+func (f producerFunc) Produce(ctx context.Context, messages ...Message) error {
+	return f(ctx, messages...)
+}
+
+func TestPublisher(t *testing.T) {
+	a := assert.New(t)
+	var messages []Message
+	p := New(producerFunc(func(_ context.Context, msgs ...Message) error {
+		messages = append(messages, msgs...)
+		return nil
+	}), "rates")
+
+	require.NoError(t, p.Write(context.Background(), []boc.Observations{
+		{D: "2022-05-24", Yield2Year: boc.Val{V: "2.57"}, Yield10Year: boc.Val{V: "2.78"}},
+	}))
+	require.NoError(t, p.WriteRevisions(context.Background(), []boc.Revision{
+		{Date: "2022-05-24", Series: boc.SeriesYield2Year, Old: "2.57", New: "2.58"},
+		{Date: "2022-05-27", Series: boc.SeriesYieldRRB, Old: "", New: "0.60"},
+	}))
+	require.NoError(t, p.Write(context.Background(), nil))
+
+	require.Len(t, messages, 3)
+	a.Equal("rates", messages[0].Topic)
+	a.Equal("2022-05-24", string(messages[0].Key))
+	a.JSONEq(`{"type":"observation","date":"2022-05-24","values":{"`+boc.SeriesYield2Year+`":2.57,"`+boc.SeriesYield10Year+`":2.78}}`, string(messages[0].Value))
+	a.JSONEq(`{"type":"revision","date":"2022-05-24","series":"`+boc.SeriesYield2Year+`","old":2.57,"new":2.58}`, string(messages[1].Value))
+	a.JSONEq(`{"type":"revision","date":"2022-05-27","series":"`+boc.SeriesYieldRRB+`","new":0.6}`, string(messages[2].Value))
+	a.Equal("2022-05-27", string(messages[2].Key))
+}
+
+func TestPublisherError(t *testing.T) {
+	errBroker := errors.New("broker unavailable")
+	p := New(producerFunc(func(context.Context, ...Message) error { return errBroker }), "rates")
+	err := p.Write(context.Background(), []boc.Observations{{D: "2022-05-24"}})
+	assert.ErrorIs(t, err, errBroker)
+	assert.ErrorContains(t, err, "rates")
+}
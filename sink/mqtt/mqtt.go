@@ -0,0 +1,135 @@
+// Package mqtt implements a boc.Sink publishing the latest yields to MQTT
+// topics such as boc/yield/10y, for dashboards and home automation.
+//
+// The package does not depend on an MQTT client, Client is satisfied in a few
+// lines by the clients of eclipse/paho.mqtt.golang or paho.golang.
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// DefaultPrefix is prepended to the topics unless WithPrefix is used
+const DefaultPrefix = "boc/yield"
+
+// defaultTopics are the topics of the series, relative to the prefix
+var defaultTopics = map[string]string{
+	boc.SeriesYield2Year:  "2y",
+	boc.SeriesYield3Year:  "3y",
+	boc.SeriesYield5Year:  "5y",
+	boc.SeriesYield7Year:  "7y",
+	boc.SeriesYield10Year: "10y",
+	boc.SeriesYieldLong:   "long",
+	boc.SeriesYieldRRB:    "rrb",
+}
+
+// Client publishes a message to an MQTT broker
+type Client interface {
+	Publish(ctx context.Context, topic string, retained bool, payload []byte) error
+}
+
+// Publisher is a boc.Sink publishing the latest value of each series, one
+// topic per series, and the latest date to the date topic
+type Publisher struct {
+	client   Client
+	prefix   string
+	topics   map[string]string
+	retained bool
+	// keys are the series keys of topics in the order they are published
+	keys []string
+
+	mu sync.Mutex
+	// published are the dates of the values last published by topic
+	published map[string]string
+}
+
+var _ boc.Sink = (*Publisher)(nil)
+
+// Option configures a Publisher
+type Option func(*Publisher)
+
+// WithPrefix sets the prefix of the topics, DefaultPrefix by default
+func WithPrefix(prefix string) Option {
+	return func(p *Publisher) {
+		p.prefix = prefix
+	}
+}
+
+// WithTopic publishes the series to topic, relative to the prefix, in addition
+// to the benchmark yields published by default
+func WithTopic(seriesKey, topic string) Option {
+	return func(p *Publisher) {
+		if _, ok := p.topics[seriesKey]; !ok {
+			p.keys = append(p.keys, seriesKey)
+		}
+		p.topics[seriesKey] = topic
+	}
+}
+
+// WithRetained sets whether the messages are retained by the broker, so that
+// new subscribers get the latest value right away. It is true by default.
+func WithRetained(retained bool) Option {
+	return func(p *Publisher) {
+		p.retained = retained
+	}
+}
+
+// New returns a Publisher publishing with client
+func New(client Client, opts ...Option) *Publisher {
+	p := &Publisher{client: client, prefix: DefaultPrefix, topics: make(map[string]string, len(defaultTopics)), retained: true, published: make(map[string]string)}
+	for _, key := range boc.AllSeries() {
+		if topic, ok := defaultTopics[key]; ok {
+			p.topics[key] = topic
+			p.keys = append(p.keys, key)
+		}
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Write implements boc.Sink, publishing the last value of each series in
+// observations. A value older than the one last published to its topic is
+// skipped, so that the observations of revised past dates do not replace the
+// latest values, while a revised value of the latest date is published again.
+func (p *Publisher) Write(ctx context.Context, observations []boc.Observations) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, key := range p.keys {
+		for i := len(observations) - 1; i >= 0; i-- {
+			value, ok := observations[i].Value(key)
+			if !ok {
+				continue
+			}
+			if err := p.publish(ctx, p.topics[key], observations[i].D, strconv.FormatFloat(value, 'f', -1, 64)); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	if len(observations) == 0 {
+		return nil
+	}
+	latest := observations[len(observations)-1].D
+	return p.publish(ctx, "date", latest, latest)
+}
+
+// publish publishes payload for date to topic, relative to the prefix, unless
+// a value of a later date was already published to it
+func (p *Publisher) publish(ctx context.Context, topic, date, payload string) error {
+	if date < p.published[topic] {
+		return nil
+	}
+	full := p.prefix + "/" + topic
+	if err := p.client.Publish(ctx, full, p.retained, []byte(payload)); err != nil {
+		return fmt.Errorf("error publishing to %s: %w", full, err)
+	}
+	p.published[topic] = date
+	return nil
+}
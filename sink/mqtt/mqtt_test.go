@@ -0,0 +1,100 @@
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+type message struct {
+	topic    string
+	retained bool
+	payload  string
+}
+
+type clientFunc func(ctx context.Context, topic string, retained bool, payload []byte) error
+
+func (f clientFunc) Publish(ctx context.Context, topic string, retained bool, payload []byte) error {
+	return f(ctx, topic, retained, payload)
+}
+
+func recorder(messages *[]message) Client {
+	return clientFunc(func(_ context.Context, topic string, retained bool, payload []byte) error {
+		*messages = append(*messages, message{topic, retained, string(payload)})
+		return nil
+	})
+}
+
+func TestPublisher(t *testing.T) {
+	var messages []message
+	p := New(recorder(&messages))
+	require.NoError(t, p.Write(context.Background(), []boc.Observations{
+		{D: "2022-05-24", Yield2Year: boc.Val{V: "2.57"}},
+		{D: "2022-05-27", Average1To3Year: boc.Val{V: "2.62"}, Yield2Year: boc.Val{V: "2.61"}, Yield10Year: boc.Val{V: "2.80"}},
+	}))
+	assert.Equal(t, []message{
+		{"boc/yield/2y", true, "2.61"},
+		{"boc/yield/10y", true, "2.8"},
+		{"boc/yield/date", true, "2022-05-27"},
+	}, messages)
+
+	messages = nil
+	require.NoError(t, p.Write(context.Background(), nil))
+	assert.Empty(t, messages)
+}
+
+func TestPublisherRevisions(t *testing.T) {
+	var messages []message
+	p := New(recorder(&messages))
+	require.NoError(t, p.Write(context.Background(), []boc.Observations{
+		{D: "2022-05-26", Yield2Year: boc.Val{V: "2.55"}, Yield10Year: boc.Val{V: "2.76"}},
+		{D: "2022-05-27", Yield2Year: boc.Val{V: "2.61"}},
+	}))
+	assert.Equal(t, []message{
+		{"boc/yield/2y", true, "2.61"},
+		{"boc/yield/10y", true, "2.76"},
+		{"boc/yield/date", true, "2022-05-27"},
+	}, messages, "the last value of each series")
+
+	messages = nil
+	require.NoError(t, p.Write(context.Background(), []boc.Observations{
+		{D: "2022-05-24", Yield2Year: boc.Val{V: "2.58"}, Yield10Year: boc.Val{V: "2.79"}},
+	}))
+	assert.Empty(t, messages, "a revision of a past date is not published as current")
+
+	messages = nil
+	require.NoError(t, p.Write(context.Background(), []boc.Observations{
+		{D: "2022-05-27", Yield2Year: boc.Val{V: "2.62"}},
+	}))
+	assert.Equal(t, []message{
+		{"boc/yield/2y", true, "2.62"},
+		{"boc/yield/date", true, "2022-05-27"},
+	}, messages, "a revision of the latest date is published again")
+}
+
+func TestPublisherOptions(t *testing.T) {
+	var messages []message
+	p := New(recorder(&messages), WithPrefix("home/rates"), WithTopic(boc.SeriesAverage1To3Year, "avg/1-3y"),
+		WithTopic("BD.CDN.1YR.DQ.YLD", "1y"), WithRetained(false))
+	require.NoError(t, p.Write(context.Background(), []boc.Observations{
+		{D: "2022-05-27", Average1To3Year: boc.Val{V: "2.62"}, Extra: map[string]boc.Val{"BD.CDN.1YR.DQ.YLD": {V: "2.40"}}},
+	}))
+	assert.Equal(t, []message{
+		{"home/rates/avg/1-3y", false, "2.62"},
+		{"home/rates/1y", false, "2.4"},
+		{"home/rates/date", false, "2022-05-27"},
+	}, messages, "series outside AllSeries are published too")
+}
+
+func TestPublisherError(t *testing.T) {
+	errBroker := errors.New("not connected")
+	p := New(clientFunc(func(context.Context, string, bool, []byte) error { return errBroker }))
+	err := p.Write(context.Background(), []boc.Observations{{D: "2022-05-27", Yield2Year: boc.Val{V: "2.61"}}})
+	assert.ErrorIs(t, err, errBroker)
+	assert.ErrorContains(t, err, "boc/yield/2y")
+}
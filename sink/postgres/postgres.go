@@ -0,0 +1,212 @@
+// Package postgres implements a boc.Sink upserting the observations into a
+// normalized PostgreSQL table, one row per date and series. The table can be
+// turned into a TimescaleDB hypertable with WithHypertable.
+//
+// The package only depends on database/sql, open the *sql.DB with the driver
+// of your choice (pgx, lib/pq).
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+const (
+	// DefaultTable is the name of the observations table unless WithTable is used
+	DefaultTable = "boc_observations"
+	// batchSize is the number of rows upserted or deleted per statement, well under the
+	// limit of 65535 parameters of PostgreSQL
+	batchSize = 1000
+)
+
+// Writer is a boc.Sink writing to PostgreSQL
+type Writer struct {
+	db         *sql.DB
+	table      string
+	hypertable bool
+}
+
+var _ boc.RevisionSink = (*Writer)(nil)
+
+// Option configures a Writer
+type Option func(*Writer)
+
+// WithTable sets the name of the observations table, DefaultTable by default.
+// The migrations are recorded in a table of the same name suffixed with _migrations.
+func WithTable(name string) Option {
+	return func(w *Writer) {
+		w.table = name
+	}
+}
+
+// WithHypertable makes Migrate convert the observations table into a
+// TimescaleDB hypertable partitioned by date
+func WithHypertable() Option {
+	return func(w *Writer) {
+		w.hypertable = true
+	}
+}
+
+// New returns a Writer using db. Call Migrate before the first Write.
+func New(db *sql.DB, opts ...Option) *Writer {
+	w := &Writer{db: db, table: DefaultTable}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// migrations are applied in order, the version of a migration is its index plus one.
+// %[1]s is replaced by the quoted table name and %[2]s by the unquoted table name.
+var migrations = []string{
+	`CREATE TABLE IF NOT EXISTS %[1]s (
+	date DATE NOT NULL,
+	series_key TEXT NOT NULL,
+	value DOUBLE PRECISION NOT NULL,
+	PRIMARY KEY (series_key, date)
+)`,
+	`CREATE INDEX IF NOT EXISTS "%[2]s_date_idx" ON %[1]s (date)`,
+}
+
+// Migrate creates or updates the schema, applying each pending migration in
+// its own transaction
+func (w *Writer) Migrate(ctx context.Context) error {
+	versions := quoteIdent(w.table + "_migrations")
+	if _, err := w.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+versions+` (
+	version INTEGER PRIMARY KEY,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`); err != nil {
+		return fmt.Errorf("error creating migrations table: %w", err)
+	}
+	var current int
+	if err := w.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM `+versions).Scan(&current); err != nil {
+		return fmt.Errorf("error reading schema version: %w", err)
+	}
+	for i := current; i < len(migrations); i++ {
+		if err := w.migrate(ctx, versions, i+1, fmt.Sprintf(migrations[i], quoteIdent(w.table), w.table)); err != nil {
+			return fmt.Errorf("error applying migration %d: %w", i+1, err)
+		}
+	}
+	if w.hypertable {
+		if _, err := w.db.ExecContext(ctx, `SELECT create_hypertable($1, 'date', if_not_exists => TRUE, migrate_data => TRUE)`, w.table); err != nil {
+			return fmt.Errorf("error creating hypertable: %w", err)
+		}
+	}
+	return nil
+}
+
+func (w *Writer) migrate(ctx context.Context, versions string, version int, stmt string) error {
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, stmt); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO `+versions+` (version) VALUES ($1)`, version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Write implements boc.Sink, upserting the values in one transaction. The
+// rows of the values a revision cleared are deleted by WriteRevisions.
+func (w *Writer) Write(ctx context.Context, observations []boc.Observations) error {
+	var rows []any
+	for _, obs := range observations {
+		for _, key := range boc.AllSeries() {
+			if value, ok := obs.Value(key); ok {
+				rows = append(rows, obs.D, key, value)
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+	for start := 0; start < len(rows); start += batchSize * 3 {
+		batch := rows[start:min(start+batchSize*3, len(rows))]
+		if _, err := tx.ExecContext(ctx, w.upsert(len(batch)/3), batch...); err != nil {
+			return fmt.Errorf("error upserting observations: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing observations: %w", err)
+	}
+	return nil
+}
+
+// WriteRevisions implements boc.RevisionSink, deleting in one transaction the
+// rows of the values the revisions cleared. The other revisions are upserted by Write.
+func (w *Writer) WriteRevisions(ctx context.Context, revisions []boc.Revision) error {
+	var cleared []any
+	for _, rev := range revisions {
+		if !(boc.Val{V: rev.New}).Valid() {
+			cleared = append(cleared, rev.Date, rev.Series)
+		}
+	}
+	if len(cleared) == 0 {
+		return nil
+	}
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+	for start := 0; start < len(cleared); start += batchSize * 2 {
+		batch := cleared[start:min(start+batchSize*2, len(cleared))]
+		if _, err := tx.ExecContext(ctx, w.delete(len(batch)/2), batch...); err != nil {
+			return fmt.Errorf("error deleting cleared values: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing revisions: %w", err)
+	}
+	return nil
+}
+
+// delete returns the statement deleting the rows of n dates and series keys
+func (w *Writer) delete(n int) string {
+	var sb strings.Builder
+	sb.WriteString("DELETE FROM ")
+	sb.WriteString(quoteIdent(w.table))
+	sb.WriteString(" WHERE (date, series_key) IN (")
+	for i := range n {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "($%d::date, $%d)", i*2+1, i*2+2)
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+// upsert returns the statement upserting n rows
+func (w *Writer) upsert(n int) string {
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO ")
+	sb.WriteString(quoteIdent(w.table))
+	sb.WriteString(" (date, series_key, value) VALUES ")
+	for i := range n {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "($%d, $%d, $%d)", i*3+1, i*3+2, i*3+3)
+	}
+	sb.WriteString(" ON CONFLICT (series_key, date) DO UPDATE SET value = EXCLUDED.value")
+	return sb.String()
+}
+
+// quoteIdent quotes a PostgreSQL identifier
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
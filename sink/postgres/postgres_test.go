@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+	"github.com/clauderoy790/bank-of-canada-interests-rates/internal/testutil"
+)
+
+// fakeDB is a database/sql driver recording the statements it executes
+type fakeDB struct {
+	mu        sync.Mutex
+	version   int64
+	execs     []string
+	args      [][]driver.Value
+	commits   int
+	rollbacks int
+	failOn    string
+}
+
+func (f *fakeDB) Open(string) (driver.Conn, error) { return fakeConn{f}, nil }
+
+type fakeConn struct{ db *fakeDB }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c.db, query}, nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return fakeTx(c), nil }
+
+type fakeTx struct{ db *fakeDB }
+
+func (t fakeTx) Commit() error {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+	t.db.commits++
+	return nil
+}
+
+func (t fakeTx) Rollback() error {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+	t.db.rollbacks++
+	return nil
+}
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if s.db.failOn != "" && strings.Contains(s.query, s.db.failOn) {
+		return nil, errors.New("boom")
+	}
+	s.db.execs = append(s.db.execs, s.query)
+	s.db.args = append(s.db.args, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	return &fakeRows{value: s.db.version}, nil
+}
+
+type fakeRows struct {
+	value int64
+	done  bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"version"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+func newFakeDB(t *testing.T) (*sql.DB, *fakeDB) {
+	t.Helper()
+	fake := new(fakeDB)
+	db := sql.OpenDB(connector{fake})
+	t.Cleanup(func() { db.Close() })
+	return db, fake
+}
+
+type connector struct{ db *fakeDB }
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return fakeConn{c.db}, nil }
+func (c connector) Driver() driver.Driver                        { return c.db }
+
+func TestMigrate(t *testing.T) {
+	a := assert.New(t)
+	db, fake := newFakeDB(t)
+
+	require.NoError(t, New(db, WithTable("rates")).Migrate(context.Background()))
+	require.Len(t, fake.execs, 5)
+	a.Contains(fake.execs[0], `CREATE TABLE IF NOT EXISTS "rates_migrations"`)
+	a.Contains(fake.execs[1], `CREATE TABLE IF NOT EXISTS "rates"`)
+	a.Equal([]driver.Value{int64(1)}, fake.args[2])
+	a.Contains(fake.execs[3], `"rates_date_idx" ON "rates"`)
+	a.Equal([]driver.Value{int64(2)}, fake.args[4])
+	a.Equal(2, fake.commits)
+
+	fake.execs, fake.version = nil, int64(len(migrations))
+	require.NoError(t, New(db, WithHypertable()).Migrate(context.Background()))
+	require.Len(t, fake.execs, 2, "no pending migration")
+	a.Contains(fake.execs[1], "create_hypertable")
+	a.Equal([]driver.Value{DefaultTable}, fake.args[len(fake.args)-1])
+
+	fake.version, fake.failOn = 0, "CREATE INDEX"
+	a.ErrorContains(New(db).Migrate(context.Background()), "migration 2")
+}
+
+func TestWrite(t *testing.T) {
+	a := assert.New(t)
+	db, fake := newFakeDB(t)
+	w := New(db)
+
+	require.NoError(t, w.Write(context.Background(), []boc.Observations{
+		{D: "2022-05-24", Yield2Year: boc.Val{V: "2.57"}, Yield10Year: boc.Val{V: "2.78"}},
+		{D: "2022-05-25", Yield2Year: boc.Val{V: "2.53"}},
+	}))
+	require.Len(t, fake.execs, 1, "the missing values are not deleted")
+	a.Equal(`INSERT INTO "boc_observations" (date, series_key, value) VALUES ($1, $2, $3), ($4, $5, $6), ($7, $8, $9)`+
+		` ON CONFLICT (series_key, date) DO UPDATE SET value = EXCLUDED.value`, fake.execs[0])
+	a.Equal([]driver.Value{
+		"2022-05-24", boc.SeriesYield2Year, 2.57,
+		"2022-05-24", boc.SeriesYield10Year, 2.78,
+		"2022-05-25", boc.SeriesYield2Year, 2.53,
+	}, fake.args[0])
+	a.Equal(1, fake.commits)
+
+	require.NoError(t, w.Write(context.Background(), []boc.Observations{{D: "2022-05-23"}}))
+	a.Len(fake.execs, 1, "nothing to write")
+
+	fake.failOn = "INSERT"
+	a.Error(w.Write(context.Background(), []boc.Observations{{D: "2022-05-24", Yield2Year: boc.Val{V: "2.57"}}}))
+	a.Equal(1, fake.commits)
+}
+
+func TestWriteRevisions(t *testing.T) {
+	a := assert.New(t)
+	db, fake := newFakeDB(t)
+	w := New(db)
+
+	require.NoError(t, w.WriteRevisions(context.Background(), []boc.Revision{
+		{Date: "2022-05-24", Series: boc.SeriesYield2Year, Old: "2.57", New: "2.58"},
+		{Date: "2022-05-24", Series: boc.SeriesYieldRRB, Old: "0.61", New: ""},
+		{Date: "2022-05-25", Series: boc.SeriesYield10Year, Old: "2.78", New: "n/a"},
+	}))
+	require.Len(t, fake.execs, 1)
+	a.Equal(`DELETE FROM "boc_observations" WHERE (date, series_key) IN (($1::date, $2), ($3::date, $4))`, fake.execs[0])
+	a.Equal([]driver.Value{"2022-05-24", boc.SeriesYieldRRB, "2022-05-25", boc.SeriesYield10Year}, fake.args[0],
+		"only the cleared values are deleted")
+	a.Equal(1, fake.commits)
+
+	require.NoError(t, w.WriteRevisions(context.Background(), []boc.Revision{{Date: "2022-05-24", Series: boc.SeriesYield2Year, Old: "2.57", New: "2.58"}}))
+	a.Len(fake.execs, 1, "nothing cleared")
+
+	fake.failOn = "DELETE"
+	a.ErrorContains(w.WriteRevisions(context.Background(), []boc.Revision{{Date: "2022-05-24", Series: boc.SeriesYieldRRB, Old: "0.61"}}),
+		"error deleting cleared values")
+	a.Equal(1, fake.commits)
+}
+
+func TestWriteSubset(t *testing.T) {
+	a := assert.New(t)
+	db, fake := newFakeDB(t)
+	// the Valet API only returns the requested series
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(testutil.Fixture(t), &payload))
+	for _, obs := range payload["observations"].([]any) {
+		for key := range obs.(map[string]any) {
+			if key != "d" && key != boc.SeriesYield2Year {
+				delete(obs.(map[string]any), key)
+			}
+		}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(payload)
+	}))
+	t.Cleanup(srv.Close)
+	client, err := boc.NewBOCInterests(boc.WithBaseURL(srv.URL), boc.WithSeries(boc.SeriesYield2Year))
+	require.NoError(t, err)
+
+	// the rows of the other series written by another client are kept
+	require.NoError(t, boc.Backfill(context.Background(), client, New(db), "", ""))
+	require.NotEmpty(t, fake.execs)
+	for i, exec := range fake.execs {
+		a.True(strings.HasPrefix(exec, "INSERT"), exec)
+		for j := 1; j < len(fake.args[i]); j += 3 {
+			a.Equal(boc.SeriesYield2Year, fake.args[i][j])
+		}
+	}
+}
+
+func TestWriteBatches(t *testing.T) {
+	db, fake := newFakeDB(t)
+	observations := make([]boc.Observations, batchSize+1)
+	for i := range observations {
+		observations[i] = boc.Observations{D: "2022-05-24", Yield2Year: boc.Val{V: "2.57"}}
+	}
+	require.NoError(t, New(db).Write(context.Background(), observations))
+	require.Len(t, fake.args, 2)
+	assert.Len(t, fake.args[0], batchSize*3)
+	assert.Len(t, fake.args[1], 3)
+	assert.Equal(t, 1, fake.commits, "all the batches are in one transaction")
+
+	revisions := make([]boc.Revision, batchSize+1)
+	for i := range revisions {
+		revisions[i] = boc.Revision{Date: "2022-05-24", Series: boc.SeriesYield2Year, Old: "2.57"}
+	}
+	require.NoError(t, New(db).WriteRevisions(context.Background(), revisions))
+	require.Len(t, fake.args, 4)
+	assert.Len(t, fake.args[2], batchSize*2)
+	assert.Len(t, fake.args[3], 2)
+	assert.Equal(t, 2, fake.commits)
+}
@@ -0,0 +1,101 @@
+package boc
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingSink struct {
+	writes [][]Observations
+	err    error
+}
+
+func (s *recordingSink) Write(_ context.Context, observations []Observations) error {
+	s.writes = append(s.writes, append([]Observations(nil), observations...))
+	return s.err
+}
+
+type revisionSink struct {
+	recordingSink
+	revisions []Revision
+}
+
+func (s *revisionSink) WriteRevisions(_ context.Context, revisions []Revision) error {
+	s.revisions = append(s.revisions, revisions...)
+	return nil
+}
+
+func TestWithSink(t *testing.T) {
+	a := assert.New(t)
+	data := readFixture(t)
+	srv := newDataServer(t, func() *BOCData { return data })
+	sink := new(revisionSink)
+	failing := &recordingSink{err: errors.New("unavailable")}
+
+	b := newBOCInterests(WithBaseURL(srv.URL), WithFullRefresh(), WithSink(failing), WithSink(sink))
+	a.NoError(b.load(context.Background()))
+	a.NoError(b.Refresh(context.Background()))
+	a.Empty(sink.writes, "nothing written without change")
+
+	updated := readFixture(t)
+	updated.Observations[1].Yield2Year.V = "2.58"
+	updated.Observations = append(updated.Observations, Observations{D: "2022-06-02", Yield2Year: Val{V: "2.80"}})
+	data = updated
+	a.NoError(b.Refresh(context.Background()), "sink failures do not fail the refresh")
+
+	a.Len(failing.writes, 1)
+	a.Len(sink.writes, 1)
+	a.Len(sink.writes[0], 2)
+	a.Equal("2022-05-24", sink.writes[0][0].D)
+	a.Equal("2.58", sink.writes[0][0].Yield2Year.V)
+	a.Equal("2022-06-02", sink.writes[0][1].D)
+	a.Equal([]Revision{{Date: "2022-05-24", Series: SeriesYield2Year, Old: "2.57", New: "2.58"}}, sink.revisions)
+}
+
+// betweenReader is an ObservationReader only implementing Between
+type betweenReader struct {
+	ObservationReader
+	observations []Observations
+}
+
+func (r betweenReader) Between(start, end string, opts ...QueryOption) (iter.Seq2[string, *Observations], error) {
+	return func(yield func(string, *Observations) bool) {
+		for i := range r.observations {
+			if !yield(r.observations[i].D, &r.observations[i]) {
+				return
+			}
+		}
+	}, nil
+}
+
+func TestBackfillBatches(t *testing.T) {
+	observations := make([]Observations, backfillBatchSize+1)
+	sink := new(recordingSink)
+	assert.NoError(t, Backfill(context.Background(), betweenReader{observations: observations}, sink, "", ""))
+	assert.Len(t, sink.writes, 2)
+	assert.Len(t, sink.writes[0], backfillBatchSize)
+	assert.Len(t, sink.writes[1], 1)
+}
+
+func TestBackfill(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	sink := new(recordingSink)
+
+	a.NoError(Backfill(context.Background(), b, sink, "2022-05-25", ""))
+	a.Len(sink.writes, 1)
+	a.Len(sink.writes[0], 6)
+	a.Equal("2022-05-25", sink.writes[0][0].D)
+
+	sink.err = errors.New("unavailable")
+	a.ErrorIs(Backfill(context.Background(), b, sink, "", ""), sink.err)
+	a.ErrorIs(Backfill(context.Background(), b, sink, "foo", ""), ErrInvalidDate)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	a.ErrorIs(Backfill(ctx, b, new(recordingSink), "", ""), context.Canceled)
+}
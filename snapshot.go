@@ -0,0 +1,84 @@
+package boc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+// snapshotVersion is bumped when the snapshot format changes. Version 1
+// snapshots, without checksum, are still read.
+const snapshotVersion = 2
+
+type snapshot struct {
+	Version int
+	// Payload is the gob encoded data, since version 2
+	Payload []byte
+	// Checksum is the SHA-256 of Payload, since version 2
+	Checksum []byte
+	// Data is the data of the version 1 snapshots
+	Data *BOCData
+}
+
+// Save implements BOCInterests
+func (b *bocInterests) Save(w io.Writer) error {
+	return writeSnapshot(w, b.current().bocData())
+}
+
+// LoadSnapshot provides an interface to the data of a snapshot written by Save,
+// without fetching it from the Bank of Canada. Refresh fetches fresh data.
+// It fails with ErrCorruptSnapshot if the snapshot was damaged.
+func LoadSnapshot(r io.Reader, opts ...Option) (BOCInterests, error) {
+	data, err := readSnapshot(r)
+	if err != nil {
+		return nil, err
+	}
+	boc := newBOCInterests(opts...)
+	boc.ds.Store(boc.newDataset(context.Background(), data))
+	return boc, nil
+}
+
+// writeSnapshot writes the data with its checksum, so that readSnapshot detects
+// a truncated or damaged file instead of serving part of the data
+func writeSnapshot(w io.Writer, data *BOCData) error {
+	payload := new(bytes.Buffer)
+	if err := gob.NewEncoder(payload).Encode(data); err != nil {
+		return fmt.Errorf("error encoding snapshot: %w", err)
+	}
+	checksum := sha256.Sum256(payload.Bytes())
+	s := snapshot{Version: snapshotVersion, Payload: payload.Bytes(), Checksum: checksum[:]}
+	if err := gob.NewEncoder(w).Encode(&s); err != nil {
+		return fmt.Errorf("error encoding snapshot: %w", err)
+	}
+	return nil
+}
+
+func readSnapshot(r io.Reader) (*BOCData, error) {
+	s := new(snapshot)
+	if err := gob.NewDecoder(r).Decode(s); err != nil {
+		return nil, fmt.Errorf("error decoding snapshot: %w: %w", ErrCorruptSnapshot, err)
+	}
+	switch s.Version {
+	case 1:
+		if s.Data == nil {
+			return nil, fmt.Errorf("snapshot has no data")
+		}
+		return s.Data, nil
+	case snapshotVersion:
+		if len(s.Payload) == 0 {
+			return nil, fmt.Errorf("snapshot has no data")
+		}
+		if checksum := sha256.Sum256(s.Payload); !bytes.Equal(checksum[:], s.Checksum) {
+			return nil, fmt.Errorf("%w: checksum mismatch", ErrCorruptSnapshot)
+		}
+		data := new(BOCData)
+		if err := gob.NewDecoder(bytes.NewReader(s.Payload)).Decode(data); err != nil {
+			return nil, fmt.Errorf("error decoding snapshot: %w: %w", ErrCorruptSnapshot, err)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("unsupported snapshot version: %d", s.Version)
+}
@@ -0,0 +1,73 @@
+package boc
+
+import (
+	"bytes"
+	"encoding/gob"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, b.Save(buf))
+
+	loaded, err := LoadSnapshot(buf)
+	require.NoError(t, err)
+	a.Equal(b.Observations(), loaded.Observations())
+	a.Equal(b.SeriesDetail(), loaded.SeriesDetail())
+	a.Equal(b.GroupDetail(), loaded.GroupDetail())
+
+	v, err := loaded.SeriesValue("2022-05-25", SeriesYield2Year)
+	a.NoError(err)
+	a.Equal(2.53, v)
+	a.WithinDuration(b.Staleness().FetchedAt, loaded.Staleness().FetchedAt, 0, "the fetch time is saved")
+	a.False(loaded.Staleness().FetchedAt.IsZero())
+}
+
+func TestSnapshotIntegrity(t *testing.T) {
+	a := assert.New(t)
+	buf := new(bytes.Buffer)
+	require.NoError(t, newTestBOC(t).Save(buf))
+	saved := buf.Bytes()
+
+	_, err := LoadSnapshot(bytes.NewReader(saved[:len(saved)/2]))
+	a.ErrorIs(err, ErrCorruptSnapshot, "truncated")
+
+	s := new(snapshot)
+	require.NoError(t, gob.NewDecoder(bytes.NewReader(saved)).Decode(s))
+	s.Payload[len(s.Payload)/2] ^= 0xff
+	buf.Reset()
+	require.NoError(t, gob.NewEncoder(buf).Encode(s))
+	_, err = LoadSnapshot(buf)
+	a.ErrorIs(err, ErrCorruptSnapshot)
+	a.ErrorContains(err, "checksum mismatch")
+
+	buf.Reset()
+	require.NoError(t, gob.NewEncoder(buf).Encode(&snapshot{Version: 1, Data: readFixture(t)}))
+	loaded, err := LoadSnapshot(buf)
+	require.NoError(t, err, "version 1 snapshots have no checksum")
+	a.Len(loaded.Observations(), 8)
+	a.True(loaded.Staleness().FetchedAt.IsZero())
+}
+
+func TestLoadSnapshotErrors(t *testing.T) {
+	a := assert.New(t)
+	_, err := LoadSnapshot(strings.NewReader("not a snapshot"))
+	a.Error(err)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, gob.NewEncoder(buf).Encode(&snapshot{Version: snapshotVersion + 1, Data: &BOCData{}}))
+	_, err = LoadSnapshot(buf)
+	a.ErrorContains(err, "unsupported snapshot version")
+
+	buf.Reset()
+	require.NoError(t, gob.NewEncoder(buf).Encode(&snapshot{Version: snapshotVersion}))
+	_, err = LoadSnapshot(buf)
+	a.ErrorContains(err, "snapshot has no data")
+}
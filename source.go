@@ -0,0 +1,144 @@
+package boc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// RateSource is a source of interest rates: the Bank of Canada client, or any
+// other source registered in Sources, e.g. FRED or the European Central Bank,
+// so that rates of several countries are compared through one API
+type RateSource interface {
+	// Refresh fetches the latest data of the source
+	Refresh(ctx context.Context) error
+	// Records returns the observations of the source in chronological order
+	Records() []Record
+	// RecordFor returns the observation of a formatted date, e.g. "2022-06-01",
+	// and false if the source has no data for it
+	RecordFor(date string) (Record, bool)
+	// Metadata describes the source and its series
+	Metadata() SourceMetadata
+}
+
+// SourceMetadata describes a RateSource
+type SourceMetadata struct {
+	Name        string
+	Description string
+	Link        string
+	// Attribution is the citation required to republish the data
+	Attribution Attribution
+	// Series are the details of the series by key
+	Series map[string]Detail
+}
+
+var _ RateSource = (*bocInterests)(nil)
+
+// Metadata implements RateSource
+func (b *bocInterests) Metadata() SourceMetadata {
+	group := b.GroupDetail()
+	name := "Bank of Canada"
+	if b.language == French {
+		name = "Banque du Canada"
+	}
+	return SourceMetadata{
+		Name:        name,
+		Description: group.Description,
+		Link:        group.Link,
+		Attribution: b.Attribution(),
+		Series:      b.SeriesDetails(),
+	}
+}
+
+// SourceSeries is a series of a source registered in Sources
+type SourceSeries struct {
+	Source string
+	Series string
+}
+
+// Sources is a registry of rate sources by name. It is safe for concurrent use.
+type Sources struct {
+	mu      sync.RWMutex
+	sources map[string]RateSource
+}
+
+// NewSources returns an empty registry
+func NewSources() *Sources {
+	return &Sources{sources: make(map[string]RateSource)}
+}
+
+// Register adds a source under a name, replacing the source of the same name
+func (s *Sources) Register(name string, source RateSource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sources[name] = source
+}
+
+// Names returns the names of the registered sources, sorted
+func (s *Sources) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.sources))
+	for name := range s.sources {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Source returns the source registered under a name
+func (s *Sources) Source(name string) (RateSource, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	source, ok := s.sources[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown rate source %q", name)
+	}
+	return source, nil
+}
+
+// Refresh refreshes every source, stopping at the first error
+func (s *Sources) Refresh(ctx context.Context) error {
+	for _, name := range s.Names() {
+		source, err := s.Source(name)
+		if err != nil {
+			return err
+		}
+		if err := source.Refresh(ctx); err != nil {
+			return fmt.Errorf("error refreshing %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Value returns the value of a series of a source for a formatted date, e.g. "2022-06-01"
+func (s *Sources) Value(series SourceSeries, date string) (float64, error) {
+	source, err := s.Source(series.Source)
+	if err != nil {
+		return 0, err
+	}
+	record, ok := source.RecordFor(date)
+	if !ok {
+		return 0, &DataError{Date: date, Err: noDataError(date)}
+	}
+	v, ok := record.Value(series.Series)
+	if !ok {
+		return 0, &DataError{Date: date, Series: series.Series, Err: ErrNoValue}
+	}
+	return v, nil
+}
+
+// Spread returns the value of series a minus series b for a formatted date, e.g.
+// the 10 year yield of Canada minus the one of the United States
+func (s *Sources) Spread(a, b SourceSeries, date string) (Bps, error) {
+	va, err := s.Value(a, date)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := s.Value(b, date)
+	if err != nil {
+		return 0, err
+	}
+	return ToBps(va - vb), nil
+}
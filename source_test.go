@@ -0,0 +1,80 @@
+package boc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource is a RateSource with fixed records
+type fakeSource struct {
+	records    []Record
+	refreshErr error
+	refreshes  int
+	lookups    int
+}
+
+func (f *fakeSource) Refresh(context.Context) error {
+	f.refreshes++
+	return f.refreshErr
+}
+
+func (f *fakeSource) Records() []Record        { panic("the lookups use RecordFor") }
+func (f *fakeSource) Metadata() SourceMetadata { return SourceMetadata{Name: "fake"} }
+
+func (f *fakeSource) RecordFor(date string) (Record, bool) {
+	f.lookups++
+	for _, r := range f.records {
+		if r.Date == date {
+			return r, true
+		}
+	}
+	return Record{}, false
+}
+
+func TestSources(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	us := &fakeSource{records: []Record{
+		{Date: "2022-05-31", Values: map[string]Val{"DGS10": {V: "2.85"}}},
+		{Date: "2022-06-01", Values: map[string]Val{"DGS10": {V: "2.94"}}},
+	}}
+	sources := NewSources()
+	sources.Register("boc", b)
+	sources.Register("fred", us)
+	a.Equal([]string{"boc", "fred"}, sources.Names())
+
+	cad := SourceSeries{Source: "boc", Series: SeriesYield10Year}
+	ust := SourceSeries{Source: "fred", Series: "DGS10"}
+	spread, err := sources.Spread(cad, ust, "2022-06-01")
+	require.NoError(t, err)
+	a.Equal(Bps(3), spread)
+	spread, err = sources.Spread(cad, ust, "2022-05-31")
+	require.NoError(t, err)
+	a.Equal(Bps(5), spread)
+
+	_, err = sources.Spread(cad, ust, "2022-05-30")
+	a.ErrorIs(err, ErrNoData)
+	_, err = sources.Value(SourceSeries{Source: "fred", Series: "DGS2"}, "2022-06-01")
+	a.ErrorIs(err, ErrNoValue)
+	_, err = sources.Value(SourceSeries{Source: "ecb"}, "2022-06-01")
+	a.ErrorContains(err, `unknown rate source "ecb"`)
+
+	a.Equal(4, us.lookups)
+
+	us.refreshErr = errors.New("boom")
+	a.ErrorContains(sources.Refresh(context.Background()), "error refreshing fred: boom")
+	a.Equal(1, us.refreshes)
+}
+
+func TestMetadata(t *testing.T) {
+	a := assert.New(t)
+	m := newTestBOC(t).Metadata()
+	a.Equal("Banque du Canada", m.Name)
+	a.Equal("https://www.bankofcanada.ca/terms/", m.Attribution.TermsURL)
+	a.Len(m.Series, 11)
+	a.NotEmpty(m.Link)
+}
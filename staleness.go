@@ -0,0 +1,38 @@
+package boc
+
+import "time"
+
+// Staleness reports how fresh the data in use is
+type Staleness struct {
+	// Stale reports whether the last refresh failed, the data in use being older
+	Stale bool
+	// FetchedAt is when the data in use was fetched, zero if unknown, e.g. loaded
+	// from a snapshot written before fetch times were saved
+	FetchedAt time.Time
+	// Err is the error of the last refresh if it failed
+	Err error
+	// now is the clock of the client, time.Now if nil
+	now func() time.Time
+}
+
+// Age returns how long ago the data in use was fetched by the clock of the
+// client, 0 if unknown
+func (s Staleness) Age() time.Duration {
+	if s.FetchedAt.IsZero() {
+		return 0
+	}
+	if s.now == nil {
+		return time.Since(s.FetchedAt)
+	}
+	return s.now().Sub(s.FetchedAt)
+}
+
+// Staleness implements BOCInterests
+func (b *bocInterests) Staleness() Staleness {
+	s := Staleness{FetchedAt: b.current().meta.FetchedAt, now: b.now}
+	if err := b.refreshErr.Load(); err != nil {
+		s.Stale = true
+		s.Err = *err
+	}
+	return s
+}
@@ -0,0 +1,57 @@
+package boc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaleIfError(t *testing.T) {
+	a := assert.New(t)
+	srv := newTestServer(t, http.StatusOK)
+	b := newBOCInterests(WithBaseURL(srv.URL), WithStaleIfError())
+	a.NoError(b.load(context.Background()))
+
+	s := b.Staleness()
+	a.False(s.Stale)
+	a.NoError(s.Err)
+	a.False(s.FetchedAt.IsZero())
+	a.Less(s.Age(), time.Minute)
+
+	b.baseURL = newTestServer(t, http.StatusBadGateway).URL
+	a.NoError(b.Refresh(context.Background()), "the stale data is kept")
+	s = b.Staleness()
+	a.True(s.Stale)
+	a.ErrorIs(s.Err, ErrBadStatus)
+	_, err := b.GetObservationForDate("2022-05-24")
+	a.NoError(err)
+
+	b.baseURL = srv.URL
+	a.NoError(b.Refresh(context.Background()))
+	s = b.Staleness()
+	a.False(s.Stale)
+	a.NoError(s.Err)
+}
+
+func TestStalenessWithoutOption(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	b.baseURL = newTestServer(t, http.StatusBadGateway).URL
+
+	a.ErrorIs(b.Refresh(context.Background()), ErrBadStatus)
+	a.True(b.Staleness().Stale)
+	a.Equal(time.Duration(0), Staleness{}.Age())
+}
+
+func TestStalenessAge(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	fetchedAt := b.current().meta.FetchedAt
+	a.False(fetchedAt.IsZero())
+	b.now = func() time.Time { return fetchedAt.Add(3 * time.Hour) }
+
+	a.Equal(3*time.Hour, b.Staleness().Age(), "the age follows the clock of the client")
+}
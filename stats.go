@@ -0,0 +1,97 @@
+package boc
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats describes the state of a client, e.g. to debug a long-running service
+type Stats struct {
+	// Observations is the number of dates of the data in use
+	Observations int
+	// FirstDate and LastDate are the bounds of the data in use, empty if it has no observation
+	FirstDate string
+	LastDate  string
+	// MemoryBytes estimates the memory held by the data in use and the last payload
+	MemoryBytes int64
+	// FetchedAt is when the data in use was fetched, see Staleness
+	FetchedAt time.Time
+	// Fetches is the number of fetches since the client was created, including
+	// the ones served from the cache
+	Fetches int64
+	// FetchErrors is the number of those fetches that failed
+	FetchErrors int64
+	// CacheHits and CacheMisses count the reads of the cache of WithCache
+	CacheHits   int64
+	CacheMisses int64
+	// LastError is the error of the latest failed fetch, nil if none failed
+	LastError error
+	// LastErrorAt is when LastError happened
+	LastErrorAt time.Time
+}
+
+// counters are the fetch statistics of a client, see Stats
+type counters struct {
+	fetches     atomic.Int64
+	fetchErrors atomic.Int64
+	cacheHits   atomic.Int64
+	cacheMisses atomic.Int64
+	lastErr     atomic.Pointer[fetchFailure]
+}
+
+type fetchFailure struct {
+	err error
+	at  time.Time
+}
+
+// record counts a fetch that returned err
+func (c *counters) record(err error) {
+	c.fetches.Add(1)
+	if err != nil {
+		c.fetchErrors.Add(1)
+		c.lastErr.Store(&fetchFailure{err: err, at: time.Now()})
+	}
+}
+
+// Stats implements BOCInterests
+func (b *bocInterests) Stats() Stats {
+	ds := b.current()
+	s := Stats{
+		Observations: len(ds.dates),
+		MemoryBytes:  ds.size(),
+		FetchedAt:    ds.meta.FetchedAt,
+		Fetches:      b.counters.fetches.Load(),
+		FetchErrors:  b.counters.fetchErrors.Load(),
+		CacheHits:    b.counters.cacheHits.Load(),
+		CacheMisses:  b.counters.cacheMisses.Load(),
+	}
+	if n := len(ds.dates); n > 0 {
+		s.FirstDate, s.LastDate = ds.dates[0], ds.dates[n-1]
+	}
+	if raw := b.raw.Load(); raw != nil {
+		s.MemoryBytes += int64(len(*raw))
+	}
+	if failure := b.counters.lastErr.Load(); failure != nil {
+		s.LastError, s.LastErrorAt = failure.err, failure.at
+	}
+	return s
+}
+
+// size estimates the bytes held by the dataset: the dates, the columns and the
+// values of the series without a field, the metadata being negligible
+func (d *dataset) size() int64 {
+	const (
+		stringHeader = 16
+		mapEntry     = 48
+	)
+	n := int64(len(d.dates)) * (stringHeader + int64(len("2006-01-02")))
+	for _, column := range d.columns {
+		n += int64(len(column)) * 8
+	}
+	for _, values := range d.extra {
+		for key, v := range values {
+			n += mapEntry + int64(len(key)+len(v.V))
+		}
+	}
+	return n
+}
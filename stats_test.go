@@ -0,0 +1,44 @@
+package boc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clauderoy790/bank-of-canada-interests-rates/cache"
+)
+
+func TestStats(t *testing.T) {
+	a := assert.New(t)
+	srv, _ := newFlakyServer(t, 0)
+	store := cache.NewMemory()
+	b := newBOCInterests(WithBaseURL(srv.URL), WithCache(store, time.Hour))
+	require.NoError(t, b.load(context.Background()))
+	require.NoError(t, b.load(context.Background()))
+
+	s := b.Stats()
+	a.Equal(8, s.Observations)
+	a.Equal("2022-05-20", s.FirstDate)
+	a.Equal("2022-06-01", s.LastDate)
+	a.Greater(s.MemoryBytes, int64(len(b.RawJSON())))
+	a.False(s.FetchedAt.IsZero())
+	a.Equal(int64(2), s.Fetches)
+	a.Zero(s.FetchErrors)
+	a.Equal(int64(1), s.CacheHits)
+	a.Equal(int64(1), s.CacheMisses)
+	a.NoError(s.LastError)
+
+	b.baseURL = newTestServer(t, http.StatusServiceUnavailable).URL
+	b.cache = nil
+	a.Error(b.Refresh(context.Background()))
+	s = b.Stats()
+	a.Equal(int64(3), s.Fetches)
+	a.Equal(int64(1), s.FetchErrors)
+	a.ErrorIs(s.LastError, ErrBadStatus)
+	a.False(s.LastErrorAt.IsZero())
+	a.Equal(8, s.Observations, "the data in use is kept")
+}
@@ -0,0 +1,147 @@
+package boc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Storage persists the data of a client between runs. Implement it to keep
+// the data in a database; MemoryStorage and FileStorage are provided.
+type Storage interface {
+	// Get returns the saved data, or nil if nothing was saved yet
+	Get(ctx context.Context) (*BOCData, error)
+	// Put replaces the saved data
+	Put(ctx context.Context, data *BOCData) error
+	// Observation returns the saved observation of a date formatted as YYYY-MM-DD,
+	// or an error wrapping ErrNoData if there is none
+	Observation(ctx context.Context, date string) (*Observations, error)
+}
+
+// MemoryStorage is a Storage keeping the data in memory
+type MemoryStorage struct {
+	mu   sync.RWMutex
+	data *BOCData
+}
+
+// NewMemoryStorage returns an empty MemoryStorage
+func NewMemoryStorage() *MemoryStorage {
+	return new(MemoryStorage)
+}
+
+// Get implements Storage
+func (s *MemoryStorage) Get(context.Context) (*BOCData, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data, nil
+}
+
+// Put implements Storage
+func (s *MemoryStorage) Put(_ context.Context, data *BOCData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = data
+	return nil
+}
+
+// Observation implements Storage
+func (s *MemoryStorage) Observation(_ context.Context, date string) (*Observations, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return storedObservation(s.data, date)
+}
+
+// FileStorage is a Storage keeping the data in a snapshot file, in the format written by Save
+type FileStorage struct {
+	path string
+}
+
+// NewFileStorage returns a FileStorage using the file at path, which is created on the first Put
+func NewFileStorage(path string) *FileStorage {
+	return &FileStorage{path: path}
+}
+
+// Get implements Storage
+func (s *FileStorage) Get(context.Context) (*BOCData, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening storage file: %w", err)
+	}
+	defer f.Close()
+	return readSnapshot(f)
+}
+
+// Put implements Storage. The file is replaced atomically.
+func (s *FileStorage) Put(_ context.Context, data *BOCData) error {
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating storage file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if err := writeSnapshot(f, data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error writing storage file: %w", err)
+	}
+	if err := os.Rename(f.Name(), s.path); err != nil {
+		return fmt.Errorf("error replacing storage file: %w", err)
+	}
+	return nil
+}
+
+// Observation implements Storage
+func (s *FileStorage) Observation(ctx context.Context, date string) (*Observations, error) {
+	data, err := s.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return storedObservation(data, date)
+}
+
+// storedObservation returns a copy of the observation of date in data, the last one if it is duplicated
+func storedObservation(data *BOCData, date string) (*Observations, error) {
+	if data != nil {
+		for i := len(data.Observations) - 1; i >= 0; i-- {
+			if data.Observations[i].D == date {
+				obs := data.Observations[i]
+				return &obs, nil
+			}
+		}
+	}
+	return nil, &DataError{Date: date, Err: ErrNoData}
+}
+
+// loadStored makes the data of the storage the dataset in use, and reports
+// whether there was any. Corrupt data is logged and ignored, to be fetched again.
+func (b *bocInterests) loadStored(ctx context.Context) (bool, error) {
+	data, err := b.storage.Get(ctx)
+	if errors.Is(err, ErrCorruptSnapshot) {
+		b.logger.Warn("corrupt storage, fetching the data again", "error", err)
+		return false, nil
+	}
+	if err != nil || data == nil {
+		return false, err
+	}
+	b.logger.Info("data loaded from storage", "observations", len(data.Observations), "fetched_at", data.FetchedAt)
+	b.ds.Store(b.newDataset(ctx, data))
+	return true, nil
+}
+
+// store saves the data to the storage, if any. Failures are logged since the data is still usable.
+func (b *bocInterests) store(ctx context.Context, data *BOCData) {
+	if b.storage == nil {
+		return
+	}
+	if err := b.storage.Put(ctx, data); err != nil {
+		b.logger.Warn("storage write failed", "error", err)
+	}
+}
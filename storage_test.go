@@ -0,0 +1,92 @@
+package boc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorages(t *testing.T) {
+	storages := map[string]Storage{
+		"memory": NewMemoryStorage(),
+		"file":   NewFileStorage(filepath.Join(t.TempDir(), "boc.snapshot")),
+	}
+	for name, s := range storages {
+		t.Run(name, func(t *testing.T) {
+			a := assert.New(t)
+			ctx := context.Background()
+
+			data, err := s.Get(ctx)
+			a.NoError(err)
+			a.Nil(data)
+			_, err = s.Observation(ctx, "2022-05-24")
+			a.ErrorIs(err, ErrNoData)
+
+			require.NoError(t, s.Put(ctx, readFixture(t)))
+			data, err = s.Get(ctx)
+			a.NoError(err)
+			a.Equal(readFixture(t), data)
+
+			obs, err := s.Observation(ctx, "2022-05-24")
+			a.NoError(err)
+			a.Equal("2.57", obs.Yield2Year.V)
+			_, err = s.Observation(ctx, "2022-05-23")
+			a.ErrorIs(err, ErrNoData)
+		})
+	}
+}
+
+func TestFileStorageInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "boc.snapshot")
+	require.NoError(t, os.WriteFile(path, []byte("not a snapshot"), 0o600))
+	_, err := NewFileStorage(path).Get(context.Background())
+	assert.Error(t, err)
+}
+
+func TestWithStorage(t *testing.T) {
+	a := assert.New(t)
+	srv, calls := newFlakyServer(t, 0)
+	storage := NewMemoryStorage()
+
+	_, err := NewBOCInterests(WithBaseURL(srv.URL), WithStorage(storage))
+	a.NoError(err)
+	a.Equal(int32(1), calls.Load())
+	data, err := storage.Get(context.Background())
+	a.NoError(err)
+	a.Len(data.Observations, 8)
+
+	// the next client starts from the storage
+	b, err := NewBOCInterests(WithBaseURL(srv.URL), WithStorage(storage))
+	a.NoError(err)
+	a.Equal(int32(1), calls.Load())
+	obs, err := b.GetObservationForDate("2022-05-24")
+	a.NoError(err)
+	a.Equal("2.57", obs.Yield2Year.V)
+
+	a.NoError(b.Refresh(context.Background()))
+	a.Equal(int32(2), calls.Load())
+}
+
+func TestWithStorageCorrupt(t *testing.T) {
+	a := assert.New(t)
+	srv, calls := newFlakyServer(t, 0)
+	storage := NewFileStorage(filepath.Join(t.TempDir(), "boc.snapshot"))
+	require.NoError(t, storage.Put(context.Background(), readFixture(t)))
+	raw, err := os.ReadFile(storage.path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(storage.path, raw[:len(raw)-10], 0o600))
+
+	_, err = storage.Get(context.Background())
+	a.ErrorIs(err, ErrCorruptSnapshot)
+	_, err = NewBOCInterests(WithBaseURL(srv.URL), WithStorage(storage))
+	a.NoError(err)
+	a.Equal(int32(1), calls.Load(), "the corrupt data is fetched again")
+	data, err := storage.Get(context.Background())
+	a.NoError(err)
+	a.Len(data.Observations, 8)
+	a.False(data.FetchedAt.IsZero())
+}
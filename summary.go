@@ -0,0 +1,99 @@
+package boc
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// SummaryRow is the latest value of a series in a Summary
+type SummaryRow struct {
+	Series string
+	// Label is the English label of the series, see SeriesLabel
+	Label string
+	// Value is in percent
+	Value float64
+	// Change is the change in basis points since the previous value of the series,
+	// NaN for the first value
+	Change float64
+}
+
+// Summary is the daily summary of the latest observation: the value of each
+// series and its change since the previous date with data
+type Summary struct {
+	Date string
+	Rows []SummaryRow
+	// Alerts are the alerts to report with the summary, left for the caller to fill
+	Alerts []AlertEvent
+	// Attribution is the citation written at the end of the summary
+	Attribution Attribution
+}
+
+// Summarize returns the values of the latest observation of client and their
+// changes since the previous date with a value
+func Summarize(client Reader) (*Summary, error) {
+	seq, err := client.Between("", "")
+	if err != nil {
+		return nil, err
+	}
+	// the last two values of each series
+	last, prev := make([]Point, len(allSeries)), make([]Point, len(allSeries))
+	latest := ""
+	for date, obs := range seq {
+		latest = date
+		for c, key := range allSeries {
+			if v, ok := obs.Value(key); ok {
+				prev[c], last[c] = last[c], Point{Date: date, Value: v}
+			}
+		}
+	}
+	if latest == "" {
+		return nil, &DataError{Err: ErrNoData}
+	}
+	s := &Summary{Date: latest, Attribution: client.Attribution()}
+	for c, key := range allSeries {
+		if last[c].Date != latest {
+			continue
+		}
+		row := SummaryRow{Series: key, Label: seriesLabels[key], Value: last[c].Value, Change: math.NaN()}
+		if prev[c].Date != "" {
+			row.Change = float64(ToBps(last[c].Value - prev[c].Value).Round(2))
+		}
+		s.Rows = append(s.Rows, row)
+	}
+	return s, nil
+}
+
+// WriteText writes the summary as an aligned plain-text table, followed by the
+// alerts and the attribution
+func (s *Summary) WriteText(w io.Writer) error {
+	labelWidth, changeWidth := 0, 0
+	for _, row := range s.Rows {
+		labelWidth = max(labelWidth, len(row.Label))
+		changeWidth = max(changeWidth, len(FormatChange(row.Change)))
+	}
+	var sb strings.Builder
+	for _, row := range s.Rows {
+		fmt.Fprintf(&sb, "%-*s  %6.2f%%  %*s\n", labelWidth, row.Label, row.Value, changeWidth, FormatChange(row.Change))
+	}
+	if len(s.Alerts) > 0 {
+		sb.WriteString("\nAlerts:\n")
+		for _, event := range s.Alerts {
+			fmt.Fprintf(&sb, "- %s on %s\n", event.Name, event.Date)
+		}
+	}
+	if s.Attribution.Text != "" {
+		fmt.Fprintf(&sb, "\n%s\n", s.Attribution)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// FormatChange formats a change in basis points with its sign, e.g. +8 bps, or n/a if NaN
+func FormatChange(bps float64) string {
+	if math.IsNaN(bps) {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+g bps", bps)
+}
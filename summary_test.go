@@ -0,0 +1,70 @@
+package boc
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummary(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	s, err := Summarize(b)
+	require.NoError(t, err)
+	a.Equal("2022-06-01", s.Date)
+	a.Equal(b.Attribution(), s.Attribution)
+	require.Len(t, s.Rows, 11)
+	a.Equal(SummaryRow{Series: SeriesAverage1To3Year, Label: "1 to 3 year average yield", Value: 2.74, Change: 5}, s.Rows[0])
+	a.Equal(SummaryRow{Series: SeriesYield10Year, Label: "10 year benchmark yield", Value: 2.97, Change: 7}, s.Rows[8])
+	a.Equal(SeriesYieldRRB, s.Rows[10].Series)
+	a.Equal(5.0, s.Rows[10].Change)
+
+	data := readFixture(t)
+	data.Observations = data.Observations[:1]
+	b.ds.Store(b.newDataset(context.Background(), data))
+	s, err = Summarize(b)
+	require.NoError(t, err)
+	a.True(math.IsNaN(s.Rows[0].Change), "no previous value")
+
+	b.ds.Store(b.newDataset(context.Background(), &BOCData{}))
+	_, err = Summarize(b)
+	a.ErrorIs(err, ErrNoData)
+}
+
+func TestSummaryWriteText(t *testing.T) {
+	s := &Summary{
+		Date: "2022-06-01",
+		Rows: []SummaryRow{
+			{Series: SeriesYield2Year, Label: "2 year benchmark yield", Value: 2.73, Change: 5},
+			{Series: SeriesYield10Year, Label: "10 year benchmark yield", Value: 2.97, Change: -12.5},
+			{Series: SeriesYieldRRB, Label: "Real return bond yield", Value: 0.72, Change: math.NaN()},
+		},
+		Alerts:      []AlertEvent{{Name: "10y above 2.9%", Date: "2022-06-01"}},
+		Attribution: Attribution{Text: "Source: Bank of Canada.", TermsURL: "https://www.bankofcanada.ca/terms/"},
+	}
+	var sb strings.Builder
+	require.NoError(t, s.WriteText(&sb))
+	assert.Equal(t, strings.Join([]string{
+		"2 year benchmark yield     2.73%     +5 bps",
+		"10 year benchmark yield    2.97%  -12.5 bps",
+		"Real return bond yield     0.72%        n/a",
+		"",
+		"Alerts:",
+		"- 10y above 2.9% on 2022-06-01",
+		"",
+		"Source: Bank of Canada. https://www.bankofcanada.ca/terms/",
+		"",
+	}, "\n"), sb.String())
+}
+
+func TestFormatChange(t *testing.T) {
+	assert.Equal(t, "+8 bps", FormatChange(8))
+	assert.Equal(t, "-2.5 bps", FormatChange(-2.5))
+	assert.Equal(t, "+0 bps", FormatChange(0))
+	assert.Equal(t, "n/a", FormatChange(math.NaN()))
+}
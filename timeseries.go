@@ -0,0 +1,28 @@
+package boc
+
+import "time"
+
+// TimeSeries implements BOCInterests
+func (b *bocInterests) TimeSeries(seriesKey, start, end string, opts ...QueryOption) ([]time.Time, []float64, error) {
+	points, err := seriesBetween(b, seriesKey, start, end, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	times, values := splitPoints(points)
+	return times, values, nil
+}
+
+// splitPoints returns the dates of points, at midnight UTC, and their values
+func splitPoints(points []Point) ([]time.Time, []float64) {
+	times := make([]time.Time, 0, len(points))
+	values := make([]float64, 0, len(points))
+	for _, p := range points {
+		t, err := time.Parse("2006-01-02", p.Date)
+		if err != nil {
+			continue
+		}
+		times = append(times, t)
+		values = append(values, p.Value)
+	}
+	return times, values
+}
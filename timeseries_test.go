@@ -0,0 +1,26 @@
+package boc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeSeries(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+
+	times, values, err := b.TimeSeries(SeriesYieldRRB, "2022-05-26", "2022-05-30")
+	a.NoError(err)
+	a.Equal([]time.Time{
+		time.Date(2022, 5, 26, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 5, 30, 0, 0, 0, 0, time.UTC),
+	}, times)
+	a.Equal([]float64{0.57, 0.63}, values)
+
+	_, _, err = b.TimeSeries("foo", "", "")
+	a.ErrorIs(err, ErrUnknownSeries)
+	_, _, err = b.TimeSeries(SeriesYieldRRB, "foo", "")
+	a.ErrorIs(err, ErrInvalidDate)
+}
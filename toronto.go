@@ -0,0 +1,59 @@
+package boc
+
+import (
+	"sync"
+	"time"
+)
+
+// torontoLocation is the time zone of the Bank of Canada, nil if the time zone
+// database is not available, e.g. in a scratch container
+var torontoLocation = sync.OnceValue(func() *time.Location {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		return nil
+	}
+	return loc
+})
+
+// TorontoDay returns the calendar day of t in Toronto, where the Bank of Canada
+// publishes its data, at midnight UTC like the other calendar functions. Without
+// time zone database the Eastern Time rules in force since 2007 are applied.
+func TorontoDay(t time.Time) time.Time {
+	loc := torontoLocation()
+	if loc == nil {
+		loc = easternTime(t)
+	}
+	return truncateDay(t.In(loc))
+}
+
+// easternTime returns the zone of Eastern Time at t: daylight time from 2:00
+// on the second Sunday of March to 2:00 on the first Sunday of November
+func easternTime(t time.Time) *time.Location {
+	year := t.UTC().Year()
+	start := nthWeekday(year, time.March, time.Sunday, 2).Add(7 * time.Hour)
+	end := nthWeekday(year, time.November, time.Sunday, 1).Add(6 * time.Hour)
+	if !t.Before(start) && t.Before(end) {
+		return time.FixedZone("EDT", -4*60*60)
+	}
+	return time.FixedZone("EST", -5*60*60)
+}
+
+// Today implements BOCInterests
+func (b *bocInterests) Today(opts ...QueryOption) (*Observations, error) {
+	return b.observationOn(TorontoDay(b.now()), opts)
+}
+
+// Yesterday implements BOCInterests
+func (b *bocInterests) Yesterday(opts ...QueryOption) (*Observations, error) {
+	return b.observationOn(TorontoDay(b.now()).AddDate(0, 0, -1), opts)
+}
+
+// observationOn returns the observation of a day, whatever the date layout of the client
+func (b *bocInterests) observationOn(day time.Time, opts []QueryOption) (*Observations, error) {
+	q := newQuery(opts)
+	ds, err := b.fresh(q.context())
+	if err != nil {
+		return nil, err
+	}
+	return observationAt(ds, day.Format(time.DateOnly), q)
+}
@@ -0,0 +1,62 @@
+package boc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTorontoDay(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{name: "evening in Toronto is the next day in UTC", t: time.Date(2022, 6, 2, 2, 30, 0, 0, time.UTC), want: date(2022, 6, 1)},
+		{name: "daylight time", t: time.Date(2022, 6, 2, 4, 0, 0, 0, time.UTC), want: date(2022, 6, 2)},
+		{name: "standard time", t: time.Date(2022, 1, 11, 4, 30, 0, 0, time.UTC), want: date(2022, 1, 10)},
+		{name: "other zone", t: time.Date(2022, 6, 2, 8, 0, 0, 0, time.FixedZone("JST", 9*60*60)), want: date(2022, 6, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TorontoDay(tt.t))
+			assert.Equal(t, tt.want, truncateDay(tt.t.In(easternTime(tt.t))), "without time zone database")
+		})
+	}
+}
+
+func TestEasternTime(t *testing.T) {
+	a := assert.New(t)
+	zone := func(t time.Time) string {
+		name, _ := t.In(easternTime(t)).Zone()
+		return name
+	}
+	a.Equal("EST", zone(time.Date(2022, 3, 13, 6, 59, 0, 0, time.UTC)))
+	a.Equal("EDT", zone(time.Date(2022, 3, 13, 7, 0, 0, 0, time.UTC)))
+	a.Equal("EDT", zone(time.Date(2022, 11, 6, 5, 59, 0, 0, time.UTC)))
+	a.Equal("EST", zone(time.Date(2022, 11, 6, 6, 0, 0, 0, time.UTC)))
+}
+
+func TestTodayYesterday(t *testing.T) {
+	a := assert.New(t)
+	b := newTestBOC(t)
+	b.dateLayout = "DD/MM/YYYY"
+	b.now = func() time.Time { return time.Date(2022, 6, 1, 1, 0, 0, 0, time.UTC) }
+
+	obs, err := b.Today()
+	require.NoError(t, err)
+	a.Equal("2022-05-31", obs.D, "still May 31 in Toronto")
+	obs, err = b.Yesterday()
+	require.NoError(t, err)
+	a.Equal("2022-05-30", obs.D)
+
+	b.now = func() time.Time { return time.Date(2022, 5, 30, 12, 0, 0, 0, time.UTC) }
+	_, err = b.Yesterday()
+	a.ErrorIs(err, ErrNoData)
+	obs, err = b.Yesterday(ForwardFill())
+	require.NoError(t, err)
+	a.Equal("2022-05-29", obs.D)
+	a.Equal("2.61", obs.Yield2Year.V)
+}
@@ -0,0 +1,89 @@
+package boc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/clauderoy790/bank-of-canada-interests-rates/cache"
+)
+
+func TestWithTracerProvider(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantSpans []string
+		wantErr   bool
+	}{
+		{
+			name:      "success",
+			status:    http.StatusOK,
+			wantSpans: []string{"boc.decode", "boc.fetch", "boc.index"},
+		},
+		{
+			name:      "error",
+			status:    http.StatusInternalServerError,
+			wantSpans: []string{"boc.fetch"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			recorder := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			srv := newTestServer(t, tt.status)
+			b := newBOCInterests(WithTracerProvider(tp), WithBaseURL(srv.URL))
+
+			err := b.load(context.Background())
+			a.Equal(tt.wantErr, err != nil)
+
+			names := make([]string, 0)
+			for _, span := range recorder.Ended() {
+				names = append(names, span.Name())
+				if span.Name() == "boc.fetch" && tt.wantErr {
+					a.Equal(codes.Error, span.Status().Code)
+				}
+			}
+			a.Equal(tt.wantSpans, names)
+		})
+	}
+}
+
+func TestCacheSpans(t *testing.T) {
+	a := assert.New(t)
+	srv := newTestServer(t, http.StatusOK)
+	store := cache.NewMemory()
+	url := srv.URL + "/observations/group/" + bondYieldsGroup + "/json"
+
+	// load returns the attributes of the cache spans of a load reading store
+	load := func() map[string][]attribute.KeyValue {
+		recorder := tracetest.NewSpanRecorder()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		b := newBOCInterests(WithTracerProvider(tp), WithBaseURL(srv.URL), WithCache(store, 0))
+		require.NoError(t, b.load(context.Background()))
+		spans := make(map[string][]attribute.KeyValue)
+		for _, span := range recorder.Ended() {
+			if strings.HasPrefix(span.Name(), "boc.cache.") {
+				spans[span.Name()] = span.Attributes()
+			}
+		}
+		return spans
+	}
+
+	miss := load()
+	a.ElementsMatch([]attribute.KeyValue{attribute.String("boc.cache_key", url), attribute.Bool("boc.cache_hit", false)}, miss["boc.cache.get"])
+	a.Equal([]attribute.KeyValue{attribute.String("boc.cache_key", url)}, miss["boc.cache.set"])
+
+	hit := load()
+	a.ElementsMatch([]attribute.KeyValue{attribute.String("boc.cache_key", url), attribute.Bool("boc.cache_hit", true)}, hit["boc.cache.get"])
+	a.NotContains(hit, "boc.cache.set")
+}
@@ -0,0 +1,51 @@
+package boc
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// TransportOptions tune the connections to the Valet API, see WithTransportOptions.
+// The zero values keep the settings of http.DefaultTransport.
+type TransportOptions struct {
+	// MaxIdleConns is the maximum number of idle connections kept across all hosts
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept per host
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the number of connections per host, including the active ones
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept before being closed
+	IdleConnTimeout time.Duration
+	// DisableKeepAlives opens a new connection for each request
+	DisableKeepAlives bool
+	// DisableHTTP2 forces HTTP/1.1, e.g. behind proxies mishandling HTTP/2
+	DisableHTTP2 bool
+}
+
+// transport returns a clone of http.DefaultTransport with the options applied
+func (o TransportOptions) transport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if o.MaxIdleConns != 0 {
+		t.MaxIdleConns = o.MaxIdleConns
+	}
+	if o.MaxIdleConnsPerHost != 0 {
+		t.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+	}
+	if o.MaxConnsPerHost != 0 {
+		t.MaxConnsPerHost = o.MaxConnsPerHost
+	}
+	if o.IdleConnTimeout != 0 {
+		t.IdleConnTimeout = o.IdleConnTimeout
+	}
+	t.DisableKeepAlives = o.DisableKeepAlives
+	if o.DisableHTTP2 {
+		// a non-nil empty map disables the HTTP/2 upgrade
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+		if t.TLSClientConfig != nil {
+			t.TLSClientConfig.NextProtos = nil
+		}
+	}
+	return t
+}
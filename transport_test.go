@@ -0,0 +1,58 @@
+package boc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportOptions(t *testing.T) {
+	a := assert.New(t)
+	def := http.DefaultTransport.(*http.Transport)
+
+	tr := TransportOptions{}.transport()
+	a.Equal(def.MaxIdleConns, tr.MaxIdleConns)
+	a.Equal(def.IdleConnTimeout, tr.IdleConnTimeout)
+	a.True(tr.ForceAttemptHTTP2)
+	a.Nil(tr.TLSNextProto)
+
+	tr = TransportOptions{
+		MaxIdleConns:        200,
+		MaxIdleConnsPerHost: 20,
+		MaxConnsPerHost:     50,
+		IdleConnTimeout:     time.Minute,
+		DisableKeepAlives:   true,
+		DisableHTTP2:        true,
+	}.transport()
+	a.Equal(200, tr.MaxIdleConns)
+	a.Equal(20, tr.MaxIdleConnsPerHost)
+	a.Equal(50, tr.MaxConnsPerHost)
+	a.Equal(time.Minute, tr.IdleConnTimeout)
+	a.True(tr.DisableKeepAlives)
+	a.False(tr.ForceAttemptHTTP2)
+	a.NotNil(tr.TLSNextProto)
+	a.Empty(tr.TLSNextProto)
+	a.NotSame(def, tr)
+	a.Equal(100, def.MaxIdleConns, "the default transport is unchanged")
+}
+
+func TestWithTransportOptions(t *testing.T) {
+	srv := httptest.NewUnstartedServer(newTestServer(t, http.StatusOK).Config.Handler)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	var protos []string
+	hooks := Hooks{OnResponse: func(_ *http.Request, resp *http.Response, _ time.Duration) { protos = append(protos, resp.Proto) }}
+
+	for _, disableHTTP2 := range []bool{false, true} {
+		b := newBOCInterests(WithBaseURL(srv.URL), WithHooks(hooks), WithTransportOptions(TransportOptions{DisableHTTP2: disableHTTP2}))
+		b.httpClient.Transport.(*http.Transport).TLSClientConfig = srv.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+		require.NoError(t, b.load(context.Background()))
+	}
+	assert.Equal(t, []string{"HTTP/2.0", "HTTP/1.1"}, protos)
+}
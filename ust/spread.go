@@ -0,0 +1,56 @@
+package ust
+
+import (
+	"context"
+	"fmt"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// Source names of the Spreads registry
+const (
+	SourceCanada = "boc"
+	SourceUS     = "ust"
+)
+
+// Spreads computes the spreads of the Government of Canada benchmark yields
+// over the US Treasury par yields of the same maturity
+type Spreads struct {
+	sources *boc.Sources
+}
+
+// NewSpreads returns the spreads of the yields of canada, e.g. the client of
+// boc.NewBOCInterests, over the ones of us
+func NewSpreads(canada boc.RateSource, us *Treasury) *Spreads {
+	sources := boc.NewSources()
+	sources.Register(SourceCanada, canada)
+	sources.Register(SourceUS, us)
+	return &Spreads{sources: sources}
+}
+
+// Refresh refreshes both sources
+func (s *Spreads) Refresh(ctx context.Context) error {
+	return s.sources.Refresh(ctx)
+}
+
+// Sources returns the registry of both sources, e.g. to compare other series
+func (s *Spreads) Sources() *boc.Sources {
+	return s.sources
+}
+
+// CanadaUSSpread returns the Canadian benchmark yield minus the US Treasury par
+// yield of a maturity in years, e.g. 10, for a formatted date, e.g. "2022-06-01".
+// The long-term Canadian benchmark is compared with the 30 year Treasury.
+func (s *Spreads) CanadaUSSpread(tenor float64, date string) (boc.Bps, error) {
+	us, ok := SeriesForTenor(tenor)
+	if !ok {
+		return 0, fmt.Errorf("%w: no benchmark yield with a maturity of %g years", boc.ErrUnknownSeries, tenor)
+	}
+	var canada string
+	for _, key := range boc.AllSeries() {
+		if years, ok := boc.SeriesTenor(key); ok && years == tenor {
+			canada = key
+		}
+	}
+	return s.sources.Spread(boc.SourceSeries{Source: SourceCanada, Series: canada}, boc.SourceSeries{Source: SourceUS, Series: us}, date)
+}
@@ -0,0 +1,32 @@
+package ust
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+	"github.com/clauderoy790/bank-of-canada-interests-rates/internal/testutil"
+)
+
+func TestCanadaUSSpread(t *testing.T) {
+	a := assert.New(t)
+	us, _ := newTestTreasury(t)
+	spreads := NewSpreads(testutil.NewClient(t), us)
+	require.NoError(t, spreads.Refresh(context.Background()))
+	a.Equal([]string{SourceCanada, SourceUS}, spreads.Sources().Names())
+
+	spread, err := spreads.CanadaUSSpread(10, "2022-06-01")
+	require.NoError(t, err)
+	a.Equal(boc.Bps(3), spread)
+	spread, err = spreads.CanadaUSSpread(2, "2022-05-31")
+	require.NoError(t, err)
+	a.Equal(boc.Bps(15), spread)
+
+	_, err = spreads.CanadaUSSpread(4, "2022-06-01")
+	a.ErrorIs(err, boc.ErrUnknownSeries)
+	_, err = spreads.CanadaUSSpread(10, "2022-05-30")
+	a.ErrorIs(err, boc.ErrNoData)
+}
@@ -0,0 +1,198 @@
+// Package ust is a boc.RateSource of the US Treasury daily par yield curve
+// rates, so that Government of Canada and US Treasury yields are compared
+// without another library, e.g. with CanadaUSSpread.
+package ust
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+// DefaultBaseURL is the CSV download of the daily Treasury rates
+const DefaultBaseURL = "https://home.treasury.gov/resource-center/data-chart-center/interest-rates/daily-treasury-rates.csv"
+
+// Keys of the par yield series, as named by the columns of the Treasury CSV
+const (
+	Series1Month = "1 Mo"
+	Series3Month = "3 Mo"
+	Series6Month = "6 Mo"
+	Series1Year  = "1 Yr"
+	Series2Year  = "2 Yr"
+	Series3Year  = "3 Yr"
+	Series5Year  = "5 Yr"
+	Series7Year  = "7 Yr"
+	Series10Year = "10 Yr"
+	Series20Year = "20 Yr"
+	Series30Year = "30 Yr"
+)
+
+// tenors are the par yield series by maturity in years, for the maturities of
+// the Government of Canada benchmark yields
+var tenors = map[float64]string{
+	2:  Series2Year,
+	3:  Series3Year,
+	5:  Series5Year,
+	7:  Series7Year,
+	10: Series10Year,
+	30: Series30Year,
+}
+
+// SeriesForTenor returns the par yield series of a maturity in years, and
+// false if no benchmark yield of Canada has that maturity
+func SeriesForTenor(years float64) (string, bool) {
+	key, ok := tenors[years]
+	return key, ok
+}
+
+// Treasury fetches the daily par yield curve rates of the US Treasury. It is
+// safe for concurrent use.
+type Treasury struct {
+	baseURL    string
+	httpClient *http.Client
+	from, to   int
+	records    atomic.Pointer[[]boc.Record]
+}
+
+var _ boc.RateSource = (*Treasury)(nil)
+
+// Option configures the source returned by New
+type Option func(*Treasury)
+
+// WithBaseURL overrides the CSV endpoint, e.g. for tests
+func WithBaseURL(baseURL string) Option {
+	return func(t *Treasury) {
+		t.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithHTTPClient makes the source send its requests with client instead of http.DefaultClient
+func WithHTTPClient(client *http.Client) Option {
+	return func(t *Treasury) {
+		t.httpClient = client
+	}
+}
+
+// WithYears fetches the rates from year from to year to, one request per year.
+// By default only the current year is fetched.
+func WithYears(from, to int) Option {
+	return func(t *Treasury) {
+		t.from, t.to = from, to
+	}
+}
+
+// New returns a source of the US Treasury rates. It has no data until Refresh is called.
+func New(opts ...Option) *Treasury {
+	year := time.Now().Year()
+	t := &Treasury{baseURL: DefaultBaseURL, httpClient: http.DefaultClient, from: year, to: year}
+	for _, opt := range opts {
+		opt(t)
+	}
+	records := []boc.Record{}
+	t.records.Store(&records)
+	return t
+}
+
+// Refresh implements boc.RateSource. The records in use are replaced only if
+// every year is fetched.
+func (t *Treasury) Refresh(ctx context.Context) error {
+	var records []boc.Record
+	for year := t.from; year <= t.to; year++ {
+		fetched, err := t.fetchYear(ctx, year)
+		if err != nil {
+			return fmt.Errorf("error fetching the Treasury rates of %d: %w", year, err)
+		}
+		records = append(records, fetched...)
+	}
+	slices.SortFunc(records, func(a, b boc.Record) int { return strings.Compare(a.Date, b.Date) })
+	records = slices.CompactFunc(records, func(a, b boc.Record) bool { return a.Date == b.Date })
+	t.records.Store(&records)
+	return nil
+}
+
+// RecordFor implements boc.RateSource
+func (t *Treasury) RecordFor(date string) (boc.Record, bool) {
+	records := *t.records.Load()
+	i, ok := slices.BinarySearchFunc(records, date, func(r boc.Record, date string) int { return strings.Compare(r.Date, date) })
+	if !ok {
+		return boc.Record{}, false
+	}
+	return records[i], true
+}
+
+// Records implements boc.RateSource
+func (t *Treasury) Records() []boc.Record {
+	return slices.Clone(*t.records.Load())
+}
+
+// Metadata implements boc.RateSource
+func (t *Treasury) Metadata() boc.SourceMetadata {
+	series := make(map[string]boc.Detail, len(tenors))
+	for _, key := range []string{Series1Month, Series3Month, Series6Month, Series1Year, Series2Year,
+		Series3Year, Series5Year, Series7Year, Series10Year, Series20Year, Series30Year} {
+		series[key] = boc.Detail{Label: key, Description: key + " Treasury par yield", Unit: boc.UnitPercent}
+	}
+	return boc.SourceMetadata{
+		Name:        "U.S. Department of the Treasury",
+		Description: "Daily Treasury Par Yield Curve Rates",
+		Link:        "https://home.treasury.gov/policy-issues/financing-the-government/interest-rate-statistics",
+		Attribution: boc.Attribution{
+			Text:     "Source: U.S. Department of the Treasury.",
+			TermsURL: "https://home.treasury.gov/subfooter/site-policies-and-notices",
+		},
+		Series: series,
+	}
+}
+
+func (t *Treasury) fetchYear(ctx context.Context, year int) ([]boc.Record, error) {
+	url := fmt.Sprintf("%s/%d/all?type=daily_treasury_yield_curve&field_tdr_date_value=%d&_format=csv", t.baseURL, year, year)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &boc.DataError{StatusCode: resp.StatusCode, Err: boc.ErrBadStatus}
+	}
+	return parseCSV(resp.Body)
+}
+
+// parseCSV reads the records of a Treasury CSV, whose first column is the
+// date as MM/DD/YYYY and the others the yields, empty when not published
+func parseCSV(r io.Reader) ([]boc.Record, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	header := rows[0]
+	records := make([]boc.Record, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		day, err := time.Parse("01/02/2006", row[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", boc.ErrInvalidDate, row[0])
+		}
+		record := boc.Record{Date: day.Format(time.DateOnly), Values: make(map[string]boc.Val, len(row)-1)}
+		for i, field := range row[1:] {
+			if v := (boc.Val{V: strings.TrimSpace(field)}); v.Valid() {
+				record.Values[header[i+1]] = v
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
@@ -0,0 +1,76 @@
+package ust
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boc "github.com/clauderoy790/bank-of-canada-interests-rates"
+)
+
+const testCSV = `Date,"1 Mo","2 Mo","3 Mo","6 Mo","1 Yr","2 Yr","3 Yr","5 Yr","7 Yr","10 Yr","20 Yr","30 Yr"
+06/01/2022,0.78,,1.21,1.67,2.12,2.66,2.82,2.92,2.94,2.94,3.33,3.09
+05/31/2022,0.73,,1.16,1.64,2.08,2.53,2.68,2.81,2.85,2.85,3.28,3.07
+`
+
+func newTestTreasury(t *testing.T) (*Treasury, *[]string) {
+	t.Helper()
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path != "/2022/all" {
+			w.Write([]byte(`Date,"2 Yr"` + "\n"))
+			return
+		}
+		w.Write([]byte(testCSV))
+	}))
+	t.Cleanup(srv.Close)
+	return New(WithBaseURL(srv.URL), WithYears(2021, 2022)), &paths
+}
+
+func TestTreasury(t *testing.T) {
+	a := assert.New(t)
+	us, paths := newTestTreasury(t)
+	a.Empty(us.Records())
+	require.NoError(t, us.Refresh(context.Background()))
+	a.Equal([]string{"/2021/all", "/2022/all"}, *paths)
+
+	records := us.Records()
+	require.Len(t, records, 2)
+	a.Equal("2022-05-31", records[0].Date)
+	a.Equal("2022-06-01", records[1].Date)
+	v, ok := records[1].Value(Series10Year)
+	a.True(ok)
+	a.Equal(2.94, v)
+	a.False(records[1].Has("2 Mo"))
+	record, ok := us.RecordFor("2022-05-31")
+	a.True(ok)
+	a.Equal(records[0], record)
+	_, ok = us.RecordFor("2022-05-30")
+	a.False(ok)
+
+	m := us.Metadata()
+	a.Equal("U.S. Department of the Treasury", m.Name)
+	a.Equal(boc.UnitPercent, m.Series[Series30Year].Unit)
+}
+
+func TestTreasuryErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/2022/all" {
+			w.Write([]byte("Date,\"2 Yr\"\n2022-06-01,2.66\n"))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	err := New(WithBaseURL(srv.URL), WithYears(2021, 2021)).Refresh(context.Background())
+	assert.ErrorIs(t, err, boc.ErrBadStatus)
+	assert.ErrorContains(t, err, "error fetching the Treasury rates of 2021")
+	err = New(WithBaseURL(srv.URL), WithYears(2022, 2022)).Refresh(context.Background())
+	assert.ErrorIs(t, err, boc.ErrInvalidDate)
+}
@@ -0,0 +1,31 @@
+package boc
+
+import (
+	"net/http"
+	"time"
+)
+
+// lastModified returns the Last-Modified of a response header, or else its
+// Date, and the zero time if neither is valid
+func lastModified(header http.Header) time.Time {
+	for _, key := range []string{"Last-Modified", "Date"} {
+		if t, err := http.ParseTime(header.Get(key)); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// LastUpdated implements BOCInterests
+func (b *bocInterests) LastUpdated() time.Time {
+	return b.current().meta.LastModified
+}
+
+// LatestDate implements BOCInterests
+func (b *bocInterests) LatestDate() string {
+	ds := b.current()
+	if len(ds.dates) == 0 {
+		return ""
+	}
+	return ds.dates[len(ds.dates)-1]
+}
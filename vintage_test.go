@@ -0,0 +1,46 @@
+package boc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastUpdated(t *testing.T) {
+	a := assert.New(t)
+	data, err := os.ReadFile("testdata/bond_yields_all.json")
+	require.NoError(t, err)
+	modified := time.Date(2022, 6, 1, 20, 30, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+
+	b := newBOCInterests(WithBaseURL(srv.URL))
+	require.NoError(t, b.load(context.Background()))
+	a.True(modified.Equal(b.LastUpdated()))
+	a.Equal("2022-06-01", b.LatestDate())
+
+	rs, err := b.Query().Series(SeriesYield2Year).Run()
+	require.NoError(t, err)
+	a.Equal("2022-06-01", rs.LatestDate)
+	a.True(modified.Equal(rs.LastUpdated))
+}
+
+func TestLastModifiedHeader(t *testing.T) {
+	a := assert.New(t)
+	date := time.Date(2022, 6, 2, 8, 0, 0, 0, time.UTC)
+	a.True(date.Equal(lastModified(http.Header{"Date": {date.Format(http.TimeFormat)}})))
+	a.True(lastModified(http.Header{"Last-Modified": {"yesterday"}}).IsZero())
+	a.True(lastModified(nil).IsZero())
+
+	merged := mergeData(&BOCData{LastModified: date}, &BOCData{})
+	a.True(date.Equal(merged.LastModified), "a payload served from the cache keeps the known vintage")
+}
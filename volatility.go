@@ -0,0 +1,54 @@
+package boc
+
+import (
+	"fmt"
+	"math"
+)
+
+// Volatility returns the rolling standard deviation of the daily changes of a
+// series of client over window changes
+func Volatility(client ObservationReader, seriesKey string, window int) ([]BpsPoint, error) {
+	if err := checkSeries(seriesKey); err != nil {
+		return nil, err
+	}
+	if window < 2 {
+		return nil, fmt.Errorf("volatility window must be at least 2 changes, got %d", window)
+	}
+	points, err := seriesBetween(client, seriesKey, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return volatility(changes(points), window), nil
+}
+
+// changes returns the changes between consecutive points, each dated with the later point
+func changes(points []Point) []BpsPoint {
+	if len(points) < 2 {
+		return []BpsPoint{}
+	}
+	diffs := make([]BpsPoint, 0, len(points)-1)
+	for i := 1; i < len(points); i++ {
+		diffs = append(diffs, BpsPoint{Date: points[i].Date, Value: ToBps(points[i].Value - points[i-1].Value)})
+	}
+	return diffs
+}
+
+// volatility returns the sample standard deviation of every window of changes,
+// dated with the last change of the window
+func volatility(diffs []BpsPoint, window int) []BpsPoint {
+	vols := make([]BpsPoint, 0)
+	for i := window; i <= len(diffs); i++ {
+		values := diffs[i-window : i]
+		mean := 0.0
+		for _, p := range values {
+			mean += float64(p.Value)
+		}
+		mean /= float64(window)
+		variance := 0.0
+		for _, p := range values {
+			variance += (float64(p.Value) - mean) * (float64(p.Value) - mean)
+		}
+		vols = append(vols, BpsPoint{Date: values[window-1].Date, Value: Bps(math.Sqrt(variance / float64(window-1)))})
+	}
+	return vols
+}
@@ -0,0 +1,39 @@
+package boc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChanges(t *testing.T) {
+	a := assert.New(t)
+	diffs := changes([]Point{{"d1", 2}, {"d2", 2.1}, {"d3", 2.05}})
+	a.Equal([]BpsPoint{{"d2", 10}, {"d3", -5}}, diffs, "the changes are exact basis points")
+
+	a.Empty(changes([]Point{{"d1", 2}}))
+}
+
+func TestVolatility(t *testing.T) {
+	a := assert.New(t)
+	vols := volatility([]BpsPoint{{"d1", 1}, {"d2", 3}, {"d3", 5}, {"d4", 5}}, 3)
+	a.Len(vols, 2)
+	a.Equal("d3", vols[0].Date)
+	a.InDelta(2, float64(vols[0].Value), 1e-9)
+	a.Equal("d4", vols[1].Date)
+	a.InDelta(math.Sqrt(4.0/3), float64(vols[1].Value), 1e-9)
+	a.Empty(volatility([]BpsPoint{{"d1", 1}}, 2))
+
+	b := newTestBOC(t)
+	vols, err := Volatility(b, SeriesYield2Year, 3)
+	a.NoError(err)
+	diffs := changes(b.current().points(SeriesYield2Year, 0, 8))
+	a.Len(vols, len(diffs)-2)
+	a.Equal("2022-06-01", vols[len(vols)-1].Date)
+
+	_, err = Volatility(b, "foo", 3)
+	a.ErrorIs(err, ErrUnknownSeries)
+	_, err = Volatility(b, SeriesYield2Year, 1)
+	a.Error(err)
+}
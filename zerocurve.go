@@ -0,0 +1,91 @@
+package boc
+
+import (
+	"math"
+	"sort"
+)
+
+// ZeroCurve is a zero-coupon curve bootstrapped from the benchmark par yields
+type ZeroCurve struct {
+	Date string
+	// Tenors are the maturities in years, every half year up to the longest benchmark
+	Tenors []float64
+	// ZeroRates are the zero-coupon rates in percent of each tenor, compounded semi-annually
+	ZeroRates []float64
+	// DiscountFactors are the discount factors of each tenor
+	DiscountFactors []float64
+}
+
+// Bootstrap converts the curve, read as semi-annual par yields, into a zero
+// curve. The par yields are interpolated every half year, so each node
+// prices a bond paying the par yield as coupon at par.
+func (c *Curve) Bootstrap() *ZeroCurve {
+	z := &ZeroCurve{Date: c.Date}
+	if len(c.Tenors) == 0 {
+		return z
+	}
+	n := int(math.Round(c.Tenors[len(c.Tenors)-1] * 2))
+	annuity := 0.0
+	for k := 1; k <= n; k++ {
+		years := float64(k) / 2
+		coupon := c.Yield(years) / 200
+		df := (1 - coupon*annuity) / (1 + coupon)
+		annuity += df
+		z.Tenors = append(z.Tenors, years)
+		z.DiscountFactors = append(z.DiscountFactors, df)
+		z.ZeroRates = append(z.ZeroRates, 200*(math.Pow(df, -1/(2*years))-1))
+	}
+	return z
+}
+
+// DiscountFactor returns the discount factor for a maturity in years. It is
+// log-linearly interpolated between tenors, and uses the zero rate of the
+// closest tenor outside of them.
+func (z *ZeroCurve) DiscountFactor(years float64) float64 {
+	n := len(z.Tenors)
+	if years <= 0 || n == 0 {
+		return 1
+	}
+	if years <= z.Tenors[0] {
+		return math.Pow(z.DiscountFactors[0], years/z.Tenors[0])
+	}
+	if years >= z.Tenors[n-1] {
+		return math.Pow(z.DiscountFactors[n-1], years/z.Tenors[n-1])
+	}
+	i := sort.SearchFloat64s(z.Tenors, years)
+	if z.Tenors[i] == years {
+		return z.DiscountFactors[i]
+	}
+	w := (years - z.Tenors[i-1]) / (z.Tenors[i] - z.Tenors[i-1])
+	return math.Exp((1-w)*math.Log(z.DiscountFactors[i-1]) + w*math.Log(z.DiscountFactors[i]))
+}
+
+// ZeroRate returns the zero rate in percent, compounded semi-annually, for a maturity in years
+func (z *ZeroCurve) ZeroRate(years float64) float64 {
+	if years <= 0 {
+		if len(z.ZeroRates) == 0 {
+			return 0
+		}
+		return z.ZeroRates[0]
+	}
+	return 200 * (math.Pow(z.DiscountFactor(years), -1/(2*years)) - 1)
+}
+
+// PV returns the present value of cashflows discounted with the zero curve
+func (z *ZeroCurve) PV(cashflows []Cashflow) float64 {
+	pv := 0.0
+	for _, cf := range cashflows {
+		pv += cf.Amount * z.DiscountFactor(cf.Years)
+	}
+	return pv
+}
+
+// BootstrapCurve returns the zero-coupon curve bootstrapped from the benchmark
+// yields of client for a date
+func BootstrapCurve(client ObservationReader, date string, opts ...QueryOption) (*ZeroCurve, error) {
+	c, err := YieldCurve(client, date, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c.Bootstrap(), nil
+}
@@ -0,0 +1,55 @@
+package boc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootstrap(t *testing.T) {
+	a := assert.New(t)
+
+	// a flat par curve has the same zero rates
+	flat := (&Curve{Tenors: []float64{2, 10}, Yields: []float64{3, 3}}).Bootstrap()
+	a.Len(flat.Tenors, 20)
+	a.Equal(0.5, flat.Tenors[0])
+	a.Equal(10.0, flat.Tenors[19])
+	for i, years := range flat.Tenors {
+		a.InDelta(3, flat.ZeroRates[i], 1e-9)
+		a.InDelta(math.Pow(1.015, -2*years), flat.DiscountFactors[i], 1e-12)
+	}
+
+	// an upward sloping par curve has zero rates above the par yields
+	c := &Curve{Tenors: []float64{2, 5, 10, 30}, Yields: []float64{2, 3, 3.5, 4}}
+	z := c.Bootstrap()
+	a.Len(z.Tenors, 60)
+	for i, years := range z.Tenors {
+		a.GreaterOrEqual(z.ZeroRates[i], c.Yield(years)-1e-9)
+
+		// each node prices its par bond at par
+		price := 0.0
+		for j := 0; j <= i; j++ {
+			price += c.Yield(years) / 2 * z.DiscountFactors[j]
+		}
+		price += 100 * z.DiscountFactors[i]
+		a.InDelta(100, price, 1e-9)
+	}
+	a.InDelta(z.ZeroRates[19], z.ZeroRate(10), 1e-9)
+
+	empty := (&Curve{}).Bootstrap()
+	a.Empty(empty.Tenors)
+	a.Equal(1.0, empty.DiscountFactor(5))
+}
+
+func TestZeroCurveInterpolation(t *testing.T) {
+	a := assert.New(t)
+	z := (&Curve{Tenors: []float64{2, 10}, Yields: []float64{3, 3}}).Bootstrap()
+
+	a.Equal(1.0, z.DiscountFactor(0))
+	a.InDelta(math.Pow(1.015, -0.5), z.DiscountFactor(0.25), 1e-12)
+	a.InDelta(math.Pow(1.015, -2*1.25), z.DiscountFactor(1.25), 1e-12)
+	a.InDelta(math.Pow(1.015, -2*15), z.DiscountFactor(15), 1e-12)
+	a.InDelta(3, z.ZeroRate(15), 1e-9)
+	a.InDelta(3, z.ZeroRate(0), 1e-9)
+}